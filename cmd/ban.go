@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"github.com/jedib0t/go-pretty/v6/table"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/rpc"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"time"
+)
+
+var (
+	banAddParams = &pb.Ban{}
+	banDelParams = &pb.BanID{}
+	banExpires   string
+	banDuration  string
+)
+
+func renderBans(bans []store.Ban, title string) {
+	t := defaultTable(title)
+	t.AppendHeader(table.Row{"id", "kind", "value", "reason", "expires", "created"})
+	for _, b := range bans {
+		expires := "never"
+		if !b.Expires.IsZero() {
+			expires = b.Expires.Local().Format(eventTimeFormat)
+		}
+		t.AppendRow(table.Row{b.BanID, b.Kind, b.Value, b.Reason, expires,
+			b.CreatedOn.Local().Format(eventTimeFormat)})
+	}
+	t.SortBy([]table.SortBy{{
+		Name: "id",
+	}})
+	t.Render()
+}
+
+// banCmd represents the ban admin commands
+var banCmd = &cobra.Command{
+	Use:               "ban",
+	Short:             "ban commands",
+	Long:              `Manage IP, CIDR, ASN and country bans`,
+	PersistentPreRunE: connectRPC,
+}
+
+// banListCmd lists every ban
+var banListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List bans",
+	Long:  `List bans`,
+	Run: func(cmd *cobra.Command, args []string) {
+		stream, err := cl.BanAll(context.Background(), &emptypb.Empty{})
+		if err != nil {
+			log.Fatalf("Failed to fetch bans: %v", err)
+			return
+		}
+		var bans []store.Ban
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("Failed to receive ban: %v", err)
+			}
+			bans = append(bans, rpc.PBToBan(in))
+		}
+		renderBans(bans, "List of all bans")
+	},
+}
+
+// banAddCmd adds a new ban
+var banAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a ban",
+	Long: `Add a ban. Bans without an expiry or duration never expire.
+
+  mika ban add -k cidr -v 10.0.0.0/8 -r "Abusive network" --duration 30d
+  mika ban add -k asn -v AS64496
+  mika ban add -k country -v XX`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case banExpires != "":
+			t, err := parseEventTime(banExpires)
+			if err != nil {
+				return err
+			}
+			banAddParams.Expires = timestamppb.New(t)
+		case banDuration != "":
+			d, err := util.ParseDuration(banDuration)
+			if err != nil {
+				return err
+			}
+			banAddParams.Expires = timestamppb.New(time.Now().Add(d))
+		}
+		b, err := cl.BanAdd(context.Background(), banAddParams)
+		if err != nil {
+			log.Fatalf("Failed to add ban: %v", err)
+		}
+		renderBans([]store.Ban{rpc.PBToBan(b)}, "Ban added successfully")
+		return nil
+	},
+}
+
+// banDeleteCmd lifts a ban
+var banDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a ban",
+	Long:  `Delete a ban, lifting it immediately`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if banDelParams.BanId == 0 {
+			log.Fatalf("Must supply a ban id")
+			return
+		}
+		if _, err := cl.BanDelete(context.Background(), banDelParams); err != nil {
+			log.Fatalf("Failed to delete ban: %v", err)
+		}
+		log.Infof("Ban deleted successfully")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(banCmd)
+	banCmd.AddCommand(banListCmd)
+	banCmd.AddCommand(banAddCmd)
+	banCmd.AddCommand(banDeleteCmd)
+
+	banAddCmd.Flags().StringVarP(&banAddParams.Kind, "kind", "k", store.BanIP, "What to ban, ip|cidr|asn|country|user")
+	banAddCmd.Flags().StringVarP(&banAddParams.Value, "value", "v", "", "IP, CIDR range, AS number or ISO country code to ban")
+	banAddCmd.Flags().StringVarP(&banAddParams.Reason, "reason", "r", "", "Reason sent to the banned client")
+	banAddCmd.Flags().StringVarP(&banExpires, "expires", "e", "", "Expiry time, local \"2006-01-02 15:04\" or RFC3339")
+	banAddCmd.Flags().StringVarP(&banDuration, "duration", "d", "", "Duration of the ban when no expiry is set, eg: 30d")
+
+	banDeleteCmd.Flags().Uint32VarP(&banDelParams.BanId, "id", "i", 0, "Ban ID")
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"github.com/jedib0t/go-pretty/v6/table"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/rpc"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"strings"
+	"time"
+)
+
+const eventTimeFormat = "2006-01-02 15:04"
+
+var (
+	eventAddParams = &pb.Event{}
+	eventDelParams = &pb.EventID{}
+	eventRoles     []uint
+	eventStart     string
+	eventEnd       string
+	eventDuration  string
+)
+
+func renderEvents(events []store.Event, title string) {
+	t := defaultTable(title)
+	t.AppendHeader(table.Row{"id", "name", "x_up", "x_dn", "roles", "categories", "start", "end", "active"})
+	now := time.Now()
+	for _, e := range events {
+		roles, categories := e.Scope()
+		t.AppendRow(table.Row{e.EventID, e.Name, e.MultiUp, e.MultiDn, roles, categories,
+			e.StartOn.Local().Format(eventTimeFormat), e.EndOn.Local().Format(eventTimeFormat), e.Active(now)})
+	}
+	t.SortBy([]table.SortBy{{
+		Name: "id",
+	}})
+	t.Render()
+}
+
+// parseEventTime accepts either a local time in the eventTimeFormat or a RFC3339 timestamp
+func parseEventTime(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation(eventTimeFormat, value, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("Invalid time, expected \"%s\" or RFC3339: %s", eventTimeFormat, value)
+	}
+	return t, nil
+}
+
+// eventCmd represents the event admin commands
+var eventCmd = &cobra.Command{
+	Use:               "event",
+	Short:             "event commands",
+	Long:              `Manage scheduled freeleech and upload multiplier events`,
+	PersistentPreRunE: connectRPC,
+}
+
+// eventListCmd lists every scheduled event
+var eventListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List scheduled events",
+	Long:  `List scheduled events`,
+	Run: func(cmd *cobra.Command, args []string) {
+		stream, err := cl.EventAll(context.Background(), &emptypb.Empty{})
+		if err != nil {
+			log.Fatalf("Failed to fetch events: %v", err)
+			return
+		}
+		var events []store.Event
+		for {
+			in, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("Failed to receive event: %v", err)
+			}
+			events = append(events, rpc.PBToEvent(in))
+		}
+		renderEvents(events, "List of all events")
+	},
+}
+
+// eventAddCmd schedules a new event
+var eventAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Schedule a new event",
+	Long: `Schedule a new event. Times are local, formatted as "2006-01-02 15:04", or RFC3339.
+
+  mika event add -n "Freeleech weekend" --multi_dn 0 --start "2021-01-01 00:00" --duration 2d`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		start := time.Now()
+		if eventStart != "" {
+			t, err := parseEventTime(eventStart)
+			if err != nil {
+				return err
+			}
+			start = t
+		}
+		var end time.Time
+		switch {
+		case eventEnd != "":
+			t, err := parseEventTime(eventEnd)
+			if err != nil {
+				return err
+			}
+			end = t
+		case eventDuration != "":
+			d, err := util.ParseDuration(eventDuration)
+			if err != nil {
+				return err
+			}
+			end = start.Add(d)
+		default:
+			return errors.New("Must supply one of: end, duration")
+		}
+		for _, r := range eventRoles {
+			eventAddParams.RoleIds = append(eventAddParams.RoleIds, uint32(r))
+		}
+		for i, c := range eventAddParams.Categories {
+			eventAddParams.Categories[i] = strings.TrimSpace(c)
+		}
+		eventAddParams.StartOn = timestamppb.New(start)
+		eventAddParams.EndOn = timestamppb.New(end)
+		e, err := cl.EventAdd(context.Background(), eventAddParams)
+		if err != nil {
+			log.Fatalf("Failed to add event: %v", err)
+		}
+		renderEvents([]store.Event{rpc.PBToEvent(e)}, "Event added successfully")
+		return nil
+	},
+}
+
+// eventDeleteCmd removes an event, ending it immediately if active
+var eventDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an event",
+	Long:  `Delete an event, ending it immediately if active`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if eventDelParams.EventId == 0 {
+			log.Fatalf("Must supply an event id")
+			return
+		}
+		if _, err := cl.EventDelete(context.Background(), eventDelParams); err != nil {
+			log.Fatalf("Failed to delete event: %v", err)
+		}
+		log.Infof("Event deleted successfully")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventCmd)
+	eventCmd.AddCommand(eventListCmd)
+	eventCmd.AddCommand(eventAddCmd)
+	eventCmd.AddCommand(eventDeleteCmd)
+
+	eventAddCmd.Flags().StringVarP(&eventAddParams.Name, "name", "n", "", "Name of the event")
+	eventAddCmd.Flags().Float64VarP(&eventAddParams.MultiUp, "multi_up", "U", 1.0, "Upload multiplier")
+	eventAddCmd.Flags().Float64VarP(&eventAddParams.MultiDn, "multi_dn", "D", 1.0, "Download multiplier, 0 for freeleech")
+	eventAddCmd.Flags().UintSliceVarP(&eventRoles, "roles", "r", nil, "Role ids the event applies to, empty for all")
+	eventAddCmd.Flags().StringSliceVarP(&eventAddParams.Categories, "categories", "c", nil, "Torrent categories the event applies to, empty for all")
+	eventAddCmd.Flags().StringVarP(&eventStart, "start", "s", "", "Start time (default: now)")
+	eventAddCmd.Flags().StringVarP(&eventEnd, "end", "e", "", "End time")
+	eventAddCmd.Flags().StringVarP(&eventDuration, "duration", "d", "", "Duration of the event when no end time is set, eg: 2d")
+
+	eventDeleteCmd.Flags().Uint32VarP(&eventDelParams.EventId, "id", "i", 0, "Event ID")
+}
@@ -35,6 +35,8 @@ var migrateCmd = &cobra.Command{
 				Passkey:         "mika",
 				IsDeleted:       false,
 				DownloadEnabled: true,
+				MultiUp:         -1,
+				MultiDown:       -1,
 				CreatedOn:       util.Now(),
 				UpdatedOn:       util.Now(),
 			}
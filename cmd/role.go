@@ -178,6 +178,9 @@ func init() {
 	roleSetCmd.Flags().BoolVarP(&roleSetParams.UploadEnabled, "upload_enabled", "U", true, "Uploading enabled")
 	roleSetCmd.Flags().Float64VarP(&roleSetParams.MultiDown, "multi_down", "d", 1.0, "Download multiplier")
 	roleSetCmd.Flags().Float64VarP(&roleSetParams.MultiUp, "multi_up", "u", 1.0, "Upload multiplier")
+	roleSetCmd.Flags().Uint32Var(&roleSetParams.MaxPeers, "max_peers", 0, "Max peers returned per announce, 0 for no role limit")
+	roleSetCmd.Flags().Uint32Var(&roleSetParams.MaxHnr, "max_hnr", 0, "Hit and runs allowed before downloading is disabled, 0 for no limit")
+	roleSetCmd.Flags().Uint32Var(&roleSetParams.MaxLeeching, "max_leeching", 0, "Torrents a user can leech at once, 0 for no limit")
 
 	roleDeleteCmd.Flags().StringVarP(&roleDelParam.RoleName, "name", "n", "", "Name of the role")
 	roleDeleteCmd.Flags().Uint32VarP(&roleDelParam.RoleId, "id", "i", 0, "Role ID")
@@ -188,4 +191,7 @@ func init() {
 	roleAddCmd.Flags().BoolVarP(&roleAddParam.UploadEnabled, "upload_enabled", "U", true, "Uploading enabled")
 	roleAddCmd.Flags().Float64VarP(&roleAddParam.MultiDown, "multi_down", "d", 1.0, "Download multiplier")
 	roleAddCmd.Flags().Float64VarP(&roleAddParam.MultiUp, "multi_up", "u", 1.0, "Upload multiplier")
+	roleAddCmd.Flags().Uint32Var(&roleAddParam.MaxPeers, "max_peers", 0, "Max peers returned per announce, 0 for no role limit")
+	roleAddCmd.Flags().Uint32Var(&roleAddParam.MaxHnr, "max_hnr", 0, "Hit and runs allowed before downloading is disabled, 0 for no limit")
+	roleAddCmd.Flags().Uint32Var(&roleAddParam.MaxLeeching, "max_leeching", 0, "Torrents a user can leech at once, 0 for no limit")
 }
@@ -58,17 +58,40 @@ var serveCmd = &cobra.Command{
 			}
 		}()
 
+		btListener, err := net.Listen("tcp", btServer.Addr)
+		if err != nil {
+			log.Fatalf("failed to listen: %v", err)
+		}
+		if config.Tracker.ProxyProtocol {
+			btListener = tracker.NewProxyListener(btListener, config.Tracker.TrustedProxiesParsed)
+		}
 		go func() {
 			log.Infof("Starting tracker service")
-			if errRpc := btServer.ListenAndServe(); errRpc != nil {
+			if errRpc := btServer.Serve(btListener); errRpc != nil {
 				log.Errorf("HTTP error: %v", errRpc)
 			}
 		}()
 
+		var udpServer *tracker.UDPServer
+		if config.Tracker.ListenUDP != "" {
+			udpServer = tracker.NewUDPServer(config.Tracker.ListenUDP)
+			go func() {
+				log.Infof("Starting UDP tracker service")
+				if errUDP := udpServer.ListenAndServe(); errUDP != nil {
+					log.Errorf("UDP error: %v", errUDP)
+				}
+			}()
+		}
+
 		util.WaitForSignal(ctx, func(ctx context.Context) error {
 			if err := btServer.Shutdown(ctx); err != nil {
 				log.Fatalf("Error closing servers gracefully; %s", err)
 			}
+			if udpServer != nil {
+				if err := udpServer.Shutdown(ctx); err != nil {
+					log.Fatalf("Error closing udp server gracefully; %s", err)
+				}
+			}
 			return nil
 		})
 	},
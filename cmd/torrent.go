@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"github.com/anacrolix/torrent/bencode"
 	"github.com/anacrolix/torrent/metainfo"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/viciious/mika/client"
@@ -15,7 +14,6 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"io"
-	"io/ioutil"
 )
 
 var (
@@ -36,11 +34,11 @@ func connectRPC(cmd *cobra.Command, args []string) error {
 
 func renderTorrents(torrents []*store.Torrent, title string) {
 	t := defaultTable(title)
-	t.AppendHeader(table.Row{"info_hash", "sn", "up_tot", "dn_tot", "en", "reason", "x_up", "x_dn"})
+	t.AppendHeader(table.Row{"info_hash", "sn", "up_tot", "dn_tot", "en", "reason", "x_up", "x_dn", "trap"})
 	for _, tor := range torrents {
 		t.AppendRow(table.Row{
 			tor.InfoHash, tor.Snatches, tor.Uploaded, tor.Downloaded,
-			tor.IsEnabled, tor.Reason, tor.MultiUp, tor.MultiDn})
+			tor.IsEnabled, tor.Reason, tor.MultiUp, tor.MultiDn, tor.TrapUserID})
 	}
 	t.Render()
 }
@@ -64,17 +62,14 @@ var torrentAddCmd = &cobra.Command{
 		if err != nil {
 			return errors.Wrapf(err, "Failed to read torrent meta info")
 		}
-		b, err := ioutil.ReadFile(torrentFile)
+		info, err := f.UnmarshalInfo()
 		if err != nil {
-			return err
-		}
-		var info metainfo.Info
-		if err := bencode.Unmarshal(b, &info); err != nil {
-			return err
+			return errors.Wrapf(err, "Failed to read torrent info dict")
 		}
 		if torrentAddParams.Title == "" {
 			torrentAddParams.Title = info.Name
 		}
+		torrentAddParams.Size = uint64(info.TotalLength())
 		torrentAddParams.InfoHash = f.HashInfoBytes().Bytes()
 		r, err2 := cl.TorrentAdd(context.Background(), torrentAddParams)
 		if err2 != nil {
@@ -182,4 +177,7 @@ func init() {
 	torrentAddCmd.Flags().StringVarP(&torrentAddParams.Title, "name", "n", "", "Name of the torrent")
 	torrentAddCmd.Flags().Float64VarP(&torrentAddParams.MultiUp, "multi_up", "U", 1.0, "Upload multiplier")
 	torrentAddCmd.Flags().Float64VarP(&torrentAddParams.MultiDn, "multi_dn", "D", 1.0, "Download multiplier")
+	torrentAddCmd.Flags().Uint32Var(&torrentAddParams.MaxPeers, "max_peers", 0, "Max peers returned per announce, 0 for no torrent limit")
+	torrentAddCmd.Flags().StringVarP(&torrentAddParams.Category, "category", "c", "", "Category of the torrent, used to scope events")
+	torrentAddCmd.Flags().Uint32Var(&torrentAddParams.TrapUserId, "trap_user", 0, "Register as a trap torrent only given to this user id")
 }
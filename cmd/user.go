@@ -20,6 +20,11 @@ var (
 	roleStr      = ""
 	userAddParam = &pb.UserAddParams{}
 	userGetParam = &pb.UserID{}
+	// userMultiUp and userMultiDown are only sent when their flags are set
+	userMultiUp   = -1.0
+	userMultiDown = -1.0
+	// userRotateParam.User is set in init so the flags can bind to its fields
+	userRotateParam = &pb.PasskeyRotateParams{}
 )
 
 // userCmd represents user admin commands
@@ -65,6 +70,34 @@ var userGetCmd = &cobra.Command{
 	},
 }
 
+// userRotateCmd replaces the passkey of a user
+var userRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Rotate the passkey of a user",
+	Long: `Replace the passkey of a user with a new random one. The old passkey keeps working for
+the grace period, announces using it are sent a warning to redownload their torrents.
+
+  mika user rotate -u 10 --grace 3d`,
+	Run: func(cmd *cobra.Command, args []string) {
+		id := userRotateParam.User
+		if id.Passkey == "" && id.RemoteId == 0 && id.UserId == 0 {
+			log.Fatalf("Must provide at least one ID type (-p,-u,-r)")
+			return
+		}
+		rotation, err := cl.UserRotatePasskey(context.Background(), userRotateParam)
+		if err != nil {
+			log.Fatalf("Failed to rotate passkey: %v", err)
+			return
+		}
+		expires := "now"
+		if rotation.OldExpires != nil {
+			expires = rotation.OldExpires.AsTime().Local().Format(eventTimeFormat)
+		}
+		log.Infof("Passkey of user %d rotated to %s, %s expires %s", rotation.UserId, rotation.Passkey,
+			rotation.OldPasskey, expires)
+	},
+}
+
 // userAddCmd can be used to add users
 var userAddCmd = &cobra.Command{
 	Use:   "add",
@@ -111,6 +144,12 @@ var userAddCmd = &cobra.Command{
 			return
 		}
 		userAddParam.RoleId = validRoleID
+		if cmd.Flags().Changed("multi_up") {
+			userAddParam.MultiUp = &userMultiUp
+		}
+		if cmd.Flags().Changed("multi_down") {
+			userAddParam.MultiDown = &userMultiDown
+		}
 		u, err2 := client.UserAdd(context.Background(), userAddParam)
 		if err2 != nil {
 			log.Fatalf("Failed to add user: %v", err2)
@@ -124,15 +163,25 @@ func init() {
 	rootCmd.AddCommand(userCmd)
 	userCmd.AddCommand(userAddCmd)
 	userCmd.AddCommand(userGetCmd)
+	userCmd.AddCommand(userRotateCmd)
 
 	userGetCmd.Flags().StringVarP(&userGetParam.Passkey, "passkey", "p", "", "User passkey")
 	userGetCmd.Flags().Uint32VarP(&userGetParam.UserId, "user_id", "u", 0, "Internal tracker user ID")
 	userGetCmd.Flags().Uint64VarP(&userGetParam.RemoteId, "remote_id", "r", 0, "Remote user ID")
 
+	userRotateParam.User = &pb.UserID{}
+	userRotateCmd.Flags().StringVarP(&userRotateParam.User.Passkey, "passkey", "p", "", "User passkey")
+	userRotateCmd.Flags().Uint32VarP(&userRotateParam.User.UserId, "user_id", "u", 0, "Internal tracker user ID")
+	userRotateCmd.Flags().Uint64VarP(&userRotateParam.User.RemoteId, "remote_id", "r", 0, "Remote user ID")
+	userRotateCmd.Flags().StringVar(&userRotateParam.Grace, "grace", "", "How long the old passkey keeps working, 0 stops it immediately (default: tracker passkey_grace)")
+
 	userAddCmd.Flags().StringVarP(&userAddParam.UserName, "name", "n", "", "Username of the user")
 	userAddCmd.Flags().StringVarP(&userAddParam.Passkey, "passkey", "p", "", "Passkey for user. (default: random)")
 	userAddCmd.Flags().BoolVarP(&userAddParam.DownloadEnabled, "download_enabled", "D", true, "Passkey for user. (default: true)")
 	userAddCmd.Flags().Uint64VarP(&userAddParam.Downloaded, "downloaded", "d", 0, "User download total (default: 0)")
 	userAddCmd.Flags().Uint64VarP(&userAddParam.Uploaded, "uploaded", "u", 0, "User upload total (default: 0)")
+	userAddCmd.Flags().Float64Var(&userMultiUp, "multi_up", -1, "Personal upload multiplier, negative inherits the role multiplier")
+	userAddCmd.Flags().Float64Var(&userMultiDown, "multi_down", -1, "Personal download multiplier, negative inherits the role multiplier")
+	userAddCmd.Flags().Uint32Var(&userAddParam.MaxLeeching, "max_leeching", 0, "Personal concurrent leeching limit (default: 0, inherit)")
 	userAddCmd.Flags().StringVarP(&roleStr, "role", "r", "", "User role")
 }
@@ -12,17 +12,19 @@ import (
 )
 
 var (
-	wlParams = &pb.WhiteList{}
+	wlParams    = &pb.WhiteList{}
+	wlDelParams = &pb.WhiteListDeleteParams{}
 )
 
 func renderWhitelist(wl []*store.WhiteListClient, title string) {
 	t := defaultTable(title)
-	t.AppendHeader(table.Row{"name", "prefix"})
+	t.AppendHeader(table.Row{"id", "name", "prefix", "client", "min_version", "max_version", "action", "message"})
 	for _, w := range wl {
-		t.AppendRow(table.Row{w.ClientName, w.ClientPrefix})
+		t.AppendRow(table.Row{w.WhiteListID, w.ClientName, w.ClientPrefix, w.Client, w.MinVersion, w.MaxVersion,
+			w.Action, w.Message})
 	}
 	t.SortBy([]table.SortBy{{
-		Name: "name",
+		Name: "id",
 	}})
 	t.Render()
 }
@@ -36,29 +38,35 @@ var whiteListCmd = &cobra.Command{
 	PersistentPreRunE: connectRPC,
 }
 
-// userAddCmd can be used to add users
+// whiteListAddCmd can be used to add client rules
 var whiteListAddCmd = &cobra.Command{
 	Use:   "add",
-	Short: "Add a client whitelist to the tracker",
-	Long:  `Add a client whitelist to the tracker`,
+	Short: "Add a client whitelist rule to the tracker",
+	Long: `Add a client whitelist rule to the tracker. Deny rules are checked first and reject
+matching clients. Once any allow rule exists only clients matching one are allowed.
+
+  mika whitelist add -n "qBittorrent 4.3+" -c qBittorrent --min_version 4.3
+  mika whitelist add -n "Broken qBittorrent" -c qBittorrent --min_version 4.4.0 --max_version 4.4.0 \
+    -a deny -m "qBittorrent 4.4.0 is broken, please upgrade"`,
 	Run: func(cmd *cobra.Command, args []string) {
-		if wlParams.Name == "" || wlParams.Prefix == "" {
-			log.Fatalf("Must supply non-empty name and prefix")
+		if wlParams.Name == "" || (wlParams.Prefix == "" && wlParams.Client == "") {
+			log.Fatalf("Must supply non-empty name and a prefix or client")
 			return
 		}
-		if _, err := cl.WhiteListAdd(context.Background(), wlParams); err != nil {
-			log.Fatalf("Failed to add whitelist entry")
+		wl, err := cl.WhiteListAdd(context.Background(), wlParams)
+		if err != nil {
+			log.Fatalf("Failed to add whitelist entry: %v", err)
 			return
 		}
-		log.Infof("Client whitelist added: %s", wlParams.Name)
+		renderWhitelist([]*store.WhiteListClient{rpc.PBToWhiteList(wl)}, "Client whitelist added")
 	},
 }
 
-// whiteListListCmd can be used to list roles
+// whiteListListCmd can be used to list client rules
 var whiteListListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List known roles",
-	Long:  `List known roles`,
+	Short: "List client whitelist rules",
+	Long:  `List client whitelist rules`,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := cl.WhiteListAll(context.Background(), &emptypb.Empty{})
 		if err != nil {
@@ -73,12 +81,20 @@ var whiteListListCmd = &cobra.Command{
 	},
 }
 
-// whiteListDeleteCmd can be used to delete roles
+// whiteListDeleteCmd can be used to delete client rules
 var whiteListDeleteCmd = &cobra.Command{
 	Use:   "delete",
-	Short: "Delete a client whitelist from the tracker",
-	Long:  `Delete a client whitelist from the tracker`,
+	Short: "Delete a client whitelist rule from the tracker",
+	Long:  `Delete a client whitelist rule by id, or every rule with the prefix`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if wlDelParams.WhitelistId == 0 && wlDelParams.Prefix == "" {
+			log.Fatalf("Must supply an id or prefix")
+			return
+		}
+		if _, err := cl.WhiteListDelete(context.Background(), wlDelParams); err != nil {
+			log.Fatalf("Failed to delete whitelist entry: %v", err)
+		}
+		log.Infof("Client whitelist deleted successfully")
 	},
 }
 
@@ -88,8 +104,14 @@ func init() {
 	whiteListCmd.AddCommand(whiteListAddCmd)
 	whiteListCmd.AddCommand(whiteListDeleteCmd)
 
-	whiteListAddCmd.Flags().StringVarP(&wlParams.Prefix, "prefix", "p", "", "Prefix to match the client")
-	whiteListAddCmd.Flags().StringVarP(&wlParams.Name, "name", "n", "", "Name of the client")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.Prefix, "prefix", "p", "", "Peer id prefix to match the client")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.Name, "name", "n", "", "Name of the rule")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.Client, "client", "c", "", "Client name parsed from the peer id to match, eg: qBittorrent")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.MinVersion, "min_version", "", "", "Oldest client version matched, eg: 4.3")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.MaxVersion, "max_version", "", "", "Newest client version matched, eg: 4.4.1")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.Action, "action", "a", store.WhiteListAllow, "Action for matching clients, allow|deny")
+	whiteListAddCmd.Flags().StringVarP(&wlParams.Message, "message", "m", "", "Message sent to clients rejected by a deny rule")
 
-	whiteListDeleteCmd.Flags().StringVarP(&wlParams.Prefix, "prefix", "p", "", "Prefix to match the client")
+	whiteListDeleteCmd.Flags().Uint32VarP(&wlDelParams.WhitelistId, "id", "i", 0, "Whitelist rule ID")
+	whiteListDeleteCmd.Flags().StringVarP(&wlDelParams.Prefix, "prefix", "p", "", "Prefix of the rules to delete")
 }
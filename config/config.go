@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"net"
 	"net/url"
 	"os"
 	"strings"
@@ -24,23 +25,47 @@ var (
 	Tracker = trackerConfig{
 		Public:                        false,
 		Listen:                        "0.0.0.0:34000",
+		ListenUDP:                     "",
 		TLS:                           false,
 		IPv6:                          false,
 		IPv6Only:                      false,
 		AutoRegister:                  false,
 		ReaperInterval:                "90s",
 		ReaperIntervalParsed:          90 * time.Second,
+		ReaperGrace:                   "60s",
+		ReaperGraceParsed:             60 * time.Second,
 		AnnounceInterval:              "30s",
 		AnnounceIntervalParsed:        30 * time.Second,
 		AnnounceIntervalMinimum:       "10s",
 		AnnounceIntervalMinimumParsed: 10 * time.Second,
 		HNRThreshold:                  "1d",
 		HNRThresholdParsed:            24 * time.Hour,
+		HNRWindow:                     "14d",
+		HNRWindowParsed:               14 * 24 * time.Hour,
 		BatchUpdateInterval:           "30s",
 		BatchUpdateIntervalParsed:     30 * time.Second,
 		AllowNonRoutable:              true,
 		AllowClientIP:                 false,
 		MaxPeers:                      50,
+		PeerSelector:                  "random",
+		PeerSelectorMinSeeders:        0.2,
+		PeerSelectorPreferFast:        false,
+		PasskeyGrace:                  "7d",
+		PasskeyGraceParsed:            7 * 24 * time.Hour,
+		PasskeyLeakGrace:              "0",
+		Bonus: BonusFormula{
+			SeedTimeMax: "0",
+			AgeMax:      "0",
+		},
+		Cheat: CheatRules{
+			MaxSpeedAction:         CheatLog,
+			NoLeechersAction:       CheatLog,
+			GhostPeersAction:       CheatLog,
+			SpeedProfileTolerance:  3,
+			SpeedProfileMinSamples: 20,
+			SpeedProfileAction:     CheatLog,
+			UserAgentAction:        CheatLog,
+		},
 	}
 	API = rpcConfig{
 		Listen: "localhost:34001",
@@ -90,6 +115,10 @@ type trackerConfig struct {
 	// Listen sets the host and port to listen on
 	// hostname:port
 	Listen string `mapstructure:"listen"`
+	// ListenUDP sets the host and port for the BEP15 UDP tracker to listen on. An empty
+	// value disables the UDP tracker
+	// hostname:port
+	ListenUDP string `mapstructure:"listen_udp"`
 	// TLS enables TLS for the tracker component
 	// true|false
 	TLS bool `mapstructure:"tls"`
@@ -106,6 +135,11 @@ type trackerConfig struct {
 	// 60s|1m
 	ReaperInterval       string `mapstructure:"reaper_interval"`
 	ReaperIntervalParsed time.Duration
+	// ReaperGrace is how long past the announce interval a peer can go without announcing
+	// before it is considered gone and reaped from the swarm
+	// 60s|1m
+	ReaperGrace       string `mapstructure:"reaper_grace"`
+	ReaperGraceParsed time.Duration
 	// AnnounceInterval defines how often peers should announce. The lower this is
 	// the more load on your system you can expect
 	// 60s|1m
@@ -119,14 +153,221 @@ type trackerConfig struct {
 	// 1d|12h|60m
 	HNRThreshold       string `mapstructure:"hnr_threshold"`
 	HNRThresholdParsed time.Duration
+	// HNRWindow is how long after completing a torrent a user has to seed it for HNRThreshold
+	// before they are flagged as a Hit-N-Run
+	// 14d|7d
+	HNRWindow       string `mapstructure:"hnr_window"`
+	HNRWindowParsed time.Duration
 	// TrackerBatchUpdateInterval defines how often we sync user stats to the back store
 	BatchUpdateInterval       string `mapstructure:"batch_update_interval"`
 	BatchUpdateIntervalParsed time.Duration
 	// TrackerAllowNonRoutable defines whether we allow peers who are using non-public/routable addresses
 	AllowNonRoutable bool `mapstructure:"allow_non_routable"`
 	AllowClientIP    bool `mapstructure:"allow_client_ip"`
+	// TrustedProxies are the addresses or CIDR ranges of the reverse proxies and load balancers
+	// in front of the tracker. The X-Real-IP and X-Forwarded-For headers are only honoured on
+	// requests from these sources.
+	// ["127.0.0.1", "10.0.0.0/8"]
+	TrustedProxies       []string `mapstructure:"trusted_proxies"`
+	TrustedProxiesParsed []*net.IPNet
+	// ProxyProtocol expects connections to the tracker listener to begin with a HAProxy PROXY
+	// protocol v1 or v2 header. When trusted proxies are set only connections from them are
+	// expected to send one.
+	// true|false
+	ProxyProtocol bool `mapstructure:"proxy_protocol"`
 
 	MaxPeers int `mapstructure:"max_peers"`
+	// PeerSelector sets the strategy used to choose which peers are returned to a client
+	// random|geo|completion|speed
+	PeerSelector string `mapstructure:"peer_selector"`
+	// PeerSelectorMinSeeders is the minimum share of the returned peers that must be seeders
+	// when enough are available
+	// 0.0-1.0
+	PeerSelectorMinSeeders float64 `mapstructure:"peer_selector_min_seeders"`
+	// PeerSelectorPreferFast makes the speed strategy favour the fastest peers instead of the slowest
+	// true|false
+	PeerSelectorPreferFast bool `mapstructure:"peer_selector_prefer_fast"`
+	// RatioRules sets the ratio the users of each role must maintain. Roles without a rule
+	// are not watched.
+	RatioRules []RatioRule `mapstructure:"ratio_rules"`
+	// Bonus sets how many bonus points are earned for each hour spent seeding a torrent
+	Bonus BonusFormula `mapstructure:"bonus"`
+	// Cheat configures the cheater detection rules checked on each announce
+	Cheat CheatRules `mapstructure:"cheat"`
+	// TrapResetPasskey replaces the passkey of users who leak a trap torrent so the leaked
+	// passkey stops working. The passkey is only replaced on the first leak of each trap.
+	// true|false
+	TrapResetPasskey bool `mapstructure:"trap_reset_passkey"`
+	// PasskeyGrace is how long the old passkey of a user keeps working after it is rotated.
+	// Announces using it are sent a warning telling the user to redownload their torrents.
+	// 7d|0
+	PasskeyGrace       string `mapstructure:"passkey_grace"`
+	PasskeyGraceParsed time.Duration
+	// PasskeyLeakGrace is how long the old passkey keeps working after it is rotated for being
+	// announced from too many places, see PasskeyMaxIPs and PasskeyMaxASNs. Leakers keep using
+	// the old passkey for as long as it works.
+	// 0|1h
+	PasskeyLeakGrace       string `mapstructure:"passkey_leak_grace"`
+	PasskeyLeakGraceParsed time.Duration
+	// PasskeyMaxIPs rotates the passkey of a user once it is announced from more than this many
+	// IPs at the same time. 0 disables the check.
+	PasskeyMaxIPs int `mapstructure:"passkey_max_ips"`
+	// PasskeyMaxASNs rotates the passkey of a user once it is announced from more than this many
+	// ASNs at the same time. 0 disables the check.
+	PasskeyMaxASNs int `mapstructure:"passkey_max_asns"`
+}
+
+// RatioRule defines the ratio required of the users of a role. Users below it are warned and,
+// if Restrict is set, have downloading disabled once the grace period passes without it
+// being recovered.
+type RatioRule struct {
+	// Role is the name of the role the rule applies to
+	Role string `mapstructure:"role"`
+	// Grace is how long a user has to recover their ratio before being restricted
+	// 7d|72h
+	Grace       string `mapstructure:"grace"`
+	GraceParsed time.Duration
+	// Restrict disables downloading once the grace period passes, otherwise users are only warned
+	// true|false
+	Restrict bool `mapstructure:"restrict"`
+	// Bands sets the required ratio by the amount downloaded
+	Bands []RatioBand `mapstructure:"bands"`
+}
+
+// RatioBand is the ratio required once a user has downloaded at least Downloaded bytes
+type RatioBand struct {
+	Downloaded uint64  `mapstructure:"downloaded"`
+	Ratio      float64 `mapstructure:"ratio"`
+}
+
+// Required returns the ratio required of a user who has downloaded the amount provided. This
+// is the ratio of the largest band reached, or 0 if none are.
+func (r RatioRule) Required(downloaded uint64) float64 {
+	var band *RatioBand
+	for i := range r.Bands {
+		if r.Bands[i].Downloaded <= downloaded && (band == nil || r.Bands[i].Downloaded > band.Downloaded) {
+			band = &r.Bands[i]
+		}
+	}
+	if band == nil {
+		return 0
+	}
+	return band.Ratio
+}
+
+// BonusFormula defines the bonus points earned per hour for seeding a torrent. The terms are
+// summed and any of them can be disabled by setting their weight to 0.
+type BonusFormula struct {
+	// Base is earned for every torrent seeded
+	Base float64 `mapstructure:"base"`
+	// Size is earned for each GiB of the torrents contents
+	Size float64 `mapstructure:"size"`
+	// Seeders is divided between the seeders of the torrent, favouring poorly seeded torrents
+	Seeders float64 `mapstructure:"seeders"`
+	// SeedTime is earned for each day the user has spent seeding the torrent
+	SeedTime float64 `mapstructure:"seed_time"`
+	// SeedTimeMax caps the seeding time counted, 0 for no limit
+	// 30d|0
+	SeedTimeMax       string `mapstructure:"seed_time_max"`
+	SeedTimeMaxParsed time.Duration
+	// Age is earned for each day since the torrent was added
+	Age float64 `mapstructure:"age"`
+	// AgeMax caps the torrent age counted, 0 for no limit
+	// 1y|0
+	AgeMax       string `mapstructure:"age_max"`
+	AgeMaxParsed time.Duration
+}
+
+// Rate returns the bonus points earned per hour seeding a torrent of the size and seeder count
+// provided. seedTime is how long the user has seeded the torrent and age is how long ago
+// the torrent was added.
+func (f BonusFormula) Rate(size uint64, seeders uint32, seedTime time.Duration, age time.Duration) float64 {
+	days := func(d time.Duration, max time.Duration) float64 {
+		if max > 0 && d > max {
+			d = max
+		}
+		if d < 0 {
+			d = 0
+		}
+		return d.Hours() / 24
+	}
+	rate := f.Base + f.Size*float64(size)/(1<<30)
+	if seeders > 0 {
+		rate += f.Seeders / float64(seeders)
+	} else {
+		rate += f.Seeders
+	}
+	rate += f.SeedTime * days(seedTime, f.SeedTimeMaxParsed)
+	return rate + f.Age*days(age, f.AgeMaxParsed)
+}
+
+// Actions taken against a user when an announce is flagged by a cheat rule. Every flagged
+// announce is recorded as a cheat report regardless of the action.
+const (
+	// CheatLog only records the report
+	CheatLog = "log"
+	// CheatDrop does not credit the upload of the flagged announce
+	CheatDrop = "drop"
+	// CheatDisableDownload drops the upload credit and disables downloading for the user. The
+	// account itself stays usable, so the user can keep seeding.
+	CheatDisableDownload = "disable_download"
+	// CheatDisable drops the upload credit and disables the account with a user ban, so every
+	// further announce is refused until the ban is lifted
+	CheatDisable = "disable"
+	// CheatReject refuses the announce outright. Only the user agent rule can reject announces
+	// as the other rules need the upload reported by the announce.
+	CheatReject = "reject"
+)
+
+// CheatRules configures the cheater detection rules. Each rule has its own action.
+type CheatRules struct {
+	// MaxSpeed is the fastest upload speed in bytes/sec considered possible, 0 disables the rule
+	MaxSpeed uint64 `mapstructure:"max_speed"`
+	// log|drop|disable_download|disable
+	MaxSpeedAction string `mapstructure:"max_speed_action"`
+	// NoLeechers flags uploads reported on swarms which have had no leechers for the last two
+	// announce intervals, as there was nobody to upload to
+	// true|false
+	NoLeechers bool `mapstructure:"no_leechers"`
+	// log|drop|disable_download|disable
+	NoLeechersAction string `mapstructure:"no_leechers_action"`
+	// GhostPeersAction applies to uploads reported by users being probed with ghost peers.
+	// Probes are started for individual users through the API.
+	// log|drop|disable_download|disable
+	GhostPeersAction string `mapstructure:"ghost_peers_action"`
+	// SpeedProfile flags uploads which are far faster than the historical upload speeds of
+	// the user, their IP or their ASN
+	// true|false
+	SpeedProfile bool `mapstructure:"speed_profile"`
+	// SpeedProfileTolerance is how many times faster than the 95th percentile of a profile an
+	// upload must be to be flagged
+	SpeedProfileTolerance float64 `mapstructure:"speed_profile_tolerance"`
+	// SpeedProfileMinSamples is how many speeds a profile must have recorded before uploads
+	// are checked against it
+	SpeedProfileMinSamples uint64 `mapstructure:"speed_profile_min_samples"`
+	// log|drop|disable_download|disable
+	SpeedProfileAction string `mapstructure:"speed_profile_action"`
+	// UserAgent flags HTTP announces whose User-Agent names a different client than the one
+	// identified from the peer id, which is how spoofed peer ids usually give themselves away
+	// true|false
+	UserAgent bool `mapstructure:"user_agent"`
+	// UserAgentVersion also requires the major version of the User-Agent and peer id to match
+	// true|false
+	UserAgentVersion bool `mapstructure:"user_agent_version"`
+	// UserAgentAliases maps the client names parsed from peer ids to the other names the client
+	// sends in its User-Agent, in addition to the built in aliases
+	UserAgentAliases map[string][]string `mapstructure:"user_agent_aliases"`
+	// log|drop|disable_download|disable|reject
+	UserAgentAction string `mapstructure:"user_agent_action"`
+}
+
+// ValidCheatAction checks if the action is one of the known cheat rule actions
+func ValidCheatAction(action string) bool {
+	switch action {
+	case CheatLog, CheatDrop, CheatDisableDownload, CheatDisable:
+		return true
+	}
+	return false
 }
 
 type rpcConfig struct {
@@ -224,7 +465,14 @@ func Read(cfgFile string) error {
 		return errors.Wrap(err, consts.ErrInvalidConfig.Error())
 	}
 	log.Debugf("Using config file: %s", viper.ConfigFileUsed())
-	full := fullConfig{}
+	// Start from the current values so any keys missing from the file keep their defaults
+	full := fullConfig{
+		General: General,
+		Tracker: Tracker,
+		API:     API,
+		Store:   Store,
+		GeoDB:   GeoDB,
+	}
 	if err := viper.Unmarshal(&full); err != nil {
 		return errors.Wrapf(err, "Failed to parse config")
 	}
@@ -236,13 +484,43 @@ func Read(cfgFile string) error {
 		{&full.Tracker.AnnounceIntervalParsed, full.Tracker.AnnounceInterval},
 		{&full.Tracker.BatchUpdateIntervalParsed, full.Tracker.BatchUpdateInterval},
 		{&full.Tracker.HNRThresholdParsed, full.Tracker.HNRThreshold},
+		{&full.Tracker.HNRWindowParsed, full.Tracker.HNRWindow},
 		{&full.Tracker.ReaperIntervalParsed, full.Tracker.ReaperInterval},
+		{&full.Tracker.ReaperGraceParsed, full.Tracker.ReaperGrace},
+		{&full.Tracker.PasskeyGraceParsed, full.Tracker.PasskeyGrace},
+		{&full.Tracker.PasskeyLeakGraceParsed, full.Tracker.PasskeyLeakGrace},
+		{&full.Tracker.Bonus.SeedTimeMaxParsed, full.Tracker.Bonus.SeedTimeMax},
+		{&full.Tracker.Bonus.AgeMaxParsed, full.Tracker.Bonus.AgeMax},
+	}
+	for i := range full.Tracker.RatioRules {
+		rule := &full.Tracker.RatioRules[i]
+		durations = append(durations, struct {
+			target *time.Duration
+			value  string
+		}{&rule.GraceParsed, rule.Grace})
 	}
 	for _, dur := range durations {
 		if err := setDuration(dur.target, dur.value); err != nil {
 			return errors.Wrapf(err, "Failed to parse time duration")
 		}
 	}
+	proxies, err := util.ParseCIDRs(full.Tracker.TrustedProxies)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to parse tracker.trusted_proxies")
+	}
+	full.Tracker.TrustedProxiesParsed = proxies
+	for _, action := range []string{full.Tracker.Cheat.MaxSpeedAction, full.Tracker.Cheat.NoLeechersAction,
+		full.Tracker.Cheat.GhostPeersAction, full.Tracker.Cheat.SpeedProfileAction} {
+		if !ValidCheatAction(action) {
+			return errors.Errorf("Invalid cheat rule action: %s", action)
+		}
+	}
+	if !ValidCheatAction(full.Tracker.Cheat.UserAgentAction) && full.Tracker.Cheat.UserAgentAction != CheatReject {
+		return errors.Errorf("Invalid cheat rule action: %s", full.Tracker.Cheat.UserAgentAction)
+	}
+	if full.Tracker.Cheat.SpeedProfile && full.Tracker.Cheat.SpeedProfileTolerance <= 1 {
+		return errors.New("tracker.cheat.speed_profile_tolerance must be greater than 1")
+	}
 	if full.API.Key == "" {
 		return errors.New("api.key cannot be empty")
 	}
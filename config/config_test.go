@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestLogger(t *testing.T) {
@@ -25,3 +26,20 @@ func TestStoreConfig_DSN(t *testing.T) {
 		"test:pass@tcp(localhost:5432)/db?arg1=foo&arg2=bar",
 		c.DSN())
 }
+
+func TestRatioRule_Required(t *testing.T) {
+	rule := RatioRule{Bands: []RatioBand{{Downloaded: 100, Ratio: 1}, {Downloaded: 10, Ratio: 0.5}}}
+	require.Equal(t, 0.0, rule.Required(5))
+	require.Equal(t, 0.5, rule.Required(10))
+	require.Equal(t, 0.5, rule.Required(99))
+	require.Equal(t, 1.0, rule.Required(1000))
+}
+
+func TestBonusFormula_Rate(t *testing.T) {
+	day := 24 * time.Hour
+	f := BonusFormula{Base: 1, Size: 0.5, Seeders: 2, SeedTime: 0.1, SeedTimeMaxParsed: 10 * day, Age: 1}
+	require.Equal(t, 1.0, BonusFormula{Base: 1}.Rate(1<<40, 10, day, day))
+	require.InDelta(t, 1+0.5*4+1+0.1*5+2, f.Rate(4<<30, 2, 5*day, 2*day), 0.0001)
+	// Seed time is capped, age is not
+	require.InDelta(t, 1+0.5*4+2+0.1*10+20, f.Rate(4<<30, 0, 50*day, 20*day), 0.0001)
+}
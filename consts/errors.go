@@ -29,10 +29,23 @@ var (
 	ErrInvalidUser = errors.New("invalid user")
 	ErrInvalidRole = errors.New("invalid role")
 	ErrInvalidPeer = errors.New("invalid peer")
+	// ErrInvalidEvent is used when an event lookup fails
+	ErrInvalidEvent = errors.New("invalid event")
+	// ErrInvalidToken is used when a freeleech token lookup fails
+	ErrInvalidToken = errors.New("invalid freeleech token")
+	// ErrInvalidProbe is used when a ghost peer probe lookup fails
+	ErrInvalidProbe = errors.New("invalid probe")
+	// ErrInvalidBan is used when a ban lookup fails
+	ErrInvalidBan = errors.New("invalid ban")
+	// ErrInvalidProxyHeader is used when a connection does not begin with a valid PROXY
+	// protocol header
+	ErrInvalidProxyHeader = errors.New("invalid proxy protocol header")
 	// ErrInvalidClient is used when an invalid client is requested/used
 	ErrInvalidClient = errors.New("invalid torrent client")
 	// ErrBadResponseCode is returned when a HTTP request returns a non 200 code
 	ErrBadResponseCode = errors.New("bad response code returned")
 
 	ErrCannotConnect = errors.New("cannot connect to server")
+	// ErrInsufficientPoints is returned when spending more bonus points than a user has
+	ErrInsufficientPoints = errors.New("insufficient bonus points")
 )
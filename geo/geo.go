@@ -146,11 +146,24 @@ type ellipse struct {
 	InvFlattening float64
 }
 
+// wgs84 is the ellipsoid used for distance calculations, because why not
+var wgs84 = ellipsoid{
+	ellipse{6378137.0, 298.257223563},
+	kilometer,
+	1000.0,
+}
+
 // distance computes the distances between two LatLong pairings
 func (db *DB) distance(llA LatLong, llB LatLong) float64 {
 	return math.Floor(db.ellipsoid.to(llA.Latitude, llA.Longitude, llB.Latitude, llB.Longitude))
 }
 
+// Distance computes the distance in kilometers between two LatLong pairings. Unlike DB.distance
+// this does not require a loaded geo database so it can be used with any Provider
+func Distance(llA LatLong, llB LatLong) float64 {
+	return math.Floor(wgs84.to(llA.Latitude, llA.Longitude, llB.Latitude, llB.Longitude))
+}
+
 // DownloadDB will fetch a new geoip database from maxmind and install it, uncompressed,
 // into the configured geodb_path config file path usually defined in the configuration
 // files.
@@ -370,15 +383,11 @@ func New(path string) (*DB, error) {
 		}
 	}
 	return &DB{
-		RWMutex: sync.RWMutex{},
-		db:      db,
-		ellipsoid: ellipsoid{
-			ellipse{6378137.0, 298.257223563}, // WGS84, because why not
-			kilometer,
-			1000.0,
-		},
-		asn4: records4,
-		asn6: records6,
+		RWMutex:   sync.RWMutex{},
+		db:        db,
+		ellipsoid: wgs84,
+		asn4:      records4,
+		asn6:      records6,
 	}, nil
 }
 
@@ -71,6 +71,13 @@ func TestDistance(t *testing.T) {
 	}
 }
 
+func TestDistanceNoDB(t *testing.T) {
+	a := LatLong{38.000000, -97.000000}
+	b := LatLong{37.000000, -98.000000}
+	require.Equal(t, 141.0, Distance(a, b))
+	require.Equal(t, 0.0, Distance(a, a))
+}
+
 func BenchmarkDistance(t *testing.B) {
 	db, _ := New(config.GeoDB.Path)
 	defer func() { db.Close() }()
@@ -51,6 +51,8 @@ var promHelp = map[string]string{
 	"t_ann_status_invalid_infohash": "t_ann_status_invalid_infohash is the total count of invalid info hash requests",
 	"t_ann_status_malformed":        "t_ann_status_malformed is the total count of malformed queries",
 	"t_ann_time_ns":                 "t_ann_time_ns is the average time it takes to fulfill a successful announce in nanoseconds",
+	"t_peers_reaped":                "t_peers_reaped is the count of expired peers removed from swarms",
+	"t_sessions_abandoned":          "t_sessions_abandoned is the count of peer sessions ended without a stopped event",
 }
 
 var (
@@ -63,6 +65,8 @@ var (
 	AnnounceStatusUnauthorized    int64
 	AnnounceStatusInvalidInfoHash int64
 	AnnounceStatusMalformed       int64
+	PeersReaped                   int64
+	SessionsAbandoned             int64
 	execLock                      *sync.Mutex
 	AnnounceExecTimesNs           []int64
 )
@@ -99,6 +103,8 @@ type RuntimeMetrics struct {
 	AnnounceStatusInvalidInfoHash int64 `prom:"t_ann_status_invalid_infohash" prom_type:"gauge"`
 	AnnounceStatusMalformed       int64 `prom:"t_ann_status_malformed" prom_type:"gauge"`
 	AnnounceExecTimesNsAvg        int64 `prom:"t_ann_time_ns" prom_type:"gauge"`
+	PeersReaped                   int64 `prom:"t_peers_reaped" prom_type:"gauge"`
+	SessionsAbandoned             int64 `prom:"t_sessions_abandoned" prom_type:"gauge"`
 
 	// GC stats
 	NumGC      int64 `prom:"num_gc" prom_type:"gauge"`
@@ -169,6 +175,8 @@ func Get() RuntimeMetrics {
 	m.AnnounceStatusInvalidInfoHash = atomic.SwapInt64(&AnnounceStatusInvalidInfoHash, 0)
 	m.AnnounceStatusMalformed = atomic.SwapInt64(&AnnounceStatusMalformed, 0)
 	m.AnnounceExecTimesNsAvg = avgExecTime()
+	m.PeersReaped = atomic.SwapInt64(&PeersReaped, 0)
+	m.SessionsAbandoned = atomic.SwapInt64(&SessionsAbandoned, 0)
 	m.NumGC = gc.NumGC
 	m.PauseTotal = gc.PauseTotal.Milliseconds()
 
@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: proto/bonus.proto
+
+package rpc
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type BonusPoints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Points float64 `protobuf:"fixed64,2,opt,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *BonusPoints) Reset() {
+	*x = BonusPoints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bonus_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BonusPoints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BonusPoints) ProtoMessage() {}
+
+func (x *BonusPoints) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bonus_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BonusPoints.ProtoReflect.Descriptor instead.
+func (*BonusPoints) Descriptor() ([]byte, []int) {
+	return file_proto_bonus_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BonusPoints) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BonusPoints) GetPoints() float64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+type BonusParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Must be greater than 0
+	Points float64 `protobuf:"fixed64,2,opt,name=points,proto3" json:"points,omitempty"`
+}
+
+func (x *BonusParams) Reset() {
+	*x = BonusParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bonus_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BonusParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BonusParams) ProtoMessage() {}
+
+func (x *BonusParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bonus_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BonusParams.ProtoReflect.Descriptor instead.
+func (*BonusParams) Descriptor() ([]byte, []int) {
+	return file_proto_bonus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BonusParams) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *BonusParams) GetPoints() float64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+var File_proto_bonus_proto protoreflect.FileDescriptor
+
+var file_proto_bonus_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d, 0x69, 0x6b, 0x61, 0x22, 0x3e, 0x0a, 0x0b, 0x42, 0x6f, 0x6e,
+	0x75, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x3e, 0x0a, 0x0b, 0x42, 0x6f, 0x6e,
+	0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68, 0x6d, 0x61, 0x63,
+	0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72, 0x70, 0x63, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_bonus_proto_rawDescOnce sync.Once
+	file_proto_bonus_proto_rawDescData = file_proto_bonus_proto_rawDesc
+)
+
+func file_proto_bonus_proto_rawDescGZIP() []byte {
+	file_proto_bonus_proto_rawDescOnce.Do(func() {
+		file_proto_bonus_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_bonus_proto_rawDescData)
+	})
+	return file_proto_bonus_proto_rawDescData
+}
+
+var file_proto_bonus_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_bonus_proto_goTypes = []interface{}{
+	(*BonusPoints)(nil), // 0: mika.BonusPoints
+	(*BonusParams)(nil), // 1: mika.BonusParams
+}
+var file_proto_bonus_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_bonus_proto_init() }
+func file_proto_bonus_proto_init() {
+	if File_proto_bonus_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_bonus_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BonusPoints); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bonus_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BonusParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_bonus_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_bonus_proto_goTypes,
+		DependencyIndexes: file_proto_bonus_proto_depIdxs,
+		MessageInfos:      file_proto_bonus_proto_msgTypes,
+	}.Build()
+	File_proto_bonus_proto = out.File
+	file_proto_bonus_proto_rawDesc = nil
+	file_proto_bonus_proto_goTypes = nil
+	file_proto_bonus_proto_depIdxs = nil
+}
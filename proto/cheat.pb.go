@@ -0,0 +1,588 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: proto/cheat.proto
+
+package rpc
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type CheatReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReportId uint32 `protobuf:"varint,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	UserId   uint32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InfoHash []byte `protobuf:"bytes,3,opt,name=info_hash,json=infoHash,proto3" json:"info_hash,omitempty"`
+	AddrIp   string `protobuf:"bytes,4,opt,name=addr_ip,json=addrIp,proto3" json:"addr_ip,omitempty"`
+	Client   string `protobuf:"bytes,5,opt,name=client,proto3" json:"client,omitempty"`
+	// Name of the rule which flagged the announce
+	Rule string `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	// log|drop|disable_download
+	Action string `protobuf:"bytes,7,opt,name=action,proto3" json:"action,omitempty"`
+	// Amount reported since the previous announce of the peer
+	Uploaded uint64 `protobuf:"varint,8,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	// Derived upload speed in bytes/sec
+	Speed     uint64                 `protobuf:"varint,9,opt,name=speed,proto3" json:"speed,omitempty"`
+	Detail    string                 `protobuf:"bytes,10,opt,name=detail,proto3" json:"detail,omitempty"`
+	CreatedOn *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+}
+
+func (x *CheatReport) Reset() {
+	*x = CheatReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cheat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheatReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheatReport) ProtoMessage() {}
+
+func (x *CheatReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cheat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheatReport.ProtoReflect.Descriptor instead.
+func (*CheatReport) Descriptor() ([]byte, []int) {
+	return file_proto_cheat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheatReport) GetReportId() uint32 {
+	if x != nil {
+		return x.ReportId
+	}
+	return 0
+}
+
+func (x *CheatReport) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheatReport) GetInfoHash() []byte {
+	if x != nil {
+		return x.InfoHash
+	}
+	return nil
+}
+
+func (x *CheatReport) GetAddrIp() string {
+	if x != nil {
+		return x.AddrIp
+	}
+	return ""
+}
+
+func (x *CheatReport) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *CheatReport) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *CheatReport) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *CheatReport) GetUploaded() uint64 {
+	if x != nil {
+		return x.Uploaded
+	}
+	return 0
+}
+
+func (x *CheatReport) GetSpeed() uint64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *CheatReport) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *CheatReport) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+type CheatReportParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 for every user
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Empty for every rule
+	Rule string `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *CheatReportParams) Reset() {
+	*x = CheatReportParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cheat_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheatReportParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheatReportParams) ProtoMessage() {}
+
+func (x *CheatReportParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cheat_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheatReportParams.ProtoReflect.Descriptor instead.
+func (*CheatReportParams) Descriptor() ([]byte, []int) {
+	return file_proto_cheat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheatReportParams) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheatReportParams) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+type GhostAnnounce struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InfoHash    []byte                 `protobuf:"bytes,1,opt,name=info_hash,json=infoHash,proto3" json:"info_hash,omitempty"`
+	AnnouncedOn *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=announced_on,json=announcedOn,proto3" json:"announced_on,omitempty"`
+	// Amounts reported since the previous announce of the peer
+	Uploaded   uint64 `protobuf:"varint,3,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	Downloaded uint64 `protobuf:"varint,4,opt,name=downloaded,proto3" json:"downloaded,omitempty"`
+}
+
+func (x *GhostAnnounce) Reset() {
+	*x = GhostAnnounce{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cheat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GhostAnnounce) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GhostAnnounce) ProtoMessage() {}
+
+func (x *GhostAnnounce) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cheat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GhostAnnounce.ProtoReflect.Descriptor instead.
+func (*GhostAnnounce) Descriptor() ([]byte, []int) {
+	return file_proto_cheat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GhostAnnounce) GetInfoHash() []byte {
+	if x != nil {
+		return x.InfoHash
+	}
+	return nil
+}
+
+func (x *GhostAnnounce) GetAnnouncedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnnouncedOn
+	}
+	return nil
+}
+
+func (x *GhostAnnounce) GetUploaded() uint64 {
+	if x != nil {
+		return x.Uploaded
+	}
+	return 0
+}
+
+func (x *GhostAnnounce) GetDownloaded() uint64 {
+	if x != nil {
+		return x.Downloaded
+	}
+	return 0
+}
+
+type GhostProbe struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId    uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartedOn *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_on,json=startedOn,proto3" json:"started_on,omitempty"`
+	// Most recent announces made while probed, oldest first
+	Announces []*GhostAnnounce `protobuf:"bytes,3,rep,name=announces,proto3" json:"announces,omitempty"`
+}
+
+func (x *GhostProbe) Reset() {
+	*x = GhostProbe{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cheat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GhostProbe) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GhostProbe) ProtoMessage() {}
+
+func (x *GhostProbe) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cheat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GhostProbe.ProtoReflect.Descriptor instead.
+func (*GhostProbe) Descriptor() ([]byte, []int) {
+	return file_proto_cheat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GhostProbe) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GhostProbe) GetStartedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedOn
+	}
+	return nil
+}
+
+func (x *GhostProbe) GetAnnounces() []*GhostAnnounce {
+	if x != nil {
+		return x.Announces
+	}
+	return nil
+}
+
+type ProbeParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Starts the probe when true, otherwise stops it
+	Enabled bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *ProbeParams) Reset() {
+	*x = ProbeParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_cheat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeParams) ProtoMessage() {}
+
+func (x *ProbeParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cheat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeParams.ProtoReflect.Descriptor instead.
+func (*ProbeParams) Descriptor() ([]byte, []int) {
+	return file_proto_cheat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProbeParams) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ProbeParams) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+var File_proto_cheat_proto protoreflect.FileDescriptor
+
+var file_proto_cheat_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x68, 0x65, 0x61, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d, 0x69, 0x6b, 0x61, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc2, 0x02, 0x0a, 0x0b, 0x43,
+	0x68, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x48, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x64, 0x64, 0x72, 0x49, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x22,
+	0x40, 0x0a, 0x11, 0x43, 0x68, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x22, 0xa7, 0x01, 0x0a, 0x0d, 0x47, 0x68, 0x6f, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x5f, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x4f, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x22, 0x93, 0x01, 0x0a, 0x0a,
+	0x47, 0x68, 0x6f, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x31,
+	0x0a, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x47, 0x68, 0x6f, 0x73, 0x74, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x73, 0x22, 0x40, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64,
+	0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_proto_cheat_proto_rawDescOnce sync.Once
+	file_proto_cheat_proto_rawDescData = file_proto_cheat_proto_rawDesc
+)
+
+func file_proto_cheat_proto_rawDescGZIP() []byte {
+	file_proto_cheat_proto_rawDescOnce.Do(func() {
+		file_proto_cheat_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_cheat_proto_rawDescData)
+	})
+	return file_proto_cheat_proto_rawDescData
+}
+
+var file_proto_cheat_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_cheat_proto_goTypes = []interface{}{
+	(*CheatReport)(nil),           // 0: mika.CheatReport
+	(*CheatReportParams)(nil),     // 1: mika.CheatReportParams
+	(*GhostAnnounce)(nil),         // 2: mika.GhostAnnounce
+	(*GhostProbe)(nil),            // 3: mika.GhostProbe
+	(*ProbeParams)(nil),           // 4: mika.ProbeParams
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_proto_cheat_proto_depIdxs = []int32{
+	5, // 0: mika.CheatReport.created_on:type_name -> google.protobuf.Timestamp
+	5, // 1: mika.GhostAnnounce.announced_on:type_name -> google.protobuf.Timestamp
+	5, // 2: mika.GhostProbe.started_on:type_name -> google.protobuf.Timestamp
+	2, // 3: mika.GhostProbe.announces:type_name -> mika.GhostAnnounce
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_cheat_proto_init() }
+func file_proto_cheat_proto_init() {
+	if File_proto_cheat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_cheat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheatReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cheat_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheatReportParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cheat_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GhostAnnounce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cheat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GhostProbe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_cheat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProbeParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_cheat_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_cheat_proto_goTypes,
+		DependencyIndexes: file_proto_cheat_proto_depIdxs,
+		MessageInfos:      file_proto_cheat_proto_msgTypes,
+	}.Build()
+	File_proto_cheat_proto = out.File
+	file_proto_cheat_proto_rawDesc = nil
+	file_proto_cheat_proto_goTypes = nil
+	file_proto_cheat_proto_depIdxs = nil
+}
@@ -0,0 +1,348 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: proto/history.proto
+
+package rpc
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type History struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	InfoHash   []byte `protobuf:"bytes,2,opt,name=info_hash,json=infoHash,proto3" json:"info_hash,omitempty"`
+	Uploaded   uint64 `protobuf:"varint,3,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	Downloaded uint64 `protobuf:"varint,4,opt,name=downloaded,proto3" json:"downloaded,omitempty"`
+	// Seconds spent seeding
+	SeedTime int64 `protobuf:"varint,5,opt,name=seed_time,json=seedTime,proto3" json:"seed_time,omitempty"`
+	// Seconds spent leeching
+	LeechTime     int64                  `protobuf:"varint,6,opt,name=leech_time,json=leechTime,proto3" json:"leech_time,omitempty"`
+	AnnounceFirst *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=announce_first,json=announceFirst,proto3" json:"announce_first,omitempty"`
+	AnnounceLast  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=announce_last,json=announceLast,proto3" json:"announce_last,omitempty"`
+	// Unset when the user has not completed the torrent
+	CompletedOn *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_on,json=completedOn,proto3" json:"completed_on,omitempty"`
+	Active      bool                   `protobuf:"varint,10,opt,name=active,proto3" json:"active,omitempty"`
+	Hnr         bool                   `protobuf:"varint,11,opt,name=hnr,proto3" json:"hnr,omitempty"`
+	HnrOn       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=hnr_on,json=hnrOn,proto3" json:"hnr_on,omitempty"`
+}
+
+func (x *History) Reset() {
+	*x = History{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_history_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *History) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*History) ProtoMessage() {}
+
+func (x *History) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use History.ProtoReflect.Descriptor instead.
+func (*History) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *History) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *History) GetInfoHash() []byte {
+	if x != nil {
+		return x.InfoHash
+	}
+	return nil
+}
+
+func (x *History) GetUploaded() uint64 {
+	if x != nil {
+		return x.Uploaded
+	}
+	return 0
+}
+
+func (x *History) GetDownloaded() uint64 {
+	if x != nil {
+		return x.Downloaded
+	}
+	return 0
+}
+
+func (x *History) GetSeedTime() int64 {
+	if x != nil {
+		return x.SeedTime
+	}
+	return 0
+}
+
+func (x *History) GetLeechTime() int64 {
+	if x != nil {
+		return x.LeechTime
+	}
+	return 0
+}
+
+func (x *History) GetAnnounceFirst() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnnounceFirst
+	}
+	return nil
+}
+
+func (x *History) GetAnnounceLast() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnnounceLast
+	}
+	return nil
+}
+
+func (x *History) GetCompletedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedOn
+	}
+	return nil
+}
+
+func (x *History) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *History) GetHnr() bool {
+	if x != nil {
+		return x.Hnr
+	}
+	return false
+}
+
+func (x *History) GetHnrOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HnrOn
+	}
+	return nil
+}
+
+type HnRClearParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// When empty all of the users hit and runs are cleared
+	InfoHash []byte `protobuf:"bytes,2,opt,name=info_hash,json=infoHash,proto3" json:"info_hash,omitempty"`
+}
+
+func (x *HnRClearParams) Reset() {
+	*x = HnRClearParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_history_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HnRClearParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HnRClearParams) ProtoMessage() {}
+
+func (x *HnRClearParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HnRClearParams.ProtoReflect.Descriptor instead.
+func (*HnRClearParams) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HnRClearParams) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *HnRClearParams) GetInfoHash() []byte {
+	if x != nil {
+		return x.InfoHash
+	}
+	return nil
+}
+
+var File_proto_history_proto protoreflect.FileDescriptor
+
+var file_proto_history_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d, 0x69, 0x6b, 0x61, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x03, 0x0a,
+	0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x6f,
+	0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a,
+	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73,
+	0x65, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x65, 0x65, 0x63, 0x68,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6c, 0x65, 0x65,
+	0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x61, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x61, 0x6e, 0x6e,
+	0x6f, 0x75, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x61, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4c, 0x61, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x6e, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x68, 0x6e, 0x72, 0x12, 0x31, 0x0a, 0x06, 0x68, 0x6e, 0x72, 0x5f, 0x6f, 0x6e, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x05, 0x68, 0x6e, 0x72, 0x4f, 0x6e, 0x22, 0x46, 0x0a, 0x0e, 0x48, 0x6e, 0x52, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x48, 0x61, 0x73, 0x68, 0x42, 0x24,
+	0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69,
+	0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61,
+	0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_history_proto_rawDescOnce sync.Once
+	file_proto_history_proto_rawDescData = file_proto_history_proto_rawDesc
+)
+
+func file_proto_history_proto_rawDescGZIP() []byte {
+	file_proto_history_proto_rawDescOnce.Do(func() {
+		file_proto_history_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_history_proto_rawDescData)
+	})
+	return file_proto_history_proto_rawDescData
+}
+
+var file_proto_history_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_history_proto_goTypes = []interface{}{
+	(*History)(nil),               // 0: mika.History
+	(*HnRClearParams)(nil),        // 1: mika.HnRClearParams
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_proto_history_proto_depIdxs = []int32{
+	2, // 0: mika.History.announce_first:type_name -> google.protobuf.Timestamp
+	2, // 1: mika.History.announce_last:type_name -> google.protobuf.Timestamp
+	2, // 2: mika.History.completed_on:type_name -> google.protobuf.Timestamp
+	2, // 3: mika.History.hnr_on:type_name -> google.protobuf.Timestamp
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_history_proto_init() }
+func file_proto_history_proto_init() {
+	if File_proto_history_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_history_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*History); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_history_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HnRClearParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_history_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_history_proto_goTypes,
+		DependencyIndexes: file_proto_history_proto_depIdxs,
+		MessageInfos:      file_proto_history_proto_msgTypes,
+	}.Build()
+	File_proto_history_proto = out.File
+	file_proto_history_proto_rawDesc = nil
+	file_proto_history_proto_goTypes = nil
+	file_proto_history_proto_depIdxs = nil
+}
@@ -34,21 +34,30 @@ var file_proto_mika_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x32, 0xe2, 0x08, 0x0a, 0x04, 0x4d, 0x69, 0x6b, 0x61, 0x12, 0x3e, 0x0a, 0x09, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x68,
+	0x65, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
+	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x80, 0x12, 0x0a, 0x04, 0x4d, 0x69,
+	0x6b, 0x61, 0x12, 0x3e, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x6c, 0x6c, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x61, 0x76, 0x65,
+	0x12, 0x16, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x61,
+	0x76, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x6c,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0a, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x61, 0x76, 0x65, 0x12, 0x16, 0x2e, 0x6d, 0x69, 0x6b, 0x61,
-	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x61, 0x76, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0c, 0x57,
-	0x68, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x64, 0x12, 0x0f, 0x2e, 0x6d, 0x69,
-	0x6b, 0x61, 0x2e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c,
+	0x22, 0x00, 0x12, 0x32, 0x0a, 0x0c, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x41,
+	0x64, 0x64, 0x12, 0x0f, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x57, 0x68, 0x69, 0x74, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0f, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c,
 	0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1b, 0x2e, 0x6d, 0x69, 0x6b, 0x61,
 	0x2e, 0x57, 0x68, 0x69, 0x74, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
 	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
@@ -93,23 +102,97 @@ var file_proto_mika_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
 	0x12, 0x2c, 0x0a, 0x07, 0x55, 0x73, 0x65, 0x72, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x6d, 0x69,
 	0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x64, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22, 0x00, 0x12, 0x31,
-	0x0a, 0x07, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x2c, 0x0a, 0x07, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x6d,
-	0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x12,
-	0x34, 0x0a, 0x0a, 0x52, 0x6f, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0c, 0x2e,
-	0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x08, 0x52, 0x6f, 0x6c, 0x65, 0x53, 0x61, 0x76,
-	0x65, 0x12, 0x0a, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f,
-	0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x11, 0x55, 0x73, 0x65, 0x72, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x73, 0x73,
+	0x6b, 0x65, 0x79, 0x12, 0x19, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b,
+	0x65, 0x79, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x15,
+	0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x52, 0x6f, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x07, 0x52, 0x6f, 0x6c, 0x65, 0x41,
+	0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b,
+	0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x07, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x64, 0x64, 0x12, 0x13, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f, 0x6c,
+	0x65, 0x41, 0x64, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x0a, 0x2e, 0x6d, 0x69, 0x6b,
+	0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0a, 0x52, 0x6f, 0x6c, 0x65,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x6f,
+	0x6c, 0x65, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x30,
+	0x0a, 0x08, 0x52, 0x6f, 0x6c, 0x65, 0x53, 0x61, 0x76, 0x65, 0x12, 0x0a, 0x2e, 0x6d, 0x69, 0x6b,
+	0x61, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x2e, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x0d, 0x2e,
+	0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x39, 0x0a, 0x0f, 0x54, 0x6f, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x6e, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x12, 0x13, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x48,
+	0x61, 0x73, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0d, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x29, 0x0a, 0x06, 0x48,
+	0x6e, 0x52, 0x47, 0x65, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x1a, 0x0d, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3a, 0x0a, 0x08, 0x48, 0x6e, 0x52, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x12, 0x14, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x48, 0x6e, 0x52, 0x43, 0x6c, 0x65,
+	0x61, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x22, 0x00, 0x12, 0x38, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x47, 0x65, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x1a, 0x15, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2d, 0x0a, 0x08,
+	0x42, 0x6f, 0x6e, 0x75, 0x73, 0x47, 0x65, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x11, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42, 0x6f,
+	0x6e, 0x75, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x0a, 0x42,
+	0x6f, 0x6e, 0x75, 0x73, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x12, 0x11, 0x2e, 0x6d, 0x69, 0x6b, 0x61,
+	0x2e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x11, 0x2e, 0x6d,
+	0x69, 0x6b, 0x61, 0x2e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22,
+	0x00, 0x12, 0x34, 0x0a, 0x0a, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x12,
+	0x11, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x11, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x08, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0b, 0x2e, 0x6d, 0x69,
+	0x6b, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x26, 0x0a, 0x08,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x64, 0x64, 0x12, 0x0b, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x0b, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0a,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x6d, 0x69, 0x6b,
+	0x61, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x14, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x46, 0x72, 0x65, 0x65, 0x6c, 0x65,
+	0x65, 0x63, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x0b, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x12, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x61,
+	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x09,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0c, 0x2e, 0x6d, 0x69, 0x6b, 0x61,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x14, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x46,
+	0x72, 0x65, 0x65, 0x6c, 0x65, 0x65, 0x63, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x3e, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x12, 0x17, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x43, 0x68, 0x65, 0x61, 0x74, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x11, 0x2e, 0x6d, 0x69, 0x6b,
+	0x61, 0x2e, 0x43, 0x68, 0x65, 0x61, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0x31, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x53, 0x65, 0x74, 0x12, 0x11, 0x2e,
+	0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x1a, 0x10, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x47, 0x68, 0x6f, 0x73, 0x74, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x41, 0x6c, 0x6c,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e,
+	0x47, 0x68, 0x6f, 0x73, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x35,
+	0x0a, 0x09, 0x54, 0x72, 0x61, 0x70, 0x4c, 0x65, 0x61, 0x6b, 0x73, 0x12, 0x14, 0x2e, 0x6d, 0x69,
+	0x6b, 0x61, 0x2e, 0x54, 0x72, 0x61, 0x70, 0x4c, 0x65, 0x61, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x0e, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x54, 0x72, 0x61, 0x70, 0x4c, 0x65, 0x61,
+	0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x2f, 0x0a, 0x06, 0x42, 0x61, 0x6e, 0x41, 0x6c, 0x6c, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x09, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42,
+	0x61, 0x6e, 0x22, 0x00, 0x30, 0x01, 0x12, 0x20, 0x0a, 0x06, 0x42, 0x61, 0x6e, 0x41, 0x64, 0x64,
+	0x12, 0x09, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42, 0x61, 0x6e, 0x1a, 0x09, 0x2e, 0x6d, 0x69,
+	0x6b, 0x61, 0x2e, 0x42, 0x61, 0x6e, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x09, 0x42, 0x61, 0x6e, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0b, 0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x42, 0x61, 0x6e,
+	0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x42, 0x24, 0x5a, 0x22,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68,
+	0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72,
+	0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var file_proto_mika_proto_goTypes = []interface{}{
@@ -124,13 +207,33 @@ var file_proto_mika_proto_goTypes = []interface{}{
 	(*UserID)(nil),                // 8: mika.UserID
 	(*UserUpdateParams)(nil),      // 9: mika.UserUpdateParams
 	(*UserAddParams)(nil),         // 10: mika.UserAddParams
-	(*RoleAddParams)(nil),         // 11: mika.RoleAddParams
-	(*RoleID)(nil),                // 12: mika.RoleID
-	(*Role)(nil),                  // 13: mika.Role
-	(*ConfigAllResponse)(nil),     // 14: mika.ConfigAllResponse
-	(*WhiteListAllResponse)(nil),  // 15: mika.WhiteListAllResponse
-	(*Torrent)(nil),               // 16: mika.Torrent
-	(*User)(nil),                  // 17: mika.User
+	(*PasskeyRotateParams)(nil),   // 11: mika.PasskeyRotateParams
+	(*RoleAddParams)(nil),         // 12: mika.RoleAddParams
+	(*RoleID)(nil),                // 13: mika.RoleID
+	(*Role)(nil),                  // 14: mika.Role
+	(*HnRClearParams)(nil),        // 15: mika.HnRClearParams
+	(*BonusParams)(nil),           // 16: mika.BonusParams
+	(*Event)(nil),                 // 17: mika.Event
+	(*EventID)(nil),               // 18: mika.EventID
+	(*TokenGrantParams)(nil),      // 19: mika.TokenGrantParams
+	(*TokenRevokeParams)(nil),     // 20: mika.TokenRevokeParams
+	(*CheatReportParams)(nil),     // 21: mika.CheatReportParams
+	(*ProbeParams)(nil),           // 22: mika.ProbeParams
+	(*TrapLeakParams)(nil),        // 23: mika.TrapLeakParams
+	(*Ban)(nil),                   // 24: mika.Ban
+	(*BanID)(nil),                 // 25: mika.BanID
+	(*ConfigAllResponse)(nil),     // 26: mika.ConfigAllResponse
+	(*WhiteListAllResponse)(nil),  // 27: mika.WhiteListAllResponse
+	(*Torrent)(nil),               // 28: mika.Torrent
+	(*User)(nil),                  // 29: mika.User
+	(*PasskeyRotation)(nil),       // 30: mika.PasskeyRotation
+	(*History)(nil),               // 31: mika.History
+	(*RatioWatchEvent)(nil),       // 32: mika.RatioWatchEvent
+	(*BonusPoints)(nil),           // 33: mika.BonusPoints
+	(*FreeleechToken)(nil),        // 34: mika.FreeleechToken
+	(*CheatReport)(nil),           // 35: mika.CheatReport
+	(*GhostProbe)(nil),            // 36: mika.GhostProbe
+	(*TrapLeak)(nil),              // 37: mika.TrapLeak
 }
 var file_proto_mika_proto_depIdxs = []int32{
 	0,  // 0: mika.Mika.ConfigAll:input_type -> google.protobuf.Empty
@@ -149,32 +252,76 @@ var file_proto_mika_proto_depIdxs = []int32{
 	9,  // 13: mika.Mika.UserSave:input_type -> mika.UserUpdateParams
 	8,  // 14: mika.Mika.UserDelete:input_type -> mika.UserID
 	10, // 15: mika.Mika.UserAdd:input_type -> mika.UserAddParams
-	0,  // 16: mika.Mika.RoleAll:input_type -> google.protobuf.Empty
-	11, // 17: mika.Mika.RoleAdd:input_type -> mika.RoleAddParams
-	12, // 18: mika.Mika.RoleDelete:input_type -> mika.RoleID
-	13, // 19: mika.Mika.RoleSave:input_type -> mika.Role
-	14, // 20: mika.Mika.ConfigAll:output_type -> mika.ConfigAllResponse
-	0,  // 21: mika.Mika.ConfigSave:output_type -> google.protobuf.Empty
-	0,  // 22: mika.Mika.WhiteListAdd:output_type -> google.protobuf.Empty
-	0,  // 23: mika.Mika.WhiteListDelete:output_type -> google.protobuf.Empty
-	15, // 24: mika.Mika.WhiteListAll:output_type -> mika.WhiteListAllResponse
-	16, // 25: mika.Mika.TorrentAll:output_type -> mika.Torrent
-	16, // 26: mika.Mika.TorrentGet:output_type -> mika.Torrent
-	16, // 27: mika.Mika.TorrentAdd:output_type -> mika.Torrent
-	0,  // 28: mika.Mika.TorrentDelete:output_type -> google.protobuf.Empty
-	16, // 29: mika.Mika.TorrentUpdate:output_type -> mika.Torrent
-	16, // 30: mika.Mika.TorrentTop:output_type -> mika.Torrent
-	17, // 31: mika.Mika.UserGet:output_type -> mika.User
-	17, // 32: mika.Mika.UserAll:output_type -> mika.User
-	17, // 33: mika.Mika.UserSave:output_type -> mika.User
-	0,  // 34: mika.Mika.UserDelete:output_type -> google.protobuf.Empty
-	17, // 35: mika.Mika.UserAdd:output_type -> mika.User
-	13, // 36: mika.Mika.RoleAll:output_type -> mika.Role
-	13, // 37: mika.Mika.RoleAdd:output_type -> mika.Role
-	0,  // 38: mika.Mika.RoleDelete:output_type -> google.protobuf.Empty
-	0,  // 39: mika.Mika.RoleSave:output_type -> google.protobuf.Empty
-	20, // [20:40] is the sub-list for method output_type
-	0,  // [0:20] is the sub-list for method input_type
+	11, // 16: mika.Mika.UserRotatePasskey:input_type -> mika.PasskeyRotateParams
+	0,  // 17: mika.Mika.RoleAll:input_type -> google.protobuf.Empty
+	12, // 18: mika.Mika.RoleAdd:input_type -> mika.RoleAddParams
+	13, // 19: mika.Mika.RoleDelete:input_type -> mika.RoleID
+	14, // 20: mika.Mika.RoleSave:input_type -> mika.Role
+	8,  // 21: mika.Mika.UserHistory:input_type -> mika.UserID
+	4,  // 22: mika.Mika.TorrentSnatches:input_type -> mika.InfoHashParam
+	8,  // 23: mika.Mika.HnRGet:input_type -> mika.UserID
+	15, // 24: mika.Mika.HnRClear:input_type -> mika.HnRClearParams
+	8,  // 25: mika.Mika.RatioWatchGet:input_type -> mika.UserID
+	8,  // 26: mika.Mika.BonusGet:input_type -> mika.UserID
+	16, // 27: mika.Mika.BonusGrant:input_type -> mika.BonusParams
+	16, // 28: mika.Mika.BonusSpend:input_type -> mika.BonusParams
+	0,  // 29: mika.Mika.EventAll:input_type -> google.protobuf.Empty
+	17, // 30: mika.Mika.EventAdd:input_type -> mika.Event
+	18, // 31: mika.Mika.EventDelete:input_type -> mika.EventID
+	19, // 32: mika.Mika.TokenGrant:input_type -> mika.TokenGrantParams
+	20, // 33: mika.Mika.TokenRevoke:input_type -> mika.TokenRevokeParams
+	8,  // 34: mika.Mika.TokenList:input_type -> mika.UserID
+	21, // 35: mika.Mika.CheatReports:input_type -> mika.CheatReportParams
+	22, // 36: mika.Mika.ProbeSet:input_type -> mika.ProbeParams
+	0,  // 37: mika.Mika.ProbeAll:input_type -> google.protobuf.Empty
+	23, // 38: mika.Mika.TrapLeaks:input_type -> mika.TrapLeakParams
+	0,  // 39: mika.Mika.BanAll:input_type -> google.protobuf.Empty
+	24, // 40: mika.Mika.BanAdd:input_type -> mika.Ban
+	25, // 41: mika.Mika.BanDelete:input_type -> mika.BanID
+	26, // 42: mika.Mika.ConfigAll:output_type -> mika.ConfigAllResponse
+	0,  // 43: mika.Mika.ConfigSave:output_type -> google.protobuf.Empty
+	2,  // 44: mika.Mika.WhiteListAdd:output_type -> mika.WhiteList
+	0,  // 45: mika.Mika.WhiteListDelete:output_type -> google.protobuf.Empty
+	27, // 46: mika.Mika.WhiteListAll:output_type -> mika.WhiteListAllResponse
+	28, // 47: mika.Mika.TorrentAll:output_type -> mika.Torrent
+	28, // 48: mika.Mika.TorrentGet:output_type -> mika.Torrent
+	28, // 49: mika.Mika.TorrentAdd:output_type -> mika.Torrent
+	0,  // 50: mika.Mika.TorrentDelete:output_type -> google.protobuf.Empty
+	28, // 51: mika.Mika.TorrentUpdate:output_type -> mika.Torrent
+	28, // 52: mika.Mika.TorrentTop:output_type -> mika.Torrent
+	29, // 53: mika.Mika.UserGet:output_type -> mika.User
+	29, // 54: mika.Mika.UserAll:output_type -> mika.User
+	29, // 55: mika.Mika.UserSave:output_type -> mika.User
+	0,  // 56: mika.Mika.UserDelete:output_type -> google.protobuf.Empty
+	29, // 57: mika.Mika.UserAdd:output_type -> mika.User
+	30, // 58: mika.Mika.UserRotatePasskey:output_type -> mika.PasskeyRotation
+	14, // 59: mika.Mika.RoleAll:output_type -> mika.Role
+	14, // 60: mika.Mika.RoleAdd:output_type -> mika.Role
+	0,  // 61: mika.Mika.RoleDelete:output_type -> google.protobuf.Empty
+	0,  // 62: mika.Mika.RoleSave:output_type -> google.protobuf.Empty
+	31, // 63: mika.Mika.UserHistory:output_type -> mika.History
+	31, // 64: mika.Mika.TorrentSnatches:output_type -> mika.History
+	31, // 65: mika.Mika.HnRGet:output_type -> mika.History
+	0,  // 66: mika.Mika.HnRClear:output_type -> google.protobuf.Empty
+	32, // 67: mika.Mika.RatioWatchGet:output_type -> mika.RatioWatchEvent
+	33, // 68: mika.Mika.BonusGet:output_type -> mika.BonusPoints
+	33, // 69: mika.Mika.BonusGrant:output_type -> mika.BonusPoints
+	33, // 70: mika.Mika.BonusSpend:output_type -> mika.BonusPoints
+	17, // 71: mika.Mika.EventAll:output_type -> mika.Event
+	17, // 72: mika.Mika.EventAdd:output_type -> mika.Event
+	0,  // 73: mika.Mika.EventDelete:output_type -> google.protobuf.Empty
+	34, // 74: mika.Mika.TokenGrant:output_type -> mika.FreeleechToken
+	0,  // 75: mika.Mika.TokenRevoke:output_type -> google.protobuf.Empty
+	34, // 76: mika.Mika.TokenList:output_type -> mika.FreeleechToken
+	35, // 77: mika.Mika.CheatReports:output_type -> mika.CheatReport
+	36, // 78: mika.Mika.ProbeSet:output_type -> mika.GhostProbe
+	36, // 79: mika.Mika.ProbeAll:output_type -> mika.GhostProbe
+	37, // 80: mika.Mika.TrapLeaks:output_type -> mika.TrapLeak
+	24, // 81: mika.Mika.BanAll:output_type -> mika.Ban
+	24, // 82: mika.Mika.BanAdd:output_type -> mika.Ban
+	0,  // 83: mika.Mika.BanDelete:output_type -> google.protobuf.Empty
+	42, // [42:84] is the sub-list for method output_type
+	0,  // [0:42] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -189,6 +336,14 @@ func file_proto_mika_proto_init() {
 	file_proto_tracker_proto_init()
 	file_proto_role_proto_init()
 	file_proto_user_proto_init()
+	file_proto_history_proto_init()
+	file_proto_ratio_proto_init()
+	file_proto_bonus_proto_init()
+	file_proto_event_proto_init()
+	file_proto_token_proto_init()
+	file_proto_cheat_proto_init()
+	file_proto_trap_proto_init()
+	file_proto_ban_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
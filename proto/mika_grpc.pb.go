@@ -21,7 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 type MikaClient interface {
 	ConfigAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigAllResponse, error)
 	ConfigSave(ctx context.Context, in *ConfigSaveParams, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	WhiteListAdd(ctx context.Context, in *WhiteList, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	WhiteListAdd(ctx context.Context, in *WhiteList, opts ...grpc.CallOption) (*WhiteList, error)
 	WhiteListDelete(ctx context.Context, in *WhiteListDeleteParams, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	WhiteListAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WhiteListAllResponse, error)
 	TorrentAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_TorrentAllClient, error)
@@ -35,10 +35,32 @@ type MikaClient interface {
 	UserSave(ctx context.Context, in *UserUpdateParams, opts ...grpc.CallOption) (*User, error)
 	UserDelete(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UserAdd(ctx context.Context, in *UserAddParams, opts ...grpc.CallOption) (*User, error)
+	UserRotatePasskey(ctx context.Context, in *PasskeyRotateParams, opts ...grpc.CallOption) (*PasskeyRotation, error)
 	RoleAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_RoleAllClient, error)
 	RoleAdd(ctx context.Context, in *RoleAddParams, opts ...grpc.CallOption) (*Role, error)
 	RoleDelete(ctx context.Context, in *RoleID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RoleSave(ctx context.Context, in *Role, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UserHistory(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_UserHistoryClient, error)
+	TorrentSnatches(ctx context.Context, in *InfoHashParam, opts ...grpc.CallOption) (Mika_TorrentSnatchesClient, error)
+	HnRGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_HnRGetClient, error)
+	HnRClear(ctx context.Context, in *HnRClearParams, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RatioWatchGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_RatioWatchGetClient, error)
+	BonusGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*BonusPoints, error)
+	BonusGrant(ctx context.Context, in *BonusParams, opts ...grpc.CallOption) (*BonusPoints, error)
+	BonusSpend(ctx context.Context, in *BonusParams, opts ...grpc.CallOption) (*BonusPoints, error)
+	EventAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_EventAllClient, error)
+	EventAdd(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error)
+	EventDelete(ctx context.Context, in *EventID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TokenGrant(ctx context.Context, in *TokenGrantParams, opts ...grpc.CallOption) (*FreeleechToken, error)
+	TokenRevoke(ctx context.Context, in *TokenRevokeParams, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TokenList(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_TokenListClient, error)
+	CheatReports(ctx context.Context, in *CheatReportParams, opts ...grpc.CallOption) (Mika_CheatReportsClient, error)
+	ProbeSet(ctx context.Context, in *ProbeParams, opts ...grpc.CallOption) (*GhostProbe, error)
+	ProbeAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_ProbeAllClient, error)
+	TrapLeaks(ctx context.Context, in *TrapLeakParams, opts ...grpc.CallOption) (Mika_TrapLeaksClient, error)
+	BanAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_BanAllClient, error)
+	BanAdd(ctx context.Context, in *Ban, opts ...grpc.CallOption) (*Ban, error)
+	BanDelete(ctx context.Context, in *BanID, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type mikaClient struct {
@@ -67,8 +89,8 @@ func (c *mikaClient) ConfigSave(ctx context.Context, in *ConfigSaveParams, opts
 	return out, nil
 }
 
-func (c *mikaClient) WhiteListAdd(ctx context.Context, in *WhiteList, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *mikaClient) WhiteListAdd(ctx context.Context, in *WhiteList, opts ...grpc.CallOption) (*WhiteList, error) {
+	out := new(WhiteList)
 	err := c.cc.Invoke(ctx, "/mika.Mika/WhiteListAdd", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -239,6 +261,15 @@ func (c *mikaClient) UserAdd(ctx context.Context, in *UserAddParams, opts ...grp
 	return out, nil
 }
 
+func (c *mikaClient) UserRotatePasskey(ctx context.Context, in *PasskeyRotateParams, opts ...grpc.CallOption) (*PasskeyRotation, error) {
+	out := new(PasskeyRotation)
+	err := c.cc.Invoke(ctx, "/mika.Mika/UserRotatePasskey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *mikaClient) RoleAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_RoleAllClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[2], "/mika.Mika/RoleAll", opts...)
 	if err != nil {
@@ -298,475 +329,1408 @@ func (c *mikaClient) RoleSave(ctx context.Context, in *Role, opts ...grpc.CallOp
 	return out, nil
 }
 
-// MikaServer is the server API for Mika service.
-// All implementations must embed UnimplementedMikaServer
-// for forward compatibility
-type MikaServer interface {
-	ConfigAll(context.Context, *emptypb.Empty) (*ConfigAllResponse, error)
-	ConfigSave(context.Context, *ConfigSaveParams) (*emptypb.Empty, error)
-	WhiteListAdd(context.Context, *WhiteList) (*emptypb.Empty, error)
-	WhiteListDelete(context.Context, *WhiteListDeleteParams) (*emptypb.Empty, error)
-	WhiteListAll(context.Context, *emptypb.Empty) (*WhiteListAllResponse, error)
-	TorrentAll(*emptypb.Empty, Mika_TorrentAllServer) error
-	TorrentGet(context.Context, *InfoHashParam) (*Torrent, error)
-	TorrentAdd(context.Context, *TorrentAddParams) (*Torrent, error)
-	TorrentDelete(context.Context, *InfoHashParam) (*emptypb.Empty, error)
-	TorrentUpdate(context.Context, *TorrentUpdateParams) (*Torrent, error)
-	TorrentTop(context.Context, *TorrentTopParams) (*Torrent, error)
-	UserGet(context.Context, *UserID) (*User, error)
-	UserAll(*emptypb.Empty, Mika_UserAllServer) error
-	UserSave(context.Context, *UserUpdateParams) (*User, error)
-	UserDelete(context.Context, *UserID) (*emptypb.Empty, error)
-	UserAdd(context.Context, *UserAddParams) (*User, error)
-	RoleAll(*emptypb.Empty, Mika_RoleAllServer) error
-	RoleAdd(context.Context, *RoleAddParams) (*Role, error)
-	RoleDelete(context.Context, *RoleID) (*emptypb.Empty, error)
-	RoleSave(context.Context, *Role) (*emptypb.Empty, error)
-	mustEmbedUnimplementedMikaServer()
+func (c *mikaClient) UserHistory(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_UserHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[3], "/mika.Mika/UserHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaUserHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// UnimplementedMikaServer must be embedded to have forward compatible implementations.
-type UnimplementedMikaServer struct {
+type Mika_UserHistoryClient interface {
+	Recv() (*History, error)
+	grpc.ClientStream
 }
 
-func (UnimplementedMikaServer) ConfigAll(context.Context, *emptypb.Empty) (*ConfigAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ConfigAll not implemented")
+type mikaUserHistoryClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) ConfigSave(context.Context, *ConfigSaveParams) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ConfigSave not implemented")
+
+func (x *mikaUserHistoryClient) Recv() (*History, error) {
+	m := new(History)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedMikaServer) WhiteListAdd(context.Context, *WhiteList) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WhiteListAdd not implemented")
+
+func (c *mikaClient) TorrentSnatches(ctx context.Context, in *InfoHashParam, opts ...grpc.CallOption) (Mika_TorrentSnatchesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[4], "/mika.Mika/TorrentSnatches", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaTorrentSnatchesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedMikaServer) WhiteListDelete(context.Context, *WhiteListDeleteParams) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WhiteListDelete not implemented")
+
+type Mika_TorrentSnatchesClient interface {
+	Recv() (*History, error)
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) WhiteListAll(context.Context, *emptypb.Empty) (*WhiteListAllResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method WhiteListAll not implemented")
+
+type mikaTorrentSnatchesClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) TorrentAll(*emptypb.Empty, Mika_TorrentAllServer) error {
-	return status.Errorf(codes.Unimplemented, "method TorrentAll not implemented")
+
+func (x *mikaTorrentSnatchesClient) Recv() (*History, error) {
+	m := new(History)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedMikaServer) TorrentGet(context.Context, *InfoHashParam) (*Torrent, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TorrentGet not implemented")
+
+func (c *mikaClient) HnRGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_HnRGetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[5], "/mika.Mika/HnRGet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaHnRGetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedMikaServer) TorrentAdd(context.Context, *TorrentAddParams) (*Torrent, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TorrentAdd not implemented")
+
+type Mika_HnRGetClient interface {
+	Recv() (*History, error)
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) TorrentDelete(context.Context, *InfoHashParam) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TorrentDelete not implemented")
+
+type mikaHnRGetClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) TorrentUpdate(context.Context, *TorrentUpdateParams) (*Torrent, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TorrentUpdate not implemented")
+
+func (x *mikaHnRGetClient) Recv() (*History, error) {
+	m := new(History)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedMikaServer) TorrentTop(context.Context, *TorrentTopParams) (*Torrent, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TorrentTop not implemented")
+
+func (c *mikaClient) HnRClear(ctx context.Context, in *HnRClearParams, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mika.Mika/HnRClear", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMikaServer) UserGet(context.Context, *UserID) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserGet not implemented")
+
+func (c *mikaClient) RatioWatchGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_RatioWatchGetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[6], "/mika.Mika/RatioWatchGet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaRatioWatchGetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedMikaServer) UserAll(*emptypb.Empty, Mika_UserAllServer) error {
-	return status.Errorf(codes.Unimplemented, "method UserAll not implemented")
+
+type Mika_RatioWatchGetClient interface {
+	Recv() (*RatioWatchEvent, error)
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) UserSave(context.Context, *UserUpdateParams) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserSave not implemented")
+
+type mikaRatioWatchGetClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) UserDelete(context.Context, *UserID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserDelete not implemented")
+
+func (x *mikaRatioWatchGetClient) Recv() (*RatioWatchEvent, error) {
+	m := new(RatioWatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedMikaServer) UserAdd(context.Context, *UserAddParams) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserAdd not implemented")
+
+func (c *mikaClient) BonusGet(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*BonusPoints, error) {
+	out := new(BonusPoints)
+	err := c.cc.Invoke(ctx, "/mika.Mika/BonusGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMikaServer) RoleAll(*emptypb.Empty, Mika_RoleAllServer) error {
-	return status.Errorf(codes.Unimplemented, "method RoleAll not implemented")
+
+func (c *mikaClient) BonusGrant(ctx context.Context, in *BonusParams, opts ...grpc.CallOption) (*BonusPoints, error) {
+	out := new(BonusPoints)
+	err := c.cc.Invoke(ctx, "/mika.Mika/BonusGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMikaServer) RoleAdd(context.Context, *RoleAddParams) (*Role, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RoleAdd not implemented")
+
+func (c *mikaClient) BonusSpend(ctx context.Context, in *BonusParams, opts ...grpc.CallOption) (*BonusPoints, error) {
+	out := new(BonusPoints)
+	err := c.cc.Invoke(ctx, "/mika.Mika/BonusSpend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedMikaServer) RoleDelete(context.Context, *RoleID) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RoleDelete not implemented")
+
+func (c *mikaClient) EventAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_EventAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[7], "/mika.Mika/EventAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaEventAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedMikaServer) RoleSave(context.Context, *Role) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RoleSave not implemented")
+
+type Mika_EventAllClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
 }
-func (UnimplementedMikaServer) mustEmbedUnimplementedMikaServer() {}
 
-// UnsafeMikaServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to MikaServer will
-// result in compilation errors.
-type UnsafeMikaServer interface {
-	mustEmbedUnimplementedMikaServer()
+type mikaEventAllClient struct {
+	grpc.ClientStream
 }
 
-func RegisterMikaServer(s grpc.ServiceRegistrar, srv MikaServer) {
-	s.RegisterService(&Mika_ServiceDesc, srv)
+func (x *mikaEventAllClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _Mika_ConfigAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
-	if err := dec(in); err != nil {
+func (c *mikaClient) EventAdd(ctx context.Context, in *Event, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/mika.Mika/EventAdd", in, out, opts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(MikaServer).ConfigAll(ctx, in)
+	return out, nil
+}
+
+func (c *mikaClient) EventDelete(ctx context.Context, in *EventID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mika.Mika/EventDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/ConfigAll",
+	return out, nil
+}
+
+func (c *mikaClient) TokenGrant(ctx context.Context, in *TokenGrantParams, opts ...grpc.CallOption) (*FreeleechToken, error) {
+	out := new(FreeleechToken)
+	err := c.cc.Invoke(ctx, "/mika.Mika/TokenGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).ConfigAll(ctx, req.(*emptypb.Empty))
+	return out, nil
+}
+
+func (c *mikaClient) TokenRevoke(ctx context.Context, in *TokenRevokeParams, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mika.Mika/TokenRevoke", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
+}
+
+func (c *mikaClient) TokenList(ctx context.Context, in *UserID, opts ...grpc.CallOption) (Mika_TokenListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[8], "/mika.Mika/TokenList", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaTokenListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mika_TokenListClient interface {
+	Recv() (*FreeleechToken, error)
+	grpc.ClientStream
+}
+
+type mikaTokenListClient struct {
+	grpc.ClientStream
+}
+
+func (x *mikaTokenListClient) Recv() (*FreeleechToken, error) {
+	m := new(FreeleechToken)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mikaClient) CheatReports(ctx context.Context, in *CheatReportParams, opts ...grpc.CallOption) (Mika_CheatReportsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[9], "/mika.Mika/CheatReports", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaCheatReportsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mika_CheatReportsClient interface {
+	Recv() (*CheatReport, error)
+	grpc.ClientStream
+}
+
+type mikaCheatReportsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mikaCheatReportsClient) Recv() (*CheatReport, error) {
+	m := new(CheatReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mikaClient) ProbeSet(ctx context.Context, in *ProbeParams, opts ...grpc.CallOption) (*GhostProbe, error) {
+	out := new(GhostProbe)
+	err := c.cc.Invoke(ctx, "/mika.Mika/ProbeSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mikaClient) ProbeAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_ProbeAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[10], "/mika.Mika/ProbeAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaProbeAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mika_ProbeAllClient interface {
+	Recv() (*GhostProbe, error)
+	grpc.ClientStream
+}
+
+type mikaProbeAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *mikaProbeAllClient) Recv() (*GhostProbe, error) {
+	m := new(GhostProbe)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mikaClient) TrapLeaks(ctx context.Context, in *TrapLeakParams, opts ...grpc.CallOption) (Mika_TrapLeaksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[11], "/mika.Mika/TrapLeaks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaTrapLeaksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mika_TrapLeaksClient interface {
+	Recv() (*TrapLeak, error)
+	grpc.ClientStream
+}
+
+type mikaTrapLeaksClient struct {
+	grpc.ClientStream
+}
+
+func (x *mikaTrapLeaksClient) Recv() (*TrapLeak, error) {
+	m := new(TrapLeak)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mikaClient) BanAll(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Mika_BanAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Mika_ServiceDesc.Streams[12], "/mika.Mika/BanAll", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mikaBanAllClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Mika_BanAllClient interface {
+	Recv() (*Ban, error)
+	grpc.ClientStream
+}
+
+type mikaBanAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *mikaBanAllClient) Recv() (*Ban, error) {
+	m := new(Ban)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mikaClient) BanAdd(ctx context.Context, in *Ban, opts ...grpc.CallOption) (*Ban, error) {
+	out := new(Ban)
+	err := c.cc.Invoke(ctx, "/mika.Mika/BanAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mikaClient) BanDelete(ctx context.Context, in *BanID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/mika.Mika/BanDelete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MikaServer is the server API for Mika service.
+// All implementations must embed UnimplementedMikaServer
+// for forward compatibility
+type MikaServer interface {
+	ConfigAll(context.Context, *emptypb.Empty) (*ConfigAllResponse, error)
+	ConfigSave(context.Context, *ConfigSaveParams) (*emptypb.Empty, error)
+	WhiteListAdd(context.Context, *WhiteList) (*WhiteList, error)
+	WhiteListDelete(context.Context, *WhiteListDeleteParams) (*emptypb.Empty, error)
+	WhiteListAll(context.Context, *emptypb.Empty) (*WhiteListAllResponse, error)
+	TorrentAll(*emptypb.Empty, Mika_TorrentAllServer) error
+	TorrentGet(context.Context, *InfoHashParam) (*Torrent, error)
+	TorrentAdd(context.Context, *TorrentAddParams) (*Torrent, error)
+	TorrentDelete(context.Context, *InfoHashParam) (*emptypb.Empty, error)
+	TorrentUpdate(context.Context, *TorrentUpdateParams) (*Torrent, error)
+	TorrentTop(context.Context, *TorrentTopParams) (*Torrent, error)
+	UserGet(context.Context, *UserID) (*User, error)
+	UserAll(*emptypb.Empty, Mika_UserAllServer) error
+	UserSave(context.Context, *UserUpdateParams) (*User, error)
+	UserDelete(context.Context, *UserID) (*emptypb.Empty, error)
+	UserAdd(context.Context, *UserAddParams) (*User, error)
+	UserRotatePasskey(context.Context, *PasskeyRotateParams) (*PasskeyRotation, error)
+	RoleAll(*emptypb.Empty, Mika_RoleAllServer) error
+	RoleAdd(context.Context, *RoleAddParams) (*Role, error)
+	RoleDelete(context.Context, *RoleID) (*emptypb.Empty, error)
+	RoleSave(context.Context, *Role) (*emptypb.Empty, error)
+	UserHistory(*UserID, Mika_UserHistoryServer) error
+	TorrentSnatches(*InfoHashParam, Mika_TorrentSnatchesServer) error
+	HnRGet(*UserID, Mika_HnRGetServer) error
+	HnRClear(context.Context, *HnRClearParams) (*emptypb.Empty, error)
+	RatioWatchGet(*UserID, Mika_RatioWatchGetServer) error
+	BonusGet(context.Context, *UserID) (*BonusPoints, error)
+	BonusGrant(context.Context, *BonusParams) (*BonusPoints, error)
+	BonusSpend(context.Context, *BonusParams) (*BonusPoints, error)
+	EventAll(*emptypb.Empty, Mika_EventAllServer) error
+	EventAdd(context.Context, *Event) (*Event, error)
+	EventDelete(context.Context, *EventID) (*emptypb.Empty, error)
+	TokenGrant(context.Context, *TokenGrantParams) (*FreeleechToken, error)
+	TokenRevoke(context.Context, *TokenRevokeParams) (*emptypb.Empty, error)
+	TokenList(*UserID, Mika_TokenListServer) error
+	CheatReports(*CheatReportParams, Mika_CheatReportsServer) error
+	ProbeSet(context.Context, *ProbeParams) (*GhostProbe, error)
+	ProbeAll(*emptypb.Empty, Mika_ProbeAllServer) error
+	TrapLeaks(*TrapLeakParams, Mika_TrapLeaksServer) error
+	BanAll(*emptypb.Empty, Mika_BanAllServer) error
+	BanAdd(context.Context, *Ban) (*Ban, error)
+	BanDelete(context.Context, *BanID) (*emptypb.Empty, error)
+	mustEmbedUnimplementedMikaServer()
+}
+
+// UnimplementedMikaServer must be embedded to have forward compatible implementations.
+type UnimplementedMikaServer struct {
+}
+
+func (UnimplementedMikaServer) ConfigAll(context.Context, *emptypb.Empty) (*ConfigAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigAll not implemented")
+}
+func (UnimplementedMikaServer) ConfigSave(context.Context, *ConfigSaveParams) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigSave not implemented")
+}
+func (UnimplementedMikaServer) WhiteListAdd(context.Context, *WhiteList) (*WhiteList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhiteListAdd not implemented")
+}
+func (UnimplementedMikaServer) WhiteListDelete(context.Context, *WhiteListDeleteParams) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhiteListDelete not implemented")
+}
+func (UnimplementedMikaServer) WhiteListAll(context.Context, *emptypb.Empty) (*WhiteListAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WhiteListAll not implemented")
+}
+func (UnimplementedMikaServer) TorrentAll(*emptypb.Empty, Mika_TorrentAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method TorrentAll not implemented")
+}
+func (UnimplementedMikaServer) TorrentGet(context.Context, *InfoHashParam) (*Torrent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TorrentGet not implemented")
+}
+func (UnimplementedMikaServer) TorrentAdd(context.Context, *TorrentAddParams) (*Torrent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TorrentAdd not implemented")
+}
+func (UnimplementedMikaServer) TorrentDelete(context.Context, *InfoHashParam) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TorrentDelete not implemented")
+}
+func (UnimplementedMikaServer) TorrentUpdate(context.Context, *TorrentUpdateParams) (*Torrent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TorrentUpdate not implemented")
+}
+func (UnimplementedMikaServer) TorrentTop(context.Context, *TorrentTopParams) (*Torrent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TorrentTop not implemented")
+}
+func (UnimplementedMikaServer) UserGet(context.Context, *UserID) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGet not implemented")
+}
+func (UnimplementedMikaServer) UserAll(*emptypb.Empty, Mika_UserAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method UserAll not implemented")
+}
+func (UnimplementedMikaServer) UserSave(context.Context, *UserUpdateParams) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserSave not implemented")
+}
+func (UnimplementedMikaServer) UserDelete(context.Context, *UserID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserDelete not implemented")
+}
+func (UnimplementedMikaServer) UserAdd(context.Context, *UserAddParams) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserAdd not implemented")
+}
+func (UnimplementedMikaServer) UserRotatePasskey(context.Context, *PasskeyRotateParams) (*PasskeyRotation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRotatePasskey not implemented")
+}
+func (UnimplementedMikaServer) RoleAll(*emptypb.Empty, Mika_RoleAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method RoleAll not implemented")
+}
+func (UnimplementedMikaServer) RoleAdd(context.Context, *RoleAddParams) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleAdd not implemented")
+}
+func (UnimplementedMikaServer) RoleDelete(context.Context, *RoleID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleDelete not implemented")
+}
+func (UnimplementedMikaServer) RoleSave(context.Context, *Role) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoleSave not implemented")
+}
+func (UnimplementedMikaServer) UserHistory(*UserID, Mika_UserHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method UserHistory not implemented")
+}
+func (UnimplementedMikaServer) TorrentSnatches(*InfoHashParam, Mika_TorrentSnatchesServer) error {
+	return status.Errorf(codes.Unimplemented, "method TorrentSnatches not implemented")
+}
+func (UnimplementedMikaServer) HnRGet(*UserID, Mika_HnRGetServer) error {
+	return status.Errorf(codes.Unimplemented, "method HnRGet not implemented")
+}
+func (UnimplementedMikaServer) HnRClear(context.Context, *HnRClearParams) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HnRClear not implemented")
+}
+func (UnimplementedMikaServer) RatioWatchGet(*UserID, Mika_RatioWatchGetServer) error {
+	return status.Errorf(codes.Unimplemented, "method RatioWatchGet not implemented")
+}
+func (UnimplementedMikaServer) BonusGet(context.Context, *UserID) (*BonusPoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BonusGet not implemented")
+}
+func (UnimplementedMikaServer) BonusGrant(context.Context, *BonusParams) (*BonusPoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BonusGrant not implemented")
+}
+func (UnimplementedMikaServer) BonusSpend(context.Context, *BonusParams) (*BonusPoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BonusSpend not implemented")
+}
+func (UnimplementedMikaServer) EventAll(*emptypb.Empty, Mika_EventAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method EventAll not implemented")
+}
+func (UnimplementedMikaServer) EventAdd(context.Context, *Event) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EventAdd not implemented")
+}
+func (UnimplementedMikaServer) EventDelete(context.Context, *EventID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EventDelete not implemented")
+}
+func (UnimplementedMikaServer) TokenGrant(context.Context, *TokenGrantParams) (*FreeleechToken, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenGrant not implemented")
+}
+func (UnimplementedMikaServer) TokenRevoke(context.Context, *TokenRevokeParams) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenRevoke not implemented")
+}
+func (UnimplementedMikaServer) TokenList(*UserID, Mika_TokenListServer) error {
+	return status.Errorf(codes.Unimplemented, "method TokenList not implemented")
+}
+func (UnimplementedMikaServer) CheatReports(*CheatReportParams, Mika_CheatReportsServer) error {
+	return status.Errorf(codes.Unimplemented, "method CheatReports not implemented")
+}
+func (UnimplementedMikaServer) ProbeSet(context.Context, *ProbeParams) (*GhostProbe, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProbeSet not implemented")
+}
+func (UnimplementedMikaServer) ProbeAll(*emptypb.Empty, Mika_ProbeAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProbeAll not implemented")
+}
+func (UnimplementedMikaServer) TrapLeaks(*TrapLeakParams, Mika_TrapLeaksServer) error {
+	return status.Errorf(codes.Unimplemented, "method TrapLeaks not implemented")
+}
+func (UnimplementedMikaServer) BanAll(*emptypb.Empty, Mika_BanAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method BanAll not implemented")
+}
+func (UnimplementedMikaServer) BanAdd(context.Context, *Ban) (*Ban, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanAdd not implemented")
+}
+func (UnimplementedMikaServer) BanDelete(context.Context, *BanID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanDelete not implemented")
+}
+func (UnimplementedMikaServer) mustEmbedUnimplementedMikaServer() {}
+
+// UnsafeMikaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MikaServer will
+// result in compilation errors.
+type UnsafeMikaServer interface {
+	mustEmbedUnimplementedMikaServer()
+}
+
+func RegisterMikaServer(s grpc.ServiceRegistrar, srv MikaServer) {
+	s.RegisterService(&Mika_ServiceDesc, srv)
+}
+
+func _Mika_ConfigAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).ConfigAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/ConfigAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).ConfigAll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_ConfigSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigSaveParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).ConfigSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/ConfigSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).ConfigSave(ctx, req.(*ConfigSaveParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_WhiteListAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhiteList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).WhiteListAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/WhiteListAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).WhiteListAdd(ctx, req.(*WhiteList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_WhiteListDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhiteListDeleteParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).WhiteListDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/WhiteListDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).WhiteListDelete(ctx, req.(*WhiteListDeleteParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_WhiteListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).WhiteListAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/WhiteListAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).WhiteListAll(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_TorrentAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).TorrentAll(m, &mikaTorrentAllServer{stream})
+}
+
+type Mika_TorrentAllServer interface {
+	Send(*Torrent) error
+	grpc.ServerStream
+}
+
+type mikaTorrentAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaTorrentAllServer) Send(m *Torrent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_TorrentGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoHashParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).TorrentGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/TorrentGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).TorrentGet(ctx, req.(*InfoHashParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_TorrentAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TorrentAddParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).TorrentAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/TorrentAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).TorrentAdd(ctx, req.(*TorrentAddParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_TorrentDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoHashParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).TorrentDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/TorrentDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).TorrentDelete(ctx, req.(*InfoHashParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_TorrentUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TorrentUpdateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).TorrentUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/TorrentUpdate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).TorrentUpdate(ctx, req.(*TorrentUpdateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_TorrentTop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TorrentTopParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).TorrentTop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/TorrentTop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).TorrentTop(ctx, req.(*TorrentTopParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).UserGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/UserGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).UserGet(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).UserAll(m, &mikaUserAllServer{stream})
+}
+
+type Mika_UserAllServer interface {
+	Send(*User) error
+	grpc.ServerStream
+}
+
+type mikaUserAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaUserAllServer) Send(m *User) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_UserSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserUpdateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).UserSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/UserSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).UserSave(ctx, req.(*UserUpdateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).UserDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/UserDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).UserDelete(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserAddParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).UserAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/UserAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).UserAdd(ctx, req.(*UserAddParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserRotatePasskey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PasskeyRotateParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).UserRotatePasskey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/UserRotatePasskey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).UserRotatePasskey(ctx, req.(*PasskeyRotateParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_RoleAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).RoleAll(m, &mikaRoleAllServer{stream})
+}
+
+type Mika_RoleAllServer interface {
+	Send(*Role) error
+	grpc.ServerStream
+}
+
+type mikaRoleAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaRoleAllServer) Send(m *Role) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_RoleAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleAddParams)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).RoleAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/RoleAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).RoleAdd(ctx, req.(*RoleAddParams))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_RoleDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoleID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).RoleDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/RoleDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).RoleDelete(ctx, req.(*RoleID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_RoleSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Role)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MikaServer).RoleSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mika.Mika/RoleSave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MikaServer).RoleSave(ctx, req.(*Role))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Mika_UserHistory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).UserHistory(m, &mikaUserHistoryServer{stream})
+}
+
+type Mika_UserHistoryServer interface {
+	Send(*History) error
+	grpc.ServerStream
+}
+
+type mikaUserHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaUserHistoryServer) Send(m *History) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_TorrentSnatches_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InfoHashParam)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).TorrentSnatches(m, &mikaTorrentSnatchesServer{stream})
+}
+
+type Mika_TorrentSnatchesServer interface {
+	Send(*History) error
+	grpc.ServerStream
+}
+
+type mikaTorrentSnatchesServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaTorrentSnatchesServer) Send(m *History) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_HnRGet_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).HnRGet(m, &mikaHnRGetServer{stream})
+}
+
+type Mika_HnRGetServer interface {
+	Send(*History) error
+	grpc.ServerStream
+}
+
+type mikaHnRGetServer struct {
+	grpc.ServerStream
 }
 
-func _Mika_ConfigSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConfigSaveParams)
+func (x *mikaHnRGetServer) Send(m *History) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_HnRClear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HnRClearParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).ConfigSave(ctx, in)
+		return srv.(MikaServer).HnRClear(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/ConfigSave",
+		FullMethod: "/mika.Mika/HnRClear",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).ConfigSave(ctx, req.(*ConfigSaveParams))
+		return srv.(MikaServer).HnRClear(ctx, req.(*HnRClearParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_WhiteListAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WhiteList)
+func _Mika_RatioWatchGet_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MikaServer).RatioWatchGet(m, &mikaRatioWatchGetServer{stream})
+}
+
+type Mika_RatioWatchGetServer interface {
+	Send(*RatioWatchEvent) error
+	grpc.ServerStream
+}
+
+type mikaRatioWatchGetServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaRatioWatchGetServer) Send(m *RatioWatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_BonusGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).WhiteListAdd(ctx, in)
+		return srv.(MikaServer).BonusGet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/WhiteListAdd",
+		FullMethod: "/mika.Mika/BonusGet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).WhiteListAdd(ctx, req.(*WhiteList))
+		return srv.(MikaServer).BonusGet(ctx, req.(*UserID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_WhiteListDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(WhiteListDeleteParams)
+func _Mika_BonusGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BonusParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).WhiteListDelete(ctx, in)
+		return srv.(MikaServer).BonusGrant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/WhiteListDelete",
+		FullMethod: "/mika.Mika/BonusGrant",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).WhiteListDelete(ctx, req.(*WhiteListDeleteParams))
+		return srv.(MikaServer).BonusGrant(ctx, req.(*BonusParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_WhiteListAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _Mika_BonusSpend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BonusParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).WhiteListAll(ctx, in)
+		return srv.(MikaServer).BonusSpend(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/WhiteListAll",
+		FullMethod: "/mika.Mika/BonusSpend",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).WhiteListAll(ctx, req.(*emptypb.Empty))
+		return srv.(MikaServer).BonusSpend(ctx, req.(*BonusParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_TorrentAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _Mika_EventAll_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(emptypb.Empty)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(MikaServer).TorrentAll(m, &mikaTorrentAllServer{stream})
+	return srv.(MikaServer).EventAll(m, &mikaEventAllServer{stream})
 }
 
-type Mika_TorrentAllServer interface {
-	Send(*Torrent) error
+type Mika_EventAllServer interface {
+	Send(*Event) error
 	grpc.ServerStream
 }
 
-type mikaTorrentAllServer struct {
+type mikaEventAllServer struct {
 	grpc.ServerStream
 }
 
-func (x *mikaTorrentAllServer) Send(m *Torrent) error {
+func (x *mikaEventAllServer) Send(m *Event) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Mika_TorrentGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InfoHashParam)
+func _Mika_EventAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Event)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).TorrentGet(ctx, in)
+		return srv.(MikaServer).EventAdd(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/TorrentGet",
+		FullMethod: "/mika.Mika/EventAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).TorrentGet(ctx, req.(*InfoHashParam))
+		return srv.(MikaServer).EventAdd(ctx, req.(*Event))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_TorrentAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TorrentAddParams)
+func _Mika_EventDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).TorrentAdd(ctx, in)
+		return srv.(MikaServer).EventDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/TorrentAdd",
+		FullMethod: "/mika.Mika/EventDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).TorrentAdd(ctx, req.(*TorrentAddParams))
+		return srv.(MikaServer).EventDelete(ctx, req.(*EventID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_TorrentDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InfoHashParam)
+func _Mika_TokenGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenGrantParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).TorrentDelete(ctx, in)
+		return srv.(MikaServer).TokenGrant(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/TorrentDelete",
+		FullMethod: "/mika.Mika/TokenGrant",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).TorrentDelete(ctx, req.(*InfoHashParam))
+		return srv.(MikaServer).TokenGrant(ctx, req.(*TokenGrantParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_TorrentUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TorrentUpdateParams)
+func _Mika_TokenRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRevokeParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).TorrentUpdate(ctx, in)
+		return srv.(MikaServer).TokenRevoke(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/TorrentUpdate",
+		FullMethod: "/mika.Mika/TokenRevoke",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).TorrentUpdate(ctx, req.(*TorrentUpdateParams))
+		return srv.(MikaServer).TokenRevoke(ctx, req.(*TokenRevokeParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_TorrentTop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TorrentTopParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MikaServer).TorrentTop(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/TorrentTop",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).TorrentTop(ctx, req.(*TorrentTopParams))
+func _Mika_TokenList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UserID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(MikaServer).TokenList(m, &mikaTokenListServer{stream})
 }
 
-func _Mika_UserGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MikaServer).UserGet(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/UserGet",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).UserGet(ctx, req.(*UserID))
-	}
-	return interceptor(ctx, in, info, handler)
+type Mika_TokenListServer interface {
+	Send(*FreeleechToken) error
+	grpc.ServerStream
 }
 
-func _Mika_UserAll_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+type mikaTokenListServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaTokenListServer) Send(m *FreeleechToken) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_CheatReports_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CheatReportParams)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(MikaServer).UserAll(m, &mikaUserAllServer{stream})
+	return srv.(MikaServer).CheatReports(m, &mikaCheatReportsServer{stream})
 }
 
-type Mika_UserAllServer interface {
-	Send(*User) error
+type Mika_CheatReportsServer interface {
+	Send(*CheatReport) error
 	grpc.ServerStream
 }
 
-type mikaUserAllServer struct {
+type mikaCheatReportsServer struct {
 	grpc.ServerStream
 }
 
-func (x *mikaUserAllServer) Send(m *User) error {
+func (x *mikaCheatReportsServer) Send(m *CheatReport) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Mika_UserSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserUpdateParams)
+func _Mika_ProbeSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeParams)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).UserSave(ctx, in)
+		return srv.(MikaServer).ProbeSet(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/UserSave",
+		FullMethod: "/mika.Mika/ProbeSet",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).UserSave(ctx, req.(*UserUpdateParams))
+		return srv.(MikaServer).ProbeSet(ctx, req.(*ProbeParams))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_UserDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MikaServer).UserDelete(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/UserDelete",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).UserDelete(ctx, req.(*UserID))
+func _Mika_ProbeAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(MikaServer).ProbeAll(m, &mikaProbeAllServer{stream})
 }
 
-func _Mika_UserAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserAddParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MikaServer).UserAdd(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/UserAdd",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).UserAdd(ctx, req.(*UserAddParams))
-	}
-	return interceptor(ctx, in, info, handler)
+type Mika_ProbeAllServer interface {
+	Send(*GhostProbe) error
+	grpc.ServerStream
 }
 
-func _Mika_RoleAll_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(emptypb.Empty)
+type mikaProbeAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaProbeAllServer) Send(m *GhostProbe) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_TrapLeaks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrapLeakParams)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(MikaServer).RoleAll(m, &mikaRoleAllServer{stream})
+	return srv.(MikaServer).TrapLeaks(m, &mikaTrapLeaksServer{stream})
 }
 
-type Mika_RoleAllServer interface {
-	Send(*Role) error
+type Mika_TrapLeaksServer interface {
+	Send(*TrapLeak) error
 	grpc.ServerStream
 }
 
-type mikaRoleAllServer struct {
+type mikaTrapLeaksServer struct {
 	grpc.ServerStream
 }
 
-func (x *mikaRoleAllServer) Send(m *Role) error {
+func (x *mikaTrapLeaksServer) Send(m *TrapLeak) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Mika_RoleAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RoleAddParams)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(MikaServer).RoleAdd(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/mika.Mika/RoleAdd",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).RoleAdd(ctx, req.(*RoleAddParams))
+func _Mika_BanAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(MikaServer).BanAll(m, &mikaBanAllServer{stream})
 }
 
-func _Mika_RoleDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RoleID)
+type Mika_BanAllServer interface {
+	Send(*Ban) error
+	grpc.ServerStream
+}
+
+type mikaBanAllServer struct {
+	grpc.ServerStream
+}
+
+func (x *mikaBanAllServer) Send(m *Ban) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Mika_BanAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ban)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).RoleDelete(ctx, in)
+		return srv.(MikaServer).BanAdd(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/RoleDelete",
+		FullMethod: "/mika.Mika/BanAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).RoleDelete(ctx, req.(*RoleID))
+		return srv.(MikaServer).BanAdd(ctx, req.(*Ban))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Mika_RoleSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Role)
+func _Mika_BanDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(MikaServer).RoleSave(ctx, in)
+		return srv.(MikaServer).BanDelete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/mika.Mika/RoleSave",
+		FullMethod: "/mika.Mika/BanDelete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(MikaServer).RoleSave(ctx, req.(*Role))
+		return srv.(MikaServer).BanDelete(ctx, req.(*BanID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -834,6 +1798,10 @@ var Mika_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserAdd",
 			Handler:    _Mika_UserAdd_Handler,
 		},
+		{
+			MethodName: "UserRotatePasskey",
+			Handler:    _Mika_UserRotatePasskey_Handler,
+		},
 		{
 			MethodName: "RoleAdd",
 			Handler:    _Mika_RoleAdd_Handler,
@@ -846,6 +1814,50 @@ var Mika_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RoleSave",
 			Handler:    _Mika_RoleSave_Handler,
 		},
+		{
+			MethodName: "HnRClear",
+			Handler:    _Mika_HnRClear_Handler,
+		},
+		{
+			MethodName: "BonusGet",
+			Handler:    _Mika_BonusGet_Handler,
+		},
+		{
+			MethodName: "BonusGrant",
+			Handler:    _Mika_BonusGrant_Handler,
+		},
+		{
+			MethodName: "BonusSpend",
+			Handler:    _Mika_BonusSpend_Handler,
+		},
+		{
+			MethodName: "EventAdd",
+			Handler:    _Mika_EventAdd_Handler,
+		},
+		{
+			MethodName: "EventDelete",
+			Handler:    _Mika_EventDelete_Handler,
+		},
+		{
+			MethodName: "TokenGrant",
+			Handler:    _Mika_TokenGrant_Handler,
+		},
+		{
+			MethodName: "TokenRevoke",
+			Handler:    _Mika_TokenRevoke_Handler,
+		},
+		{
+			MethodName: "ProbeSet",
+			Handler:    _Mika_ProbeSet_Handler,
+		},
+		{
+			MethodName: "BanAdd",
+			Handler:    _Mika_BanAdd_Handler,
+		},
+		{
+			MethodName: "BanDelete",
+			Handler:    _Mika_BanDelete_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -863,6 +1875,56 @@ var Mika_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Mika_RoleAll_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "UserHistory",
+			Handler:       _Mika_UserHistory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TorrentSnatches",
+			Handler:       _Mika_TorrentSnatches_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "HnRGet",
+			Handler:       _Mika_HnRGet_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RatioWatchGet",
+			Handler:       _Mika_RatioWatchGet_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "EventAll",
+			Handler:       _Mika_EventAll_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TokenList",
+			Handler:       _Mika_TokenList_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CheatReports",
+			Handler:       _Mika_CheatReports_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ProbeAll",
+			Handler:       _Mika_ProbeAll_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TrapLeaks",
+			Handler:       _Mika_TrapLeaks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BanAll",
+			Handler:       _Mika_BanAll_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/mika.proto",
 }
@@ -39,6 +39,9 @@ type Role struct {
 	MultiUp         float64   `protobuf:"fixed64,7,opt,name=multi_up,json=multiUp,proto3" json:"multi_up,omitempty"`
 	MultiDown       float64   `protobuf:"fixed64,8,opt,name=multi_down,json=multiDown,proto3" json:"multi_down,omitempty"`
 	Time            *TimeMeta `protobuf:"bytes,9,opt,name=time,proto3" json:"time,omitempty"`
+	MaxPeers        uint32    `protobuf:"varint,10,opt,name=max_peers,json=maxPeers,proto3" json:"max_peers,omitempty"`
+	MaxHnr          uint32    `protobuf:"varint,11,opt,name=max_hnr,json=maxHnr,proto3" json:"max_hnr,omitempty"`
+	MaxLeeching     uint32    `protobuf:"varint,12,opt,name=max_leeching,json=maxLeeching,proto3" json:"max_leeching,omitempty"`
 }
 
 func (x *Role) Reset() {
@@ -136,6 +139,27 @@ func (x *Role) GetTime() *TimeMeta {
 	return nil
 }
 
+func (x *Role) GetMaxPeers() uint32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *Role) GetMaxHnr() uint32 {
+	if x != nil {
+		return x.MaxHnr
+	}
+	return 0
+}
+
+func (x *Role) GetMaxLeeching() uint32 {
+	if x != nil {
+		return x.MaxLeeching
+	}
+	return 0
+}
+
 type RoleID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -203,6 +227,9 @@ type RoleAddParams struct {
 	UploadEnabled   bool    `protobuf:"varint,6,opt,name=upload_enabled,json=uploadEnabled,proto3" json:"upload_enabled,omitempty"`
 	MultiUp         float64 `protobuf:"fixed64,7,opt,name=multi_up,json=multiUp,proto3" json:"multi_up,omitempty"`
 	MultiDown       float64 `protobuf:"fixed64,8,opt,name=multi_down,json=multiDown,proto3" json:"multi_down,omitempty"`
+	MaxPeers        uint32  `protobuf:"varint,9,opt,name=max_peers,json=maxPeers,proto3" json:"max_peers,omitempty"`
+	MaxHnr          uint32  `protobuf:"varint,10,opt,name=max_hnr,json=maxHnr,proto3" json:"max_hnr,omitempty"`
+	MaxLeeching     uint32  `protobuf:"varint,11,opt,name=max_leeching,json=maxLeeching,proto3" json:"max_leeching,omitempty"`
 }
 
 func (x *RoleAddParams) Reset() {
@@ -286,6 +313,27 @@ func (x *RoleAddParams) GetMultiDown() float64 {
 	return 0
 }
 
+func (x *RoleAddParams) GetMaxPeers() uint32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *RoleAddParams) GetMaxHnr() uint32 {
+	if x != nil {
+		return x.MaxHnr
+	}
+	return 0
+}
+
+func (x *RoleAddParams) GetMaxLeeching() uint32 {
+	if x != nil {
+		return x.MaxLeeching
+	}
+	return 0
+}
+
 type RoleSetParams struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -299,6 +347,9 @@ type RoleSetParams struct {
 	UploadEnabled   bool     `protobuf:"varint,6,opt,name=upload_enabled,json=uploadEnabled,proto3" json:"upload_enabled,omitempty"`
 	MultiUp         float64  `protobuf:"fixed64,7,opt,name=multi_up,json=multiUp,proto3" json:"multi_up,omitempty"`
 	MultiDown       float64  `protobuf:"fixed64,8,opt,name=multi_down,json=multiDown,proto3" json:"multi_down,omitempty"`
+	MaxPeers        uint32   `protobuf:"varint,9,opt,name=max_peers,json=maxPeers,proto3" json:"max_peers,omitempty"`
+	MaxHnr          uint32   `protobuf:"varint,10,opt,name=max_hnr,json=maxHnr,proto3" json:"max_hnr,omitempty"`
+	MaxLeeching     uint32   `protobuf:"varint,11,opt,name=max_leeching,json=maxLeeching,proto3" json:"max_leeching,omitempty"`
 }
 
 func (x *RoleSetParams) Reset() {
@@ -389,12 +440,33 @@ func (x *RoleSetParams) GetMultiDown() float64 {
 	return 0
 }
 
+func (x *RoleSetParams) GetMaxPeers() uint32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *RoleSetParams) GetMaxHnr() uint32 {
+	if x != nil {
+		return x.MaxHnr
+	}
+	return 0
+}
+
+func (x *RoleSetParams) GetMaxLeeching() uint32 {
+	if x != nil {
+		return x.MaxLeeching
+	}
+	return 0
+}
+
 var File_proto_role_proto protoreflect.FileDescriptor
 
 var file_proto_role_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x6f, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x04, 0x6d, 0x69, 0x6b, 0x61, 0x1a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x02, 0x0a,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfe, 0x02, 0x0a,
 	0x04, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1b,
 	0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
@@ -413,46 +485,63 @@ var file_proto_role_proto_rawDesc = []byte{
 	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x6f, 0x77, 0x6e,
 	0x12, 0x22, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
 	0x2e, 0x6d, 0x69, 0x6b, 0x61, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04,
-	0x74, 0x69, 0x6d, 0x65, 0x22, 0x3e, 0x0a, 0x06, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x17,
-	0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x22, 0xf1, 0x01, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x64, 0x64,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64,
-	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10,
-	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
-	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x19,
-	0x0a, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x07, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x75, 0x6c,
-	0x74, 0x69, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d,
-	0x75, 0x6c, 0x74, 0x69, 0x44, 0x6f, 0x77, 0x6e, 0x22, 0x94, 0x02, 0x0a, 0x0d, 0x52, 0x6f, 0x6c,
-	0x65, 0x53, 0x65, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x75, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1b, 0x0a,
-	0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
-	0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
-	0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x64,
-	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x25,
-	0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x75,
-	0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x55, 0x70,
-	0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x6f, 0x77, 0x6e, 0x42,
-	0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65,
-	0x69, 0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b,
-	0x61, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x65, 0x72,
+	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x6e, 0x72, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x48, 0x6e, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61,
+	0x78, 0x5f, 0x6c, 0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x4c, 0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x22, 0x3e, 0x0a,
+	0x06, 0x52, 0x6f, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xca, 0x02,
+	0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x5f, 0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x55, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x64, 0x6f, 0x77, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x6f, 0x77,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x6e, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6d, 0x61, 0x78, 0x48, 0x6e, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x4c, 0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x22, 0xed, 0x02, 0x0a, 0x0d, 0x52,
+	0x6f, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x5f, 0x75, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x55, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x64, 0x6f, 0x77, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x6f, 0x77,
+	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x17,
+	0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x6e, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6d, 0x61, 0x78, 0x48, 0x6e, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x6c,
+	0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x4c, 0x65, 0x65, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68, 0x6d, 0x61,
+	0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c, 0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.14.0
+// source: proto/trap.proto
+
+package rpc
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type TrapLeak struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LeakId   uint32 `protobuf:"varint,1,opt,name=leak_id,json=leakId,proto3" json:"leak_id,omitempty"`
+	InfoHash []byte `protobuf:"bytes,2,opt,name=info_hash,json=infoHash,proto3" json:"info_hash,omitempty"`
+	// User the trap was registered to and who leaked it
+	UserId uint32 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User who announced the trap, 0 when the announce had no valid passkey
+	AnnouncerId uint32 `protobuf:"varint,4,opt,name=announcer_id,json=announcerId,proto3" json:"announcer_id,omitempty"`
+	AddrIp      string `protobuf:"bytes,5,opt,name=addr_ip,json=addrIp,proto3" json:"addr_ip,omitempty"`
+	Client      string `protobuf:"bytes,6,opt,name=client,proto3" json:"client,omitempty"`
+	// True when the passkey of the user was reset because of the leak
+	PasskeyReset bool                   `protobuf:"varint,7,opt,name=passkey_reset,json=passkeyReset,proto3" json:"passkey_reset,omitempty"`
+	CreatedOn    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_on,json=createdOn,proto3" json:"created_on,omitempty"`
+}
+
+func (x *TrapLeak) Reset() {
+	*x = TrapLeak{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_trap_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrapLeak) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrapLeak) ProtoMessage() {}
+
+func (x *TrapLeak) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trap_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrapLeak.ProtoReflect.Descriptor instead.
+func (*TrapLeak) Descriptor() ([]byte, []int) {
+	return file_proto_trap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrapLeak) GetLeakId() uint32 {
+	if x != nil {
+		return x.LeakId
+	}
+	return 0
+}
+
+func (x *TrapLeak) GetInfoHash() []byte {
+	if x != nil {
+		return x.InfoHash
+	}
+	return nil
+}
+
+func (x *TrapLeak) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *TrapLeak) GetAnnouncerId() uint32 {
+	if x != nil {
+		return x.AnnouncerId
+	}
+	return 0
+}
+
+func (x *TrapLeak) GetAddrIp() string {
+	if x != nil {
+		return x.AddrIp
+	}
+	return ""
+}
+
+func (x *TrapLeak) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *TrapLeak) GetPasskeyReset() bool {
+	if x != nil {
+		return x.PasskeyReset
+	}
+	return false
+}
+
+func (x *TrapLeak) GetCreatedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedOn
+	}
+	return nil
+}
+
+type TrapLeakParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 0 for every user
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *TrapLeakParams) Reset() {
+	*x = TrapLeakParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_trap_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrapLeakParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrapLeakParams) ProtoMessage() {}
+
+func (x *TrapLeakParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_trap_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrapLeakParams.ProtoReflect.Descriptor instead.
+func (*TrapLeakParams) Descriptor() ([]byte, []int) {
+	return file_proto_trap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TrapLeakParams) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+var File_proto_trap_proto protoreflect.FileDescriptor
+
+var file_proto_trap_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x6d, 0x69, 0x6b, 0x61, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8d, 0x02, 0x0a, 0x08, 0x54, 0x72,
+	0x61, 0x70, 0x4c, 0x65, 0x61, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x65, 0x61, 0x6b, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x65, 0x61, 0x6b, 0x49, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x69, 0x6e, 0x66, 0x6f, 0x48, 0x61, 0x73, 0x68, 0x12, 0x17, 0x0a, 0x07,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x61, 0x6e, 0x6e,
+	0x6f, 0x75, 0x6e, 0x63, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x64, 0x64, 0x72, 0x49,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x73,
+	0x73, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x6e, 0x22, 0x29, 0x0a, 0x0e, 0x54, 0x72, 0x61,
+	0x70, 0x4c, 0x65, 0x61, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6c, 0x65, 0x69, 0x67, 0x68, 0x6d, 0x61, 0x63, 0x64, 0x6f, 0x6e, 0x61, 0x6c,
+	0x64, 0x2f, 0x6d, 0x69, 0x6b, 0x61, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_proto_trap_proto_rawDescOnce sync.Once
+	file_proto_trap_proto_rawDescData = file_proto_trap_proto_rawDesc
+)
+
+func file_proto_trap_proto_rawDescGZIP() []byte {
+	file_proto_trap_proto_rawDescOnce.Do(func() {
+		file_proto_trap_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_trap_proto_rawDescData)
+	})
+	return file_proto_trap_proto_rawDescData
+}
+
+var file_proto_trap_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_trap_proto_goTypes = []interface{}{
+	(*TrapLeak)(nil),              // 0: mika.TrapLeak
+	(*TrapLeakParams)(nil),        // 1: mika.TrapLeakParams
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_proto_trap_proto_depIdxs = []int32{
+	2, // 0: mika.TrapLeak.created_on:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_trap_proto_init() }
+func file_proto_trap_proto_init() {
+	if File_proto_trap_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_trap_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrapLeak); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_trap_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrapLeakParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_trap_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_trap_proto_goTypes,
+		DependencyIndexes: file_proto_trap_proto_depIdxs,
+		MessageInfos:      file_proto_trap_proto_msgTypes,
+	}.Build()
+	File_proto_trap_proto = out.File
+	file_proto_trap_proto_rawDesc = nil
+	file_proto_trap_proto_goTypes = nil
+	file_proto_trap_proto_depIdxs = nil
+}
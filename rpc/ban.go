@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func BanToPB(b store.Ban) *pb.Ban {
+	return &pb.Ban{
+		BanId:     b.BanID,
+		Kind:      b.Kind,
+		Value:     b.Value,
+		Reason:    b.Reason,
+		Expires:   optionalTimestamp(b.Expires),
+		CreatedOn: timestamppb.New(b.CreatedOn),
+	}
+}
+
+func PBToBan(b *pb.Ban) store.Ban {
+	ban := store.Ban{
+		BanID:     b.BanId,
+		Kind:      b.Kind,
+		Value:     b.Value,
+		Reason:    b.Reason,
+		CreatedOn: b.CreatedOn.AsTime(),
+	}
+	if b.Expires != nil {
+		ban.Expires = b.Expires.AsTime()
+	}
+	return ban
+}
+
+func (s *MikaService) BanAll(_ *emptypb.Empty, stream pb.Mika_BanAllServer) error {
+	for _, b := range tracker.Bans() {
+		if err := stream.Send(BanToPB(b)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send ban")
+		}
+	}
+	return nil
+}
+
+func (s *MikaService) BanAdd(_ context.Context, params *pb.Ban) (*pb.Ban, error) {
+	b := PBToBan(params)
+	b.BanID = 0
+	b.CreatedOn = time.Time{}
+	if err := tracker.BanAdd(&b); err != nil {
+		if errors.Is(err, consts.ErrMalformedRequest) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ban: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to add ban")
+	}
+	return BanToPB(b), nil
+}
+
+func (s *MikaService) BanDelete(_ context.Context, params *pb.BanID) (*emptypb.Empty, error) {
+	if err := tracker.BanDelete(params.BanId); err != nil {
+		if errors.Is(err, consts.ErrInvalidBan) {
+			return nil, status.Errorf(codes.NotFound, "ban doesnt exist")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete ban")
+	}
+	return &emptypb.Empty{}, nil
+}
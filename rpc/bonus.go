@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *MikaService) BonusGet(_ context.Context, userID *pb.UserID) (*pb.BonusPoints, error) {
+	u, err := findUser(userID)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	return &pb.BonusPoints{UserId: u.UserID, Points: tracker.BonusPoints(u)}, nil
+}
+
+func (s *MikaService) BonusGrant(_ context.Context, params *pb.BonusParams) (*pb.BonusPoints, error) {
+	u, err := tracker.UserGetByUserID(params.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+	}
+	points, err := tracker.BonusGrant(u, params.Points)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "points must be greater than 0")
+	}
+	return &pb.BonusPoints{UserId: u.UserID, Points: points}, nil
+}
+
+func (s *MikaService) BonusSpend(_ context.Context, params *pb.BonusParams) (*pb.BonusPoints, error) {
+	u, err := tracker.UserGetByUserID(params.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+	}
+	points, err := tracker.BonusSpend(u, params.Points)
+	if err != nil {
+		if errors.Is(err, consts.ErrInsufficientPoints) {
+			return nil, status.Errorf(codes.FailedPrecondition, "insufficient bonus points")
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "points must be greater than 0")
+	}
+	return &pb.BonusPoints{UserId: u.UserID, Points: points}, nil
+}
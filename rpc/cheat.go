@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func CheatReportToPB(r store.CheatReport) *pb.CheatReport {
+	return &pb.CheatReport{
+		ReportId:  r.ReportID,
+		UserId:    r.UserID,
+		InfoHash:  r.InfoHash.Bytes(),
+		AddrIp:    r.IP,
+		Client:    r.Client,
+		Rule:      r.Rule,
+		Action:    r.Action,
+		Uploaded:  r.Uploaded,
+		Speed:     r.Speed,
+		Detail:    r.Detail,
+		CreatedOn: timestamppb.New(r.CreatedOn),
+	}
+}
+
+func (s *MikaService) CheatReports(params *pb.CheatReportParams, stream pb.Mika_CheatReportsServer) error {
+	reports, err := tracker.CheatReports(params.UserId, params.Rule)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch cheat reports")
+	}
+	for _, r := range reports {
+		if err := stream.Send(CheatReportToPB(r)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send cheat report")
+		}
+	}
+	return nil
+}
+
+func GhostProbeToPB(p store.GhostProbe) *pb.GhostProbe {
+	probe := &pb.GhostProbe{
+		UserId:    p.UserID,
+		StartedOn: timestamppb.New(p.StartedOn),
+	}
+	for _, a := range p.Announces {
+		probe.Announces = append(probe.Announces, &pb.GhostAnnounce{
+			InfoHash:    a.InfoHash.Bytes(),
+			AnnouncedOn: timestamppb.New(a.AnnouncedOn),
+			Uploaded:    a.Uploaded,
+			Downloaded:  a.Downloaded,
+		})
+	}
+	return probe
+}
+
+func (s *MikaService) ProbeSet(_ context.Context, params *pb.ProbeParams) (*pb.GhostProbe, error) {
+	var (
+		p   store.GhostProbe
+		err error
+	)
+	if params.Enabled {
+		p, err = tracker.ProbeStart(params.UserId)
+	} else {
+		p, err = tracker.ProbeStop(params.UserId)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, consts.ErrInvalidUser):
+			return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+		case errors.Is(err, consts.ErrInvalidProbe):
+			return nil, status.Errorf(codes.NotFound, "user is not being probed")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to set probe")
+		}
+	}
+	return GhostProbeToPB(p), nil
+}
+
+func (s *MikaService) ProbeAll(_ *emptypb.Empty, stream pb.Mika_ProbeAllServer) error {
+	for _, p := range tracker.Probes() {
+		if err := stream.Send(GhostProbeToPB(p)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send probe")
+		}
+	}
+	return nil
+}
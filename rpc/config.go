@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/viciious/mika/consts"
 	pb "github.com/viciious/mika/proto"
 	"github.com/viciious/mika/store"
 	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -19,15 +21,27 @@ type MikaService struct {
 
 func PBToWhiteList(p *pb.WhiteList) *store.WhiteListClient {
 	return &store.WhiteListClient{
+		WhiteListID:  p.WhitelistId,
 		ClientPrefix: p.Prefix,
 		ClientName:   p.Name,
+		Client:       p.Client,
+		MinVersion:   p.MinVersion,
+		MaxVersion:   p.MaxVersion,
+		Action:       p.Action,
+		Message:      p.Message,
 	}
 }
 
 func WhiteListToPB(w *store.WhiteListClient) *pb.WhiteList {
 	return &pb.WhiteList{
-		Prefix: w.ClientPrefix,
-		Name:   w.ClientName,
+		WhitelistId: w.WhiteListID,
+		Prefix:      w.ClientPrefix,
+		Name:        w.ClientName,
+		Client:      w.Client,
+		MinVersion:  w.MinVersion,
+		MaxVersion:  w.MaxVersion,
+		Action:      w.Action,
+		Message:     w.Message,
 	}
 }
 
@@ -37,9 +51,9 @@ func renderWhiteList(wl []*store.WhiteListClient, title string) {
 	if title != "" {
 		t.SetTitle(title)
 	}
-	t.AppendHeader(table.Row{"name", "prefix"})
+	t.AppendHeader(table.Row{"name", "prefix", "client", "min_version", "max_version", "action"})
 	for _, w := range wl {
-		t.AppendRow(table.Row{w.ClientName, w.ClientPrefix})
+		t.AppendRow(table.Row{w.ClientName, w.ClientPrefix, w.Client, w.MinVersion, w.MaxVersion, w.Action})
 	}
 	t.SortBy([]table.SortBy{{
 		Name: "name",
@@ -54,24 +68,43 @@ func (s *MikaService) ConfigSave(context.Context, *pb.ConfigSaveParams) (*emptyp
 	return nil, status.Errorf(codes.Unimplemented, "method ConfigSave not implemented")
 }
 
-func (s *MikaService) WhiteListAdd(_ context.Context, params *pb.WhiteList) (*emptypb.Empty, error) {
-	wl := &store.WhiteListClient{ClientPrefix: params.Prefix, ClientName: params.Name}
+func (s *MikaService) WhiteListAdd(_ context.Context, params *pb.WhiteList) (*pb.WhiteList, error) {
+	wl := PBToWhiteList(params)
+	wl.WhiteListID = 0
 	err := tracker.WhiteListAdd(wl)
 	if err != nil {
+		if errors.Is(err, consts.ErrMalformedRequest) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid whitelist client: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add whitelist client")
 	}
 	renderWhiteList([]*store.WhiteListClient{wl}, "Whitelisted Client")
 	log.Infof("Added new whitelisted client: %s", params.Name)
-	return &emptypb.Empty{}, nil
+	return WhiteListToPB(wl), nil
 }
 
 func (s *MikaService) WhiteListDelete(_ context.Context, params *pb.WhiteListDeleteParams) (*emptypb.Empty, error) {
-	w, err := tracker.WhiteListGet(params.Prefix)
-	if err != nil {
-		return &emptypb.Empty{}, status.Errorf(codes.NotFound, "unknown client prefix")
+	var matched []*store.WhiteListClient
+	if params.WhitelistId > 0 {
+		w, err := tracker.WhiteListGet(params.WhitelistId)
+		if err != nil {
+			return &emptypb.Empty{}, status.Errorf(codes.NotFound, "unknown whitelist id")
+		}
+		matched = append(matched, w)
+	} else {
+		for _, w := range tracker.WhiteList() {
+			if w.ClientPrefix == params.Prefix {
+				matched = append(matched, w)
+			}
+		}
+		if params.Prefix == "" || len(matched) == 0 {
+			return &emptypb.Empty{}, status.Errorf(codes.NotFound, "unknown client prefix")
+		}
 	}
-	if err := tracker.WhiteListDelete(w); err != nil {
-		return &emptypb.Empty{}, status.Errorf(codes.NotFound, "error removing client from whitelist")
+	for _, w := range matched {
+		if err := tracker.WhiteListDelete(w); err != nil {
+			return &emptypb.Empty{}, status.Errorf(codes.NotFound, "error removing client from whitelist")
+		}
 	}
 	return &emptypb.Empty{}, nil
 }
@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func EventToPB(e store.Event) *pb.Event {
+	return &pb.Event{
+		EventId:    e.EventID,
+		Name:       e.Name,
+		MultiUp:    e.MultiUp,
+		MultiDn:    e.MultiDn,
+		RoleIds:    e.RoleIDs,
+		Categories: e.Categories,
+		StartOn:    timestamppb.New(e.StartOn),
+		EndOn:      timestamppb.New(e.EndOn),
+		CreatedOn:  timestamppb.New(e.CreatedOn),
+	}
+}
+
+func PBToEvent(e *pb.Event) store.Event {
+	return store.Event{
+		EventID:    e.EventId,
+		Name:       e.Name,
+		MultiUp:    e.MultiUp,
+		MultiDn:    e.MultiDn,
+		RoleIDs:    e.RoleIds,
+		Categories: e.Categories,
+		StartOn:    e.StartOn.AsTime(),
+		EndOn:      e.EndOn.AsTime(),
+		CreatedOn:  e.CreatedOn.AsTime(),
+	}
+}
+
+func (s *MikaService) EventAll(_ *emptypb.Empty, stream pb.Mika_EventAllServer) error {
+	for _, e := range tracker.Events() {
+		if err := stream.Send(EventToPB(e)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send event")
+		}
+	}
+	return nil
+}
+
+func (s *MikaService) EventAdd(_ context.Context, params *pb.Event) (*pb.Event, error) {
+	if params.StartOn == nil || params.EndOn == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "start_on and end_on are required")
+	}
+	e := PBToEvent(params)
+	e.EventID = 0
+	e.CreatedOn = time.Time{}
+	if err := tracker.EventAdd(&e); err != nil {
+		if errors.Is(err, consts.ErrMalformedRequest) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid event: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to add event")
+	}
+	return EventToPB(e), nil
+}
+
+func (s *MikaService) EventDelete(_ context.Context, params *pb.EventID) (*emptypb.Empty, error) {
+	if err := tracker.EventDelete(params.EventId); err != nil {
+		if errors.Is(err, consts.ErrInvalidEvent) {
+			return nil, status.Errorf(codes.NotFound, "event doesnt exist")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete event")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// optionalTimestamp converts times which may be unset, leaving the field empty for zero times
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func HistoryToPB(h store.History) *pb.History {
+	return &pb.History{
+		UserId:        h.UserID,
+		InfoHash:      h.InfoHash.Bytes(),
+		Uploaded:      h.Uploaded,
+		Downloaded:    h.Downloaded,
+		SeedTime:      int64(h.SeedTime.Seconds()),
+		LeechTime:     int64(h.LeechTime.Seconds()),
+		AnnounceFirst: timestamppb.New(h.AnnounceFirst),
+		AnnounceLast:  timestamppb.New(h.AnnounceLast),
+		CompletedOn:   optionalTimestamp(h.CompletedOn),
+		Active:        h.Active,
+		Hnr:           h.HnR,
+		HnrOn:         optionalTimestamp(h.HnROn),
+	}
+}
+
+func (s *MikaService) UserHistory(userID *pb.UserID, stream pb.Mika_UserHistoryServer) error {
+	u, err := findUser(userID)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return status.Errorf(codes.Internal, "failed to get user")
+	}
+	for _, h := range tracker.UserHistory(u.UserID) {
+		if err := stream.Send(HistoryToPB(h)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send history")
+		}
+	}
+	return nil
+}
+
+func (s *MikaService) TorrentSnatches(params *pb.InfoHashParam, stream pb.Mika_TorrentSnatchesServer) error {
+	var ih store.InfoHash
+	if err := store.InfoHashFromBytes(&ih, params.InfoHash); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid info_hash")
+	}
+	if _, err := tracker.TorrentGet(ih, false); err != nil {
+		return status.Errorf(codes.NotFound, "unknown infohash")
+	}
+	for _, h := range tracker.TorrentSnatches(ih) {
+		if err := stream.Send(HistoryToPB(h)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send snatch")
+		}
+	}
+	return nil
+}
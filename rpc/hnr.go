@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *MikaService) HnRGet(userID *pb.UserID, stream pb.Mika_HnRGetServer) error {
+	u, err := findUser(userID)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return status.Errorf(codes.Internal, "failed to get user")
+	}
+	for _, hnr := range tracker.HitAndRuns(u.UserID) {
+		if err := stream.Send(HistoryToPB(hnr)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send hit and run")
+		}
+	}
+	return nil
+}
+
+func (s *MikaService) HnRClear(_ context.Context, params *pb.HnRClearParams) (*emptypb.Empty, error) {
+	var ih *store.InfoHash
+	if len(params.InfoHash) > 0 {
+		ih = &store.InfoHash{}
+		if err := store.InfoHashFromBytes(ih, params.InfoHash); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid info_hash")
+		}
+	}
+	if err := tracker.HitAndRunClear(params.UserId, ih); err != nil {
+		return nil, status.Errorf(codes.NotFound, "no hit and runs found")
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func RatioWatchEventToPB(e store.RatioWatchEvent) *pb.RatioWatchEvent {
+	return &pb.RatioWatchEvent{
+		UserId:    e.UserID,
+		State:     string(e.State),
+		Ratio:     e.Ratio,
+		Required:  e.Required,
+		Expires:   optionalTimestamp(e.Expires),
+		CreatedOn: timestamppb.New(e.CreatedOn),
+	}
+}
+
+func (s *MikaService) RatioWatchGet(userID *pb.UserID, stream pb.Mika_RatioWatchGetServer) error {
+	u, err := findUser(userID)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return status.Errorf(codes.Internal, "failed to get user")
+	}
+	for _, e := range tracker.RatioWatchEvents(u.UserID) {
+		if err := stream.Send(RatioWatchEventToPB(e)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send ratio watch event")
+		}
+	}
+	return nil
+}
@@ -27,6 +27,9 @@ func (s *MikaService) RoleAll(_ *emptypb.Empty, stream pb.Mika_RoleAllServer) er
 			UploadEnabled:   r.UploadEnabled,
 			MultiUp:         r.MultiUp,
 			MultiDown:       r.MultiDown,
+			MaxPeers:        r.MaxPeers,
+			MaxHnr:          r.MaxHnR,
+			MaxLeeching:     r.MaxLeeching,
 			Time: &pb.TimeMeta{
 				CreatedOn: timestamppb.New(r.CreatedOn),
 				UpdatedOn: timestamppb.New(r.UpdatedOn),
@@ -49,6 +52,9 @@ func RoleToPB(r *store.Role) *pb.Role {
 		UploadEnabled:   r.UploadEnabled,
 		MultiUp:         r.MultiUp,
 		MultiDown:       r.MultiDown,
+		MaxPeers:        r.MaxPeers,
+		MaxHnr:          r.MaxHnR,
+		MaxLeeching:     r.MaxLeeching,
 		Time: &pb.TimeMeta{
 			CreatedOn: timestamppb.New(r.CreatedOn),
 			UpdatedOn: timestamppb.New(r.UpdatedOn),
@@ -66,6 +72,9 @@ func PBToRole(r *pb.Role) *store.Role {
 		MultiDown:       r.MultiDown,
 		DownloadEnabled: r.DownloadEnabled,
 		UploadEnabled:   r.UploadEnabled,
+		MaxPeers:        r.MaxPeers,
+		MaxHnR:          r.MaxHnr,
+		MaxLeeching:     r.MaxLeeching,
 		CreatedOn:       r.Time.CreatedOn.AsTime(),
 		UpdatedOn:       r.Time.UpdatedOn.AsTime(),
 	}
@@ -79,8 +88,11 @@ func (s *MikaService) RoleAdd(ctx context.Context, params *pb.RoleAddParams) (*p
 		Priority:        params.Priority,
 		MultiUp:         params.MultiUp,
 		MultiDown:       params.MultiDown,
-		DownloadEnabled: params.UploadEnabled,
+		DownloadEnabled: params.DownloadEnabled,
 		UploadEnabled:   params.UploadEnabled,
+		MaxPeers:        params.MaxPeers,
+		MaxHnR:          params.MaxHnr,
+		MaxLeeching:     params.MaxLeeching,
 	}
 	if err := tracker.RoleAdd(r); err != nil {
 		return nil, errors.Wrapf(err, "Failed to add role: %s", err.Error())
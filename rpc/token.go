@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"context"
+	"github.com/viciious/mika/consts"
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+func TokenToPB(t store.FreeleechToken) *pb.FreeleechToken {
+	return &pb.FreeleechToken{
+		UserId:        t.UserID,
+		InfoHash:      t.InfoHash.Bytes(),
+		Downloaded:    t.Downloaded,
+		MaxDownloaded: t.MaxDownloaded,
+		Expires:       optionalTimestamp(t.Expires),
+		CreatedOn:     timestamppb.New(t.CreatedOn),
+	}
+}
+
+func (s *MikaService) TokenGrant(_ context.Context, params *pb.TokenGrantParams) (*pb.FreeleechToken, error) {
+	var ih store.InfoHash
+	if err := store.InfoHashFromBytes(&ih, params.InfoHash); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid info_hash")
+	}
+	var expires time.Time
+	if params.Expires != nil {
+		expires = params.Expires.AsTime()
+	}
+	t, err := tracker.TokenGrant(params.UserId, ih, expires, params.MaxDownloaded)
+	if err != nil {
+		switch {
+		case errors.Is(err, consts.ErrInvalidUser):
+			return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+		case errors.Is(err, consts.ErrInvalidInfoHash):
+			return nil, status.Errorf(codes.NotFound, "torrent doesnt exist")
+		case errors.Is(err, consts.ErrMalformedRequest):
+			return nil, status.Errorf(codes.InvalidArgument, "expires must be in the future")
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to grant token")
+		}
+	}
+	return TokenToPB(t), nil
+}
+
+func (s *MikaService) TokenRevoke(_ context.Context, params *pb.TokenRevokeParams) (*emptypb.Empty, error) {
+	var ih store.InfoHash
+	if err := store.InfoHashFromBytes(&ih, params.InfoHash); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid info_hash")
+	}
+	if err := tracker.TokenRevoke(params.UserId, ih); err != nil {
+		if errors.Is(err, consts.ErrInvalidToken) {
+			return nil, status.Errorf(codes.NotFound, "token doesnt exist")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to revoke token")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *MikaService) TokenList(userID *pb.UserID, stream pb.Mika_TokenListServer) error {
+	u, err := findUser(userID)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return status.Errorf(codes.Internal, "failed to get user")
+	}
+	for _, t := range tracker.UserTokens(u.UserID) {
+		if err := stream.Send(TokenToPB(t)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send token")
+		}
+	}
+	return nil
+}
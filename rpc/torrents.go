@@ -14,6 +14,7 @@ import (
 )
 
 func TorrentToPB(r *store.Torrent) *pb.Torrent {
+	seeders, leechers := r.Peers.Counts()
 	return &pb.Torrent{
 		InfoHash:   r.InfoHash.Bytes(),
 		Snatches:   r.Snatches,
@@ -24,9 +25,13 @@ func TorrentToPB(r *store.Torrent) *pb.Torrent {
 		Reason:     r.Reason,
 		MultiUp:    r.MultiUp,
 		MultiDn:    r.MultiDn,
+		MaxPeers:   r.MaxPeers,
+		Size:       r.Size,
+		Category:   r.Category,
+		TrapUserId: r.TrapUserID,
 		Announces:  r.Announces,
-		Seeders:    r.Seeders,
-		Leechers:   r.Leechers,
+		Seeders:    seeders,
+		Leechers:   leechers,
 		Title:      r.Title,
 		Time: &pb.TimeMeta{
 			CreatedOn: timestamppb.New(r.CreatedOn),
@@ -48,6 +53,10 @@ func PBtoTorrent(p *pb.Torrent) *store.Torrent {
 		Reason:     p.Reason,
 		MultiUp:    p.MultiUp,
 		MultiDn:    p.MultiDn,
+		MaxPeers:   p.MaxPeers,
+		Size:       p.Size,
+		Category:   p.Category,
+		TrapUserID: p.TrapUserId,
 		Announces:  p.Announces,
 		Seeders:    p.Seeders,
 		Leechers:   p.Leechers,
@@ -78,17 +87,24 @@ func (s *MikaService) TorrentAdd(_ context.Context, params *pb.TorrentAddParams)
 		return nil, status.Errorf(codes.InvalidArgument, "invalid info_hash")
 	}
 	t := &store.Torrent{
-		InfoHash:  ih,
-		MultiUp:   params.MultiUp,
-		MultiDn:   params.MultiDn,
-		Title:     params.Title,
-		IsEnabled: true,
+		InfoHash:   ih,
+		MultiUp:    params.MultiUp,
+		MultiDn:    params.MultiDn,
+		MaxPeers:   params.MaxPeers,
+		Size:       params.Size,
+		Category:   params.Category,
+		TrapUserID: params.TrapUserId,
+		Title:      params.Title,
+		IsEnabled:  true,
 	}
 	err = tracker.TorrentAdd(t)
 	if err != nil {
 		if errors.Is(err, consts.ErrDuplicate) {
 			return nil, status.Errorf(codes.AlreadyExists, "info_hash already exists")
 		}
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to add torrent")
 	}
 	return TorrentToPB(t), nil
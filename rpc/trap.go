@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	pb "github.com/viciious/mika/proto"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/tracker"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TrapLeakToPB(l store.TrapLeak) *pb.TrapLeak {
+	return &pb.TrapLeak{
+		LeakId:       l.LeakID,
+		InfoHash:     l.InfoHash.Bytes(),
+		UserId:       l.UserID,
+		AnnouncerId:  l.AnnouncerID,
+		AddrIp:       l.IP,
+		Client:       l.Client,
+		PasskeyReset: l.PasskeyReset,
+		CreatedOn:    timestamppb.New(l.CreatedOn),
+	}
+}
+
+func (s *MikaService) TrapLeaks(params *pb.TrapLeakParams, stream pb.Mika_TrapLeaksServer) error {
+	leaks, err := tracker.TrapLeaks(params.UserId)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to fetch trap leaks")
+	}
+	for _, l := range leaks {
+		if err := stream.Send(TrapLeakToPB(l)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send trap leak")
+		}
+	}
+	return nil
+}
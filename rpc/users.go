@@ -2,10 +2,12 @@ package rpc
 
 import (
 	"context"
+	"github.com/viciious/mika/config"
 	"github.com/viciious/mika/consts"
 	pb "github.com/viciious/mika/proto"
 	"github.com/viciious/mika/store"
 	"github.com/viciious/mika/tracker"
+	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -23,7 +25,7 @@ func findUser(userID *pb.UserID) (*store.User, error) {
 	} else if userID.Passkey != "" {
 		u, err = tracker.UserGetByPasskey(userID.Passkey)
 	} else if userID.RemoteId > 0 {
-		return nil, err
+		u, err = tracker.UserGetByRemoteID(userID.RemoteId)
 	} else {
 		err = errors.New("must supply at least one identifier")
 	}
@@ -64,13 +66,60 @@ func (s *MikaService) UserSave(_ context.Context, params *pb.UserUpdateParams) (
 	usr.DownloadEnabled = params.DownloadEnabled
 	usr.Downloaded = params.Downloaded
 	usr.Uploaded = params.Uploaded
-	usr.Passkey = params.Passkey
+	if params.MultiUp != nil {
+		usr.MultiUp = params.GetMultiUp()
+	}
+	if params.MultiDown != nil {
+		usr.MultiDown = params.GetMultiDown()
+	}
+	usr.MaxLeeching = params.MaxLeeching
+	// Changing the passkey saves the user along with moving them to the new passkey
+	if params.Passkey != "" && params.Passkey != usr.Passkey {
+		if err := tracker.UserChangePasskey(usr, params.Passkey); err != nil {
+			if errors.Is(err, consts.ErrDuplicate) {
+				return nil, status.Errorf(codes.AlreadyExists, "passkey already in use")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to update user")
+		}
+		return UserToPB(usr), nil
+	}
 	if err := tracker.UserSave(usr); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update user")
 	}
 	return UserToPB(usr), nil
 }
 
+func (s *MikaService) UserRotatePasskey(_ context.Context, params *pb.PasskeyRotateParams) (*pb.PasskeyRotation, error) {
+	if params.User == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "user is required")
+	}
+	usr, err := findUser(params.User)
+	if err != nil {
+		if errors.Is(err, consts.ErrInvalidUser) {
+			return nil, status.Errorf(codes.NotFound, "user doesnt exist")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	grace := config.Tracker.PasskeyGraceParsed
+	if params.Grace != "" {
+		grace, err = util.ParseDuration(params.Grace)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid grace: %v", err)
+		}
+	}
+	oldPasskey := usr.Passkey
+	expires, err := tracker.PasskeyRotate(usr, grace)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rotate passkey")
+	}
+	return &pb.PasskeyRotation{
+		UserId:     usr.UserID,
+		Passkey:    usr.Passkey,
+		OldPasskey: oldPasskey,
+		OldExpires: optionalTimestamp(expires),
+	}, nil
+}
+
 func (s *MikaService) UserDelete(_ context.Context, userID *pb.UserID) (*emptypb.Empty, error) {
 	u, err := findUser(userID)
 	if err != nil {
@@ -95,6 +144,15 @@ func (s *MikaService) UserAdd(ctx context.Context, p *pb.UserAddParams) (*pb.Use
 		Downloaded:      p.Downloaded,
 		Uploaded:        p.Uploaded,
 		RemoteID:        p.RemoteId,
+		MultiUp:         -1,
+		MultiDown:       -1,
+		MaxLeeching:     p.MaxLeeching,
+	}
+	if p.MultiUp != nil {
+		u.MultiUp = p.GetMultiUp()
+	}
+	if p.MultiDown != nil {
+		u.MultiDown = p.GetMultiDown()
 	}
 	if err := tracker.UserAdd(u); err != nil {
 		return nil, err
@@ -104,13 +162,22 @@ func (s *MikaService) UserAdd(ctx context.Context, p *pb.UserAddParams) (*pb.Use
 
 func UserToPB(u *store.User) *pb.User {
 	return &pb.User{
-		UserId:     u.UserID,
-		RoleId:     u.RoleID,
-		RemoteId:   u.RemoteID,
-		UserName:   u.UserName,
-		Downloaded: u.Downloaded,
-		Uploaded:   u.Uploaded,
-		Passkey:    u.Passkey,
+		UserId:          u.UserID,
+		RoleId:          u.RoleID,
+		RemoteId:        u.RemoteID,
+		UserName:        u.UserName,
+		Downloaded:      u.Downloaded,
+		Uploaded:        u.Uploaded,
+		DownloadedReal:  u.DownloadedReal,
+		UploadedReal:    u.UploadedReal,
+		MultiUp:         u.MultiUp,
+		MultiDown:       u.MultiDown,
+		MaxLeeching:     u.MaxLeeching,
+		BonusPoints:     u.BonusPoints,
+		Passkey:         u.Passkey,
+		IsDeleted:       u.IsDeleted,
+		DownloadEnabled: u.DownloadEnabled,
+		Announces:       u.Announces,
 		Time: &pb.TimeMeta{
 			CreatedOn: timestamppb.New(u.CreatedOn),
 			UpdatedOn: timestamppb.New(u.UpdatedOn),
@@ -129,6 +196,12 @@ func PBToUser(u *pb.User) *store.User {
 		DownloadEnabled: u.DownloadEnabled,
 		Downloaded:      u.Downloaded,
 		Uploaded:        u.Uploaded,
+		DownloadedReal:  u.DownloadedReal,
+		UploadedReal:    u.UploadedReal,
+		MultiUp:         u.MultiUp,
+		MultiDown:       u.MultiDown,
+		MaxLeeching:     u.MaxLeeching,
+		BonusPoints:     u.BonusPoints,
 		Announces:       u.Announces,
 		RemoteID:        u.RemoteId,
 		CreatedOn:       u.Time.CreatedOn.AsTime(),
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"time"
+)
+
+// Kinds of bans
+const (
+	// BanIP matches a single address
+	BanIP = "ip"
+	// BanCIDR matches every address in a range
+	BanCIDR = "cidr"
+	// BanASN matches every address announced by an autonomous system
+	BanASN = "asn"
+	// BanCountry matches every address located in a country, by ISO code
+	BanCountry = "country"
+	// BanUser matches every announce made by a user, by user id
+	BanUser = "user"
+)
+
+// Ban blocks every request made from the addresses, or by the user, it matches
+type Ban struct {
+	BanID uint32 `db:"ban_id" json:"ban_id"`
+	// Kind is what the value describes, ip|cidr|asn|country
+	Kind string `db:"kind" json:"kind"`
+	// Value is the address, CIDR range, AS number or ISO country code banned
+	Value string `db:"ban_value" json:"ban_value"`
+	// Reason is sent to the client along with the error message
+	Reason string `db:"reason" json:"reason"`
+	// Expires is when the ban is lifted, zero if it never expires
+	Expires   time.Time `db:"expires" json:"expires"`
+	CreatedOn time.Time `db:"created_on" json:"created_on"`
+}
+
+// Expired returns true once the bans expiry has passed
+func (b Ban) Expired(now time.Time) bool {
+	return !b.Expires.IsZero() && !now.Before(b.Expires)
+}
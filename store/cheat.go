@@ -0,0 +1,46 @@
+package store
+
+import (
+	"time"
+)
+
+// CheatReport records an announce flagged by one of the cheater detection rules along with
+// the action taken against the user
+type CheatReport struct {
+	ReportID uint32   `db:"cheat_report_id" json:"cheat_report_id"`
+	UserID   uint32   `db:"user_id" json:"user_id"`
+	InfoHash InfoHash `db:"info_hash" json:"info_hash"`
+	// IP is the address the flagged announce was made from
+	IP string `db:"addr_ip" json:"addr_ip"`
+	// Client is the client string of the flagged peer
+	Client string `db:"client" json:"client"`
+	// Rule is the name of the rule which flagged the announce
+	Rule string `db:"rule" json:"rule"`
+	// Action is what was done to the user, log|drop|disable_download
+	Action string `db:"action" json:"action"`
+	// Uploaded is the amount reported since the previous announce of the peer
+	Uploaded uint64 `db:"uploaded" json:"uploaded"`
+	// Speed is the derived upload speed of the peer in bytes/sec
+	Speed uint64 `db:"speed" json:"speed"`
+	// Detail is a human readable description of the evidence
+	Detail    string    `db:"detail" json:"detail"`
+	CreatedOn time.Time `db:"created_on" json:"created_on"`
+}
+
+// GhostProbe tracks a user who is only being handed unreachable peers. Clients cannot upload
+// to peers which do not exist so any upload they report while probed is fabricated.
+type GhostProbe struct {
+	UserID    uint32    `json:"user_id"`
+	StartedOn time.Time `json:"started_on"`
+	// Announces holds the most recent announces made by the user while probed, oldest first
+	Announces []GhostAnnounce `json:"announces"`
+}
+
+// GhostAnnounce is an announce made by a probed user along with the amounts reported since
+// the previous announce of the peer
+type GhostAnnounce struct {
+	InfoHash    InfoHash  `json:"info_hash"`
+	AnnouncedOn time.Time `json:"announced_on"`
+	Uploaded    uint64    `json:"uploaded"`
+	Downloaded  uint64    `json:"downloaded"`
+}
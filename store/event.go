@@ -0,0 +1,82 @@
+package store
+
+import (
+	"github.com/viciious/mika/util"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a time windowed multiplier, such as a freeleech weekend, applied on top of the
+// multipliers of every torrent it matches.
+type Event struct {
+	EventID uint32 `db:"event_id" json:"event_id"`
+	Name    string `db:"event_name" json:"event_name"`
+	// Upload multiplier, 2 is double upload
+	MultiUp float64 `db:"multi_up" json:"multi_up"`
+	// Download multiplier, 0 is freeleech
+	MultiDn float64 `db:"multi_dn" json:"multi_dn"`
+	// RoleIDs limits the event to users of these roles, empty matches every role
+	RoleIDs []uint32 `db:"-" json:"role_ids"`
+	// Categories limits the event to torrents in these categories, empty matches every torrent
+	Categories []string  `db:"-" json:"categories"`
+	StartOn    time.Time `db:"start_on" json:"start_on"`
+	EndOn      time.Time `db:"end_on" json:"end_on"`
+	CreatedOn  time.Time `db:"created_on" json:"created_on"`
+}
+
+// Active returns true while the time provided is within the events window
+func (e Event) Active(t time.Time) bool {
+	return !t.Before(e.StartOn) && t.Before(e.EndOn)
+}
+
+// Matches returns true if the event applies to a user of the role and a torrent of the category
+func (e Event) Matches(roleID uint32, category string) bool {
+	if len(e.RoleIDs) > 0 {
+		found := false
+		for _, r := range e.RoleIDs {
+			if r == roleID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(e.Categories) > 0 {
+		for _, c := range e.Categories {
+			if strings.EqualFold(c, category) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Scope returns the role ids and categories of the event as comma separated lists so
+// they can be stored in a single column
+func (e Event) Scope() (string, string) {
+	var roles []string
+	for _, r := range e.RoleIDs {
+		roles = append(roles, strconv.FormatUint(uint64(r), 10))
+	}
+	return strings.Join(roles, ","), strings.Join(e.Categories, ",")
+}
+
+// SetScope parses the comma separated lists produced by Scope
+func (e *Event) SetScope(roles string, categories string) {
+	e.RoleIDs = nil
+	e.Categories = nil
+	for _, r := range strings.Split(roles, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			e.RoleIDs = append(e.RoleIDs, util.StringToUInt32(r, 0))
+		}
+	}
+	for _, c := range strings.Split(categories, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			e.Categories = append(e.Categories, c)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"time"
+)
+
+// History is a users transfer history on a single torrent accumulated over all of their
+// sessions. Entries with a CompletedOn time are the users snatches of the torrent.
+type History struct {
+	UserID   uint32   `db:"user_id" json:"user_id"`
+	InfoHash InfoHash `db:"info_hash" json:"info_hash"`
+	// Uploaded and Downloaded are the actual amounts transferred, without multipliers
+	Uploaded   uint64        `db:"uploaded" json:"uploaded"`
+	Downloaded uint64        `db:"downloaded" json:"downloaded"`
+	SeedTime   time.Duration `db:"seed_time" json:"seed_time"`
+	LeechTime  time.Duration `db:"leech_time" json:"leech_time"`
+	// AnnounceFirst is the first announce of the users first session on the torrent
+	AnnounceFirst time.Time `db:"announce_first" json:"announce_first"`
+	AnnounceLast  time.Time `db:"announce_last" json:"announce_last"`
+	// CompletedOn is when the user finished downloading the torrent, zero if they have not
+	CompletedOn time.Time `db:"completed_on" json:"completed_on"`
+	// Active is true while the user has a session in the swarm
+	Active bool `db:"is_active" json:"is_active"`
+	// HnR is set once the user has been flagged as a hit and run for the torrent
+	HnR bool `db:"hnr" json:"hnr"`
+	// HnROn is when the entry was flagged. It is kept when the flag is cleared so the
+	// entry is never flagged again.
+	HnROn time.Time `db:"hnr_on" json:"hnr_on"`
+
+	// Keeps track of how often the values have been changes
+	Writes uint32 `db:"-" json:"-"`
+}
+
+// HistoryKey identifies the history of a user on a torrent
+type HistoryKey struct {
+	UserID   uint32
+	InfoHash InfoHash
+}
+
+// Key returns the HistoryKey of the entry
+func (h *History) Key() HistoryKey {
+	return HistoryKey{UserID: h.UserID, InfoHash: h.InfoHash}
+}
+
+// Completed checks if the user has finished downloading the torrent
+func (h *History) Completed() bool {
+	return !h.CompletedOn.IsZero()
+}
+
+// HitAndRun checks if the entry should be flagged as a hit and run. This is the case once
+// the window since completion has passed without the user seeding for at least threshold.
+func (h *History) HitAndRun(now time.Time, threshold time.Duration, window time.Duration) bool {
+	return h.Completed() && h.HnROn.IsZero() && h.SeedTime < threshold && now.Sub(h.CompletedOn) > window
+}
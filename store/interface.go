@@ -78,9 +78,68 @@ type Store interface {
 	// TorrentSync batch updates the backing store with the new TorrentStats provided
 	TorrentSync(b []*Torrent) error
 
-	// WhiteListDelete removes a client from the global whitelist
+	// Histories returns the transfer history of every user on every torrent
+	Histories() ([]*History, error)
+	// HistorySync batch inserts or updates the users transfer histories provided
+	HistorySync(b []*History) error
+
+	// RatioWatchEvents returns every recorded ratio watch transition, oldest first
+	RatioWatchEvents() ([]*RatioWatchEvent, error)
+	// RatioWatchAdd records a new ratio watch transition
+	RatioWatchAdd(e *RatioWatchEvent) error
+
+	// Events returns every scheduled multiplier event, including those which have ended
+	Events() ([]*Event, error)
+	// EventAdd stores a new event, setting its EventID
+	EventAdd(e *Event) error
+	// EventDelete permanently removes an event
+	EventDelete(eventID uint32) error
+
+	// Tokens returns every freeleech token
+	Tokens() ([]*FreeleechToken, error)
+	// TokenSync batch inserts or updates the freeleech tokens provided
+	TokenSync(b []*FreeleechToken) error
+	// TokenDelete permanently removes the freeleech token of the user for the torrent
+	TokenDelete(userID uint32, ih InfoHash) error
+
+	// CheatReports returns the cheat reports of the user, oldest first. A userID of 0 returns
+	// the reports of every user.
+	CheatReports(userID uint32) ([]*CheatReport, error)
+	// CheatReportAdd records a new cheat report, setting its ReportID
+	CheatReportAdd(r *CheatReport) error
+
+	// TrapLeaks returns the recorded leaks of trap torrents registered to the user, oldest
+	// first. A userID of 0 returns the leaks of every user.
+	TrapLeaks(userID uint32) ([]*TrapLeak, error)
+	// TrapLeakAdd records a new trap torrent leak, setting its LeakID
+	TrapLeakAdd(l *TrapLeak) error
+
+	// Bans returns every ban, including those which have expired
+	Bans() ([]*Ban, error)
+	// BanAdd stores a new ban, setting its BanID
+	BanAdd(b *Ban) error
+	// BanDelete permanently removes a ban
+	BanDelete(banID uint32) error
+
+	// RetiredPasskeys returns every retired passkey, including those which have expired
+	RetiredPasskeys() ([]*RetiredPasskey, error)
+	// RetiredPasskeyAdd stores a retired passkey, replacing any existing one with the same passkey
+	RetiredPasskeyAdd(p *RetiredPasskey) error
+	// RetiredPasskeyDelete permanently removes a retired passkey. Removing an unknown passkey is
+	// not an error.
+	RetiredPasskeyDelete(passkey string) error
+
+	// SpeedProfiles returns every historical speed profile
+	SpeedProfiles() ([]*SpeedProfile, error)
+	// SpeedProfileSync batch inserts or updates the speed profiles provided
+	SpeedProfileSync(b []*SpeedProfile) error
+	// SpeedProfileDelete permanently removes a speed profile. Removing an unknown profile is
+	// not an error.
+	SpeedProfileDelete(key SpeedProfileKey) error
+
+	// WhiteListDelete removes a client rule from the global whitelist by its WhiteListID
 	WhiteListDelete(client *WhiteListClient) error
-	// WhiteListAdd will insert a new client prefix into the allowed clients list
+	// WhiteListAdd will insert a new client rule into the global whitelist, setting its WhiteListID
 	WhiteListAdd(client *WhiteListClient) error
 	// WhiteListGetAll fetches all known whitelisted clients
 	WhiteListGetAll() ([]*WhiteListClient, error)
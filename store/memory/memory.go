@@ -5,6 +5,7 @@ import (
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/store"
 	"github.com/pkg/errors"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -48,24 +49,287 @@ func (d *Driver) TorrentSync(_ []*store.Torrent) error {
 	return nil
 }
 
+// Histories returns the transfer history of every user on every torrent
+func (d *Driver) Histories() ([]*store.History, error) {
+	d.historyMu.RLock()
+	defer d.historyMu.RUnlock()
+	var hist []*store.History
+	for _, h := range d.history {
+		entry := *h
+		hist = append(hist, &entry)
+	}
+	return hist, nil
+}
+
+// HistorySync stores a copy of each of the history entries provided
+func (d *Driver) HistorySync(b []*store.History) error {
+	d.historyMu.Lock()
+	defer d.historyMu.Unlock()
+	for _, h := range b {
+		entry := *h
+		d.history[h.Key()] = &entry
+	}
+	return nil
+}
+
+// RatioWatchEvents returns every recorded ratio watch transition, oldest first
+func (d *Driver) RatioWatchEvents() ([]*store.RatioWatchEvent, error) {
+	d.ratioMu.RLock()
+	defer d.ratioMu.RUnlock()
+	var events []*store.RatioWatchEvent
+	for _, e := range d.ratioEvents {
+		event := e
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// RatioWatchAdd records a new ratio watch transition
+func (d *Driver) RatioWatchAdd(e *store.RatioWatchEvent) error {
+	d.ratioMu.Lock()
+	d.ratioEvents = append(d.ratioEvents, *e)
+	d.ratioMu.Unlock()
+	return nil
+}
+
+// Events returns every scheduled multiplier event
+func (d *Driver) Events() ([]*store.Event, error) {
+	d.eventsMu.RLock()
+	defer d.eventsMu.RUnlock()
+	var events []*store.Event
+	for _, e := range d.events {
+		event := e
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+// EventAdd stores a new event, setting its EventID
+func (d *Driver) EventAdd(e *store.Event) error {
+	d.eventsMu.Lock()
+	d.lastEventID++
+	e.EventID = d.lastEventID
+	d.events = append(d.events, *e)
+	d.eventsMu.Unlock()
+	return nil
+}
+
+// EventDelete permanently removes an event
+func (d *Driver) EventDelete(eventID uint32) error {
+	d.eventsMu.Lock()
+	defer d.eventsMu.Unlock()
+	for i, e := range d.events {
+		if e.EventID == eventID {
+			d.events = append(d.events[:i], d.events[i+1:]...)
+			return nil
+		}
+	}
+	return consts.ErrInvalidEvent
+}
+
+// Tokens returns a copy of every freeleech token
+func (d *Driver) Tokens() ([]*store.FreeleechToken, error) {
+	d.tokensMu.RLock()
+	defer d.tokensMu.RUnlock()
+	var tokens []*store.FreeleechToken
+	for _, t := range d.tokens {
+		token := *t
+		tokens = append(tokens, &token)
+	}
+	return tokens, nil
+}
+
+// TokenSync stores a copy of each of the freeleech tokens provided
+func (d *Driver) TokenSync(b []*store.FreeleechToken) error {
+	d.tokensMu.Lock()
+	defer d.tokensMu.Unlock()
+	for _, t := range b {
+		token := *t
+		d.tokens[t.Key()] = &token
+	}
+	return nil
+}
+
+// TokenDelete permanently removes the freeleech token of the user for the torrent
+func (d *Driver) TokenDelete(userID uint32, ih store.InfoHash) error {
+	d.tokensMu.Lock()
+	defer d.tokensMu.Unlock()
+	key := store.HistoryKey{UserID: userID, InfoHash: ih}
+	if _, found := d.tokens[key]; !found {
+		return consts.ErrInvalidToken
+	}
+	delete(d.tokens, key)
+	return nil
+}
+
+// CheatReports returns a copy of the cheat reports of the user, oldest first
+func (d *Driver) CheatReports(userID uint32) ([]*store.CheatReport, error) {
+	d.cheatMu.RLock()
+	defer d.cheatMu.RUnlock()
+	var reports []*store.CheatReport
+	for _, r := range d.cheats {
+		if userID == 0 || r.UserID == userID {
+			report := r
+			reports = append(reports, &report)
+		}
+	}
+	return reports, nil
+}
+
+// CheatReportAdd records a new cheat report, setting its ReportID
+func (d *Driver) CheatReportAdd(r *store.CheatReport) error {
+	d.cheatMu.Lock()
+	r.ReportID = uint32(len(d.cheats) + 1)
+	d.cheats = append(d.cheats, *r)
+	d.cheatMu.Unlock()
+	return nil
+}
+
+// TrapLeaks returns a copy of the trap leaks of the user, oldest first
+func (d *Driver) TrapLeaks(userID uint32) ([]*store.TrapLeak, error) {
+	d.trapMu.RLock()
+	defer d.trapMu.RUnlock()
+	var leaks []*store.TrapLeak
+	for _, l := range d.leaks {
+		if userID == 0 || l.UserID == userID {
+			leak := l
+			leaks = append(leaks, &leak)
+		}
+	}
+	return leaks, nil
+}
+
+// TrapLeakAdd records a new trap leak, setting its LeakID
+func (d *Driver) TrapLeakAdd(l *store.TrapLeak) error {
+	d.trapMu.Lock()
+	l.LeakID = uint32(len(d.leaks) + 1)
+	d.leaks = append(d.leaks, *l)
+	d.trapMu.Unlock()
+	return nil
+}
+
+// Bans returns a copy of every ban
+func (d *Driver) Bans() ([]*store.Ban, error) {
+	d.bansMu.RLock()
+	defer d.bansMu.RUnlock()
+	var bans []*store.Ban
+	for _, b := range d.bans {
+		ban := b
+		bans = append(bans, &ban)
+	}
+	return bans, nil
+}
+
+// BanAdd stores a new ban, setting its BanID
+func (d *Driver) BanAdd(b *store.Ban) error {
+	d.bansMu.Lock()
+	d.lastBanID++
+	b.BanID = d.lastBanID
+	d.bans = append(d.bans, *b)
+	d.bansMu.Unlock()
+	return nil
+}
+
+// BanDelete permanently removes a ban
+func (d *Driver) BanDelete(banID uint32) error {
+	d.bansMu.Lock()
+	defer d.bansMu.Unlock()
+	for i, b := range d.bans {
+		if b.BanID == banID {
+			d.bans = append(d.bans[:i], d.bans[i+1:]...)
+			return nil
+		}
+	}
+	return consts.ErrInvalidBan
+}
+
+// RetiredPasskeys returns a copy of every retired passkey
+func (d *Driver) RetiredPasskeys() ([]*store.RetiredPasskey, error) {
+	d.passkeysMu.RLock()
+	defer d.passkeysMu.RUnlock()
+	var passkeys []*store.RetiredPasskey
+	for _, p := range d.passkeys {
+		passkey := p
+		passkeys = append(passkeys, &passkey)
+	}
+	return passkeys, nil
+}
+
+// RetiredPasskeyAdd stores a retired passkey, replacing any existing one with the same passkey
+func (d *Driver) RetiredPasskeyAdd(p *store.RetiredPasskey) error {
+	d.passkeysMu.Lock()
+	d.passkeys[p.Passkey] = *p
+	d.passkeysMu.Unlock()
+	return nil
+}
+
+// RetiredPasskeyDelete permanently removes a retired passkey
+func (d *Driver) RetiredPasskeyDelete(passkey string) error {
+	d.passkeysMu.Lock()
+	delete(d.passkeys, passkey)
+	d.passkeysMu.Unlock()
+	return nil
+}
+
+// SpeedProfiles returns a copy of every speed profile
+func (d *Driver) SpeedProfiles() ([]*store.SpeedProfile, error) {
+	d.profilesMu.RLock()
+	defer d.profilesMu.RUnlock()
+	var profiles []*store.SpeedProfile
+	for _, p := range d.profiles {
+		profile := *p
+		profile.Recent = append([]uint64{}, p.Recent...)
+		profiles = append(profiles, &profile)
+	}
+	return profiles, nil
+}
+
+// SpeedProfileSync stores a copy of each of the speed profiles provided
+func (d *Driver) SpeedProfileSync(b []*store.SpeedProfile) error {
+	d.profilesMu.Lock()
+	defer d.profilesMu.Unlock()
+	for _, p := range b {
+		profile := *p
+		profile.Recent = append([]uint64{}, p.Recent...)
+		// Pending writes are not persisted by the other stores either
+		profile.Writes = 0
+		d.profiles[p.ProfileKey()] = &profile
+	}
+	return nil
+}
+
+// SpeedProfileDelete permanently removes a speed profile
+func (d *Driver) SpeedProfileDelete(key store.SpeedProfileKey) error {
+	d.profilesMu.Lock()
+	delete(d.profiles, key)
+	d.profilesMu.Unlock()
+	return nil
+}
+
 // Conn always returns nil for in-memory store
 func (d *Driver) Conn() interface{} {
 	return nil
 }
 
-// WhiteListDelete removes a client from the global whitelist
+// WhiteListDelete removes a client rule from the global whitelist
 func (d *Driver) WhiteListDelete(client *store.WhiteListClient) error {
 	d.whitelistMu.Lock()
 	defer d.whitelistMu.Unlock()
-	delete(d.whitelist, client.ClientPrefix)
+	if _, found := d.whitelist[client.WhiteListID]; !found {
+		return consts.ErrInvalidClient
+	}
+	delete(d.whitelist, client.WhiteListID)
 	return nil
 }
 
-// WhiteListAdd will insert a new client prefix into the allowed clients list
+// WhiteListAdd will insert a new client rule into the global whitelist
 func (d *Driver) WhiteListAdd(client *store.WhiteListClient) error {
 	d.whitelistMu.Lock()
 	defer d.whitelistMu.Unlock()
-	d.whitelist[client.ClientPrefix] = client
+	d.lastRuleID++
+	client.WhiteListID = d.lastRuleID
+	wl := *client
+	d.whitelist[client.WhiteListID] = &wl
 	return nil
 }
 
@@ -75,8 +339,12 @@ func (d *Driver) WhiteListGetAll() ([]*store.WhiteListClient, error) {
 	defer d.whitelistMu.RUnlock()
 	var wl []*store.WhiteListClient
 	for _, wlc := range d.whitelist {
-		wl = append(wl, wlc)
+		client := *wlc
+		wl = append(wl, &client)
 	}
+	sort.Slice(wl, func(i, j int) bool {
+		return wl[i].WhiteListID < wl[j].WhiteListID
+	})
 	return wl, nil
 }
 
@@ -101,10 +369,23 @@ func NewDriver() *Driver {
 		roles:       make(store.Roles),
 		torrents:    make(store.Torrents),
 		whitelist:   make(store.WhiteList),
+		history:     make(map[store.HistoryKey]*store.History),
+		tokens:      make(map[store.HistoryKey]*store.FreeleechToken),
 		rolesMu:     &sync.RWMutex{},
 		torrentsMu:  &sync.RWMutex{},
 		usersMu:     &sync.RWMutex{},
 		whitelistMu: &sync.RWMutex{},
+		historyMu:   &sync.RWMutex{},
+		ratioMu:     &sync.RWMutex{},
+		eventsMu:    &sync.RWMutex{},
+		tokensMu:    &sync.RWMutex{},
+		cheatMu:     &sync.RWMutex{},
+		trapMu:      &sync.RWMutex{},
+		bansMu:      &sync.RWMutex{},
+		passkeys:    make(map[string]store.RetiredPasskey),
+		passkeysMu:  &sync.RWMutex{},
+		profiles:    make(map[store.SpeedProfileKey]*store.SpeedProfile),
+		profilesMu:  &sync.RWMutex{},
 	}
 }
 
@@ -114,24 +395,59 @@ type Driver struct {
 	roles       store.Roles
 	torrents    store.Torrents
 	whitelist   store.WhiteList
+	history     map[store.HistoryKey]*store.History
 	rolesMu     *sync.RWMutex
 	torrentsMu  *sync.RWMutex
 	usersMu     *sync.RWMutex
 	whitelistMu *sync.RWMutex
+	historyMu   *sync.RWMutex
+	ratioEvents []store.RatioWatchEvent
+	ratioMu     *sync.RWMutex
+	events      []store.Event
+	eventsMu    *sync.RWMutex
+	tokens      map[store.HistoryKey]*store.FreeleechToken
+	tokensMu    *sync.RWMutex
+	cheats      []store.CheatReport
+	cheatMu     *sync.RWMutex
+	leaks       []store.TrapLeak
+	trapMu      *sync.RWMutex
+	bans        []store.Ban
+	bansMu      *sync.RWMutex
+	passkeys    map[string]store.RetiredPasskey
+	passkeysMu  *sync.RWMutex
+	profiles    map[store.SpeedProfileKey]*store.SpeedProfile
+	profilesMu  *sync.RWMutex
 	lastUserID  uint32
 	lastRoleID  uint32
+	lastEventID uint32
+	lastBanID   uint32
+	lastRuleID  uint32
 }
 
 func (d *Driver) Migrate() error {
 	return nil
 }
 
+// Users returns a copy of the users so the tracker and the store can update their own maps
+// independently
 func (d *Driver) Users() (store.Users, error) {
-	return d.users, nil
+	d.usersMu.RLock()
+	defer d.usersMu.RUnlock()
+	users := make(store.Users, len(d.users))
+	for passkey, u := range d.users {
+		users[passkey] = u
+	}
+	return users, nil
 }
 
 func (d *Driver) Torrents() (store.Torrents, error) {
-	return d.torrents, nil
+	d.torrentsMu.RLock()
+	defer d.torrentsMu.RUnlock()
+	torrents := make(store.Torrents, len(d.torrents))
+	for ih, t := range d.torrents {
+		torrents[ih] = t
+	}
+	return torrents, nil
 }
 
 func (d *Driver) RoleSave(r *store.Role) error {
@@ -186,8 +502,18 @@ func (d *Driver) Roles() (store.Roles, error) {
 	return d.roles, nil
 }
 
-// Update is used to change a known user
+// Update is used to change a known user. Users are stored by pointer so only a changed
+// passkey needs to be handled.
 func (d *Driver) UserSave(u *store.User) error {
+	d.usersMu.Lock()
+	defer d.usersMu.Unlock()
+	for passkey, existing := range d.users {
+		if existing.UserID == u.UserID && passkey != u.Passkey {
+			delete(d.users, passkey)
+			d.users[u.Passkey] = u
+			break
+		}
+	}
 	return nil
 }
 
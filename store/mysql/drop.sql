@@ -1,3 +1,12 @@
+DROP TABLE IF EXISTS user_history cascade;
+DROP TABLE IF EXISTS user_token cascade;
+DROP TABLE IF EXISTS cheat_report cascade;
+DROP TABLE IF EXISTS trap_leak cascade;
+DROP TABLE IF EXISTS speed_profile cascade;
+DROP TABLE IF EXISTS ratio_watch cascade;
+DROP TABLE IF EXISTS event cascade;
+DROP TABLE IF EXISTS ban cascade;
+DROP TABLE IF EXISTS retired_passkey cascade;
 DROP TABLE IF EXISTS user_multi cascade;
 DROP TABLE IF EXISTS user cascade;
 DROP TABLE IF EXISTS role cascade;
@@ -0,0 +1,33 @@
+--
+-- Upgrades the tables created by older versions of schema.sql, which requires MariaDB. New tables
+-- are created by schema.sql itself so only the columns added to existing tables are handled here.
+--
+
+ALTER TABLE `role`
+  ADD COLUMN IF NOT EXISTS `max_peers` int(10) unsigned NOT NULL DEFAULT 0 AFTER `upload_enabled`,
+  ADD COLUMN IF NOT EXISTS `max_hnr` int(10) unsigned NOT NULL DEFAULT 0 AFTER `max_peers`,
+  ADD COLUMN IF NOT EXISTS `max_leeching` int(10) unsigned NOT NULL DEFAULT 0 AFTER `max_hnr`;
+
+ALTER TABLE `torrent`
+  ADD COLUMN IF NOT EXISTS `max_peers` int(10) unsigned NOT NULL DEFAULT 0 AFTER `multi_dn`,
+  ADD COLUMN IF NOT EXISTS `size` bigint(20) unsigned NOT NULL DEFAULT 0 AFTER `max_peers`,
+  ADD COLUMN IF NOT EXISTS `category` varchar(64) NOT NULL DEFAULT '' AFTER `size`,
+  ADD COLUMN IF NOT EXISTS `trap_user_id` int(10) unsigned NOT NULL DEFAULT 0 AFTER `category`;
+
+ALTER TABLE `user`
+  ADD COLUMN IF NOT EXISTS `downloaded_real` bigint(20) unsigned NOT NULL DEFAULT 0 AFTER `uploaded`,
+  ADD COLUMN IF NOT EXISTS `uploaded_real` bigint(20) unsigned NOT NULL DEFAULT 0 AFTER `downloaded_real`,
+  ADD COLUMN IF NOT EXISTS `multi_up` decimal(5,2) NOT NULL DEFAULT -1.00 AFTER `uploaded_real`,
+  ADD COLUMN IF NOT EXISTS `multi_down` decimal(5,2) NOT NULL DEFAULT -1.00 AFTER `multi_up`,
+  ADD COLUMN IF NOT EXISTS `max_leeching` int(10) unsigned NOT NULL DEFAULT 0 AFTER `multi_down`,
+  ADD COLUMN IF NOT EXISTS `bonus_points` decimal(12,2) NOT NULL DEFAULT 0.00 AFTER `max_leeching`;
+
+-- The whitelist_id primary key replacing client_prefix is added by the driver as it cannot be
+-- done conditionally here
+ALTER TABLE `whitelist`
+  MODIFY `client_prefix` varchar(8) NOT NULL DEFAULT '',
+  ADD COLUMN IF NOT EXISTS `client` varchar(64) NOT NULL DEFAULT '' AFTER `client_name`,
+  ADD COLUMN IF NOT EXISTS `min_version` varchar(16) NOT NULL DEFAULT '' AFTER `client`,
+  ADD COLUMN IF NOT EXISTS `max_version` varchar(16) NOT NULL DEFAULT '' AFTER `min_version`,
+  ADD COLUMN IF NOT EXISTS `action` varchar(8) NOT NULL DEFAULT 'allow' AFTER `max_version`,
+  ADD COLUMN IF NOT EXISTS `message` varchar(255) NOT NULL DEFAULT '' AFTER `action`;
@@ -1,10 +1,13 @@
 // Package mysql provides mysql/mariadb backed persistent storage
 //
-// NOTE this requires MySQL 8.0+ / MariaDB 10.5+ (maybe 10.4?) due to the POINT column type
+// NOTE this requires MySQL 8.0+ / MariaDB 10.5+ (maybe 10.4?) due to the POINT column type.
+// Upgrading the tables of older versions requires MariaDB as MySQL does not support
+// ADD COLUMN IF NOT EXISTS.
 package mysql
 
 import (
 	"context"
+	"database/sql"
 	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	"github.com/leighmacdonald/golib"
@@ -31,22 +34,58 @@ type Driver struct {
 	db *sqlx.DB
 }
 
+// Migrate creates any missing tables and upgrades the tables created by older versions
 func (s *Driver) Migrate() error {
-	schemaFile := golib.FindFile(path.Join("store", "mysql", "schema.sql"), path.Join("mika"))
-	body, err := ioutil.ReadFile(schemaFile)
+	if err := s.execFile("schema.sql"); err != nil {
+		return err
+	}
+	if err := s.migrateWhiteList(); err != nil {
+		return err
+	}
+	return s.execFile("migrate.sql")
+}
+
+// execFile executes every query in one of the sql files of the driver
+func (s *Driver) execFile(name string) error {
+	sqlFile := golib.FindFile(path.Join("store", "mysql", name), path.Join("mika"))
+	body, err := ioutil.ReadFile(sqlFile)
 	if err != nil {
-		return errors.Wrap(err, "failed to read schema.sql")
+		return errors.Wrapf(err, "failed to read %s", name)
 	}
 	if _, err := s.db.Exec(string(body)); err != nil {
-		return errors.Wrap(err, "failed to execute migrate query")
+		return errors.Wrapf(err, "failed to execute %s", name)
+	}
+	return nil
+}
+
+// migrateWhiteList replaces the client_prefix primary key of whitelists created by older
+// versions with whitelist_id, so a prefix can have several rules and rules need no prefix
+func (s *Driver) migrateWhiteList() error {
+	const q = `
+		SELECT COUNT(*) 
+		FROM information_schema.columns 
+		WHERE table_schema = DATABASE() AND table_name = 'whitelist' AND column_name = 'whitelist_id'`
+	var found int
+	if err := s.db.Get(&found, q); err != nil {
+		return errors.Wrap(err, "failed to check whitelist table")
+	}
+	if found > 0 {
+		return nil
+	}
+	const alter = `
+		ALTER TABLE whitelist 
+		    DROP PRIMARY KEY,
+		    ADD COLUMN whitelist_id int(10) unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY FIRST`
+	if _, err := s.db.Exec(alter); err != nil {
+		return errors.Wrap(err, "failed to migrate whitelist table")
 	}
 	return nil
 }
 
 func (s *Driver) Users() (store.Users, error) {
 	const q = `
-		SELECT user_id, role_id, is_deleted, downloaded, uploaded, 
-		       announces, passkey, download_enabled 
+		SELECT user_id, role_id, is_deleted, downloaded, uploaded, downloaded_real, uploaded_real,
+		       multi_up, multi_down, max_leeching, bonus_points, announces, passkey, download_enabled 
 		FROM user`
 	var users []*store.User
 	if err := s.db.Select(&users, q); err != nil {
@@ -62,8 +101,8 @@ func (s *Driver) Users() (store.Users, error) {
 func (s *Driver) Torrents() (store.Torrents, error) {
 	const q = `
 		SELECT info_hash, total_uploaded, total_downloaded, total_completed, 
-		       is_deleted, is_enabled, reason, multi_up, multi_dn, seeders, leechers, announces,
-		       title, created_on, updated_on
+		       is_deleted, is_enabled, reason, multi_up, multi_dn, max_peers, size, category, trap_user_id, seeders, 
+		       leechers, announces, title, created_on, updated_on
 		FROM torrent`
 	var torrents []*store.Torrent
 	if err := s.db.Select(&torrents, q); err != nil {
@@ -80,13 +119,14 @@ func (s *Driver) RoleSave(role *store.Role) error {
 	const q = `
 		INSERT INTO role (
             remote_id, role_name, priority, multi_up, multi_down, 
-		    download_enabled, upload_enabled, created_on, updated_on) 
+		    download_enabled, upload_enabled, max_peers, max_hnr, max_leeching, created_on, updated_on) 
 		VALUES 
 		    (:remote_id, :role_name, :priority, :multi_up, :multi_down, 
-		    :download_enabled, :upload_enabled, :created_on, :updated_on)
+		    :download_enabled, :upload_enabled, :max_peers, :max_hnr, :max_leeching, :created_on, :updated_on)
 		ON DUPLICATE KEY UPDATE 
 			remote_id = :remote_id, download_enabled = :download_enabled, upload_enabled = :upload_enabled, 
-		    multi_down = :multi_down, multi_up = :multi_up, 
+		    multi_down = :multi_down, multi_up = :multi_up, max_peers = :max_peers, max_hnr = :max_hnr,
+		    max_leeching = :max_leeching,
 		    priority = :priority, role_name = :role_name
 		`
 	res, err := s.db.NamedExec(q, role)
@@ -107,7 +147,7 @@ func (s *Driver) RoleByID(roleID uint32) (*store.Role, error) {
 	const q = `
 		SELECT 
        		role_id, role_name, priority, multi_up, multi_down, 
-       		download_enabled, upload_enabled, created_on, updated_on 
+       		download_enabled, upload_enabled, max_peers, max_hnr, max_leeching, created_on, updated_on 
 		FROM role 
 		WHERE role_id = ?`
 	var role store.Role
@@ -123,10 +163,11 @@ func (s *Driver) RoleByID(roleID uint32) (*store.Role, error) {
 func (s *Driver) RoleAdd(role *store.Role) error {
 	const q = `
 		INSERT INTO role 
-		    (role_name, priority, multi_up, multi_down, download_enabled, upload_enabled, created_on, updated_on) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		    (role_name, priority, multi_up, multi_down, download_enabled, upload_enabled, max_peers, 
+		     max_hnr, max_leeching, created_on, updated_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	res, err := s.db.Exec(q, role.RoleName, role.Priority, role.MultiUp, role.MultiDown, role.DownloadEnabled,
-		role.UploadEnabled, role.CreatedOn, role.UpdatedOn)
+		role.UploadEnabled, role.MaxPeers, role.MaxHnR, role.MaxLeeching, role.CreatedOn, role.UpdatedOn)
 	if err != nil {
 		return errors.Wrap(err, "Failed to create role")
 	}
@@ -150,7 +191,7 @@ func (s *Driver) Roles() (store.Roles, error) {
 	const q = `
 		SELECT 
 		    role_id, role_name, priority, multi_up, multi_down, download_enabled, 
-       		upload_enabled, created_on, updated_on 
+       		upload_enabled, max_peers, max_hnr, max_leeching, created_on, updated_on 
 		FROM role`
 	var roles []*store.Role
 	if err := s.db.Select(&roles, q); err != nil {
@@ -166,10 +207,13 @@ func (s *Driver) Roles() (store.Roles, error) {
 // Sync batch updates the backing store with the new UserStats provided
 func (s *Driver) UserSync(b []*store.User) error {
 	const q = ` UPDATE user
-    SET announces  = (announces + ?),
-        uploaded   = (uploaded + ?),
-        downloaded = (downloaded + ?)
-    WHERE passkey = ?;`
+    SET announces       = ?,
+        uploaded        = ?,
+        downloaded      = ?,
+        uploaded_real   = ?,
+        downloaded_real = ?,
+        bonus_points    = ?
+    WHERE user_id = ?;`
 	// TODO use ctx for timeout
 	ctx := context.Background()
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -180,8 +224,9 @@ func (s *Driver) UserSync(b []*store.User) error {
 	if err != nil {
 		return errors.Wrap(err, "Failed to prepare user Sync() tx")
 	}
-	for passkey, stats := range b {
-		_, err := stmt.Exec(stats.Announces, stats.Uploaded, stats.Downloaded, passkey)
+	for _, u := range b {
+		_, err := stmt.Exec(u.Announces, u.Uploaded, u.Downloaded, u.UploadedReal, u.DownloadedReal,
+			u.BonusPoints, u.UserID)
 		if err != nil {
 			if err := tx.Rollback(); err != nil {
 				log.Errorf("Failed to roll back user Sync() tx")
@@ -203,10 +248,10 @@ func (s *Driver) UserAdd(user *store.User) error {
 	}
 	const q = `
 		INSERT INTO user
-    		(role_id, remote_id, is_deleted, downloaded, uploaded, announces, passkey, 
-     		download_enabled, created_on, updated_on)
-    	VALUES (:role_id, :remote_id, :is_deleted, :downloaded, :uploaded, :announces, :passkey, 
-     		:download_enabled, :created_on, :updated_on);`
+    		(role_id, remote_id, is_deleted, downloaded, uploaded, downloaded_real, uploaded_real,
+    		multi_up, multi_down, max_leeching, bonus_points, announces, passkey, download_enabled, created_on, updated_on)
+    	VALUES (:role_id, :remote_id, :is_deleted, :downloaded, :uploaded, :downloaded_real, :uploaded_real,
+    		:multi_up, :multi_down, :max_leeching, :bonus_points, :announces, :passkey, :download_enabled, :created_on, :updated_on);`
 	res, err2 := s.db.NamedExec(q, user)
 	if err2 != nil {
 		return errors.Wrap(err2, "Failed to add user to store")
@@ -237,6 +282,12 @@ func (s *Driver) UserGetByPasskey(passkey string) (*store.User, error) {
            	u.is_deleted,
            	u.downloaded,
            	u.uploaded,
+           	u.downloaded_real,
+           	u.uploaded_real,
+           	u.multi_up,
+           	u.multi_down,
+           	u.max_leeching,
+           	u.bonus_points,
            	u.announces,
 			u.role_id
 		FROM user u
@@ -266,6 +317,12 @@ func (s *Driver) UserGetByID(userID uint32) (*store.User, error) {
            	u.is_deleted,
            	u.downloaded,
            	u.uploaded,
+           	u.downloaded_real,
+           	u.uploaded_real,
+           	u.multi_up,
+           	u.multi_down,
+           	u.max_leeching,
+           	u.bonus_points,
            	u.announces,
 			u.role_id
     	FROM user u
@@ -307,11 +364,17 @@ func (s *Driver) UserSave(user *store.User) error {
 			is_deleted       = ?,
 			downloaded       = ?,
 			uploaded         = ?,
+			downloaded_real  = ?,
+			uploaded_real    = ?,
+			multi_up         = ?,
+			multi_down       = ?,
+			max_leeching     = ?,
+			bonus_points     = ?,
 			announces        = ?
 		WHERE user_id = ?`
 	if _, err := s.db.Exec(q, user.Passkey, user.DownloadEnabled,
-		user.IsDeleted, user.Downloaded, user.Uploaded, user.Announces,
-		user.UserID); err != nil {
+		user.IsDeleted, user.Downloaded, user.Uploaded, user.DownloadedReal, user.UploadedReal,
+		user.MultiUp, user.MultiDown, user.MaxLeeching, user.BonusPoints, user.Announces, user.UserID); err != nil {
 		return errors.Wrapf(err, "Failed to update user")
 	}
 	return nil
@@ -340,6 +403,10 @@ func (s *Driver) TorrentSave(torrent *store.Torrent) error {
 		    reason = ?,
 		    multi_up = ?,
 		    multi_dn = ?,
+		    max_peers = ?,
+		    size = ?,
+		    category = ?,
+		    trap_user_id = ?,
 		    announces = ?
 		WHERE
 			info_hash = ?
@@ -354,6 +421,10 @@ func (s *Driver) TorrentSave(torrent *store.Torrent) error {
 		torrent.Reason,
 		torrent.MultiUp,
 		torrent.MultiDn,
+		torrent.MaxPeers,
+		torrent.Size,
+		torrent.Category,
+		torrent.TrapUserID,
 		torrent.Announces,
 		torrent.InfoHash.Bytes())
 	if err != nil {
@@ -409,28 +480,44 @@ func (s *Driver) Conn() interface{} {
 	return s.db
 }
 
-// WhiteListDelete removes a client from the global whitelist
+// WhiteListDelete removes a client rule from the global whitelist
 func (s *Driver) WhiteListDelete(client *store.WhiteListClient) error {
-	const q = `DELETE FROM whitelist WHERE client_prefix = ?`
-	if _, err := s.db.Exec(q, client.ClientPrefix); err != nil {
+	const q = `DELETE FROM whitelist WHERE whitelist_id = ?`
+	res, err := s.db.Exec(q, client.WhiteListID)
+	if err != nil {
 		return errors.Wrap(err, "Failed to delete client whitelist")
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return consts.ErrInvalidClient
+	}
 	return nil
 }
 
-// WhiteListAdd will insert a new client prefix into the allowed clients list
+// WhiteListAdd will insert a new client rule into the global whitelist
 func (s *Driver) WhiteListAdd(client *store.WhiteListClient) error {
-	const q = ` INSERT INTO whitelist (client_prefix, client_name) VALUES (?, ?);`
-	if _, err := s.db.Exec(q, client.ClientPrefix, client.ClientName); err != nil {
+	const q = `
+		INSERT INTO whitelist (client_prefix, client_name, client, min_version, max_version, action, message) 
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+	res, err := s.db.Exec(q, client.ClientPrefix, client.ClientName, client.Client, client.MinVersion,
+		client.MaxVersion, client.Action, client.Message)
+	if err != nil {
 		return errors.Wrap(err, "Failed to insert new whitelist entry")
 	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get whitelist id")
+	}
+	client.WhiteListID = uint32(id)
 	return nil
 }
 
-// WhiteListGetAll fetches all known whitelisted clients
+// WhiteListGetAll fetches all known client whitelist rules
 func (s *Driver) WhiteListGetAll() ([]*store.WhiteListClient, error) {
 	var wl []*store.WhiteListClient
-	const q = `SELECT client_prefix, client_name FROM whitelist;`
+	const q = `
+		SELECT whitelist_id, client_prefix, client_name, client, min_version, max_version, action, message 
+		FROM whitelist 
+		ORDER BY whitelist_id;`
 	if err := s.db.Select(&wl, q); err != nil {
 		return nil, errors.Wrap(err, "Failed to select client whitelists")
 	}
@@ -450,6 +537,10 @@ func (s *Driver) TorrentGet(hash store.InfoHash, deletedOk bool) (*store.Torrent
            	reason,
            	multi_up,
            	multi_dn,
+           	max_peers,
+           	size,
+           	category,
+           	trap_user_id,
            	seeders,
            	leechers,
            	announces
@@ -476,9 +567,11 @@ func (s *Driver) TorrentAdd(t *store.Torrent) error {
 	t.CreatedOn = util.Now()
 	t.UpdatedOn = t.CreatedOn
 	const q = `
-		INSERT INTO torrent (info_hash, multi_up, multi_dn, title, created_on, updated_on) 
-		VALUES (?, ?, ?, ?, ?, ?);`
-	_, err := s.db.Exec(q, t.InfoHash.Bytes(), t.MultiUp, t.MultiDn, t.Title, t.CreatedOn, t.UpdatedOn)
+		INSERT INTO torrent (info_hash, multi_up, multi_dn, max_peers, size, category, trap_user_id, title, 
+		                     created_on, updated_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+	_, err := s.db.Exec(q, t.InfoHash.Bytes(), t.MultiUp, t.MultiDn, t.MaxPeers, t.Size, t.Category,
+		t.TrapUserID, t.Title, t.CreatedOn, t.UpdatedOn)
 	if err != nil {
 		myErr, ok := err.(*mysql.MySQLError)
 		if ok { // MySQL error
@@ -508,6 +601,497 @@ func (s *Driver) TorrentDelete(ih store.InfoHash, dropRow bool) error {
 	return nil
 }
 
+// nullTime converts zero times to NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// Histories returns the transfer history of every user on every torrent
+func (s *Driver) Histories() ([]*store.History, error) {
+	const q = `
+		SELECT user_id, info_hash, uploaded, downloaded, seed_time, leech_time, announce_first,
+		       announce_last, completed_on, is_active, hnr, hnr_on
+		FROM user_history`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query user history")
+	}
+	defer rows.Close()
+	var hist []*store.History
+	for rows.Next() {
+		var (
+			h                   store.History
+			seedTime, leechTime int64
+			completedOn, hnrOn  sql.NullTime
+		)
+		if err := rows.Scan(&h.UserID, &h.InfoHash, &h.Uploaded, &h.Downloaded, &seedTime, &leechTime,
+			&h.AnnounceFirst, &h.AnnounceLast, &completedOn, &h.Active, &h.HnR, &hnrOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan user history")
+		}
+		h.SeedTime = time.Duration(seedTime) * time.Second
+		h.LeechTime = time.Duration(leechTime) * time.Second
+		h.CompletedOn = completedOn.Time
+		h.HnROn = hnrOn.Time
+		hist = append(hist, &h)
+	}
+	return hist, rows.Err()
+}
+
+// HistorySync batch inserts or updates the users transfer histories provided
+func (s *Driver) HistorySync(b []*store.History) error {
+	const q = `
+		INSERT INTO user_history 
+		    (user_id, info_hash, uploaded, downloaded, seed_time, leech_time, announce_first, 
+		     announce_last, completed_on, is_active, hnr, hnr_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE 
+		    uploaded = VALUES(uploaded), downloaded = VALUES(downloaded), seed_time = VALUES(seed_time), 
+		    leech_time = VALUES(leech_time), announce_last = VALUES(announce_last), 
+		    completed_on = VALUES(completed_on), is_active = VALUES(is_active), hnr = VALUES(hnr), 
+		    hnr_on = VALUES(hnr_on)`
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to being history Sync() tx")
+	}
+	stmt, err := tx.Prepare(q)
+	if err != nil {
+		return errors.Wrap(err, "Failed to prepare history Sync() tx")
+	}
+	for _, h := range b {
+		_, err := stmt.Exec(h.UserID, h.InfoHash.Bytes(), h.Uploaded, h.Downloaded, int64(h.SeedTime.Seconds()),
+			int64(h.LeechTime.Seconds()), h.AnnounceFirst, h.AnnounceLast, nullTime(h.CompletedOn), h.Active,
+			h.HnR, nullTime(h.HnROn))
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				log.Errorf("Failed to roll back history Sync() tx")
+			}
+			return errors.Wrap(err, "Failed to exec history Sync() tx")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "Failed to commit history Sync() tx")
+	}
+	return nil
+}
+
+// RatioWatchEvents returns every recorded ratio watch transition, oldest first
+func (s *Driver) RatioWatchEvents() ([]*store.RatioWatchEvent, error) {
+	const q = `
+		SELECT user_id, state, ratio, required, expires, created_on 
+		FROM ratio_watch 
+		ORDER BY ratio_watch_id`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query ratio watch events")
+	}
+	defer rows.Close()
+	var events []*store.RatioWatchEvent
+	for rows.Next() {
+		var (
+			e       store.RatioWatchEvent
+			expires sql.NullTime
+		)
+		if err := rows.Scan(&e.UserID, &e.State, &e.Ratio, &e.Required, &expires, &e.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan ratio watch event")
+		}
+		e.Expires = expires.Time
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// RatioWatchAdd records a new ratio watch transition
+func (s *Driver) RatioWatchAdd(e *store.RatioWatchEvent) error {
+	const q = `
+		INSERT INTO ratio_watch (user_id, state, ratio, required, expires, created_on) 
+		VALUES (?, ?, ?, ?, ?, ?)`
+	if _, err := s.db.Exec(q, e.UserID, e.State, e.Ratio, e.Required, nullTime(e.Expires), e.CreatedOn); err != nil {
+		return errors.Wrap(err, "Failed to add ratio watch event")
+	}
+	return nil
+}
+
+// Events returns every scheduled multiplier event
+func (s *Driver) Events() ([]*store.Event, error) {
+	const q = `
+		SELECT event_id, event_name, multi_up, multi_dn, role_ids, categories, start_on, end_on, created_on 
+		FROM event 
+		ORDER BY event_id`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query events")
+	}
+	defer rows.Close()
+	var events []*store.Event
+	for rows.Next() {
+		var (
+			e                 store.Event
+			roles, categories string
+		)
+		if err := rows.Scan(&e.EventID, &e.Name, &e.MultiUp, &e.MultiDn, &roles, &categories,
+			&e.StartOn, &e.EndOn, &e.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan event")
+		}
+		e.SetScope(roles, categories)
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// EventAdd stores a new event, setting its EventID
+func (s *Driver) EventAdd(e *store.Event) error {
+	const q = `
+		INSERT INTO event (event_name, multi_up, multi_dn, role_ids, categories, start_on, end_on, created_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	roles, categories := e.Scope()
+	res, err := s.db.Exec(q, e.Name, e.MultiUp, e.MultiDn, roles, categories, e.StartOn, e.EndOn, e.CreatedOn)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add event")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get event id")
+	}
+	e.EventID = uint32(id)
+	return nil
+}
+
+// EventDelete permanently removes an event
+func (s *Driver) EventDelete(eventID uint32) error {
+	const q = `DELETE FROM event WHERE event_id = ?`
+	res, err := s.db.Exec(q, eventID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete event")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return consts.ErrInvalidEvent
+	}
+	return nil
+}
+
+// Bans returns every ban, including those which have expired
+func (s *Driver) Bans() ([]*store.Ban, error) {
+	const q = `
+		SELECT ban_id, kind, ban_value, reason, expires, created_on 
+		FROM ban 
+		ORDER BY ban_id`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query bans")
+	}
+	defer rows.Close()
+	var bans []*store.Ban
+	for rows.Next() {
+		var (
+			b       store.Ban
+			expires sql.NullTime
+		)
+		if err := rows.Scan(&b.BanID, &b.Kind, &b.Value, &b.Reason, &expires, &b.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan ban")
+		}
+		b.Expires = expires.Time
+		bans = append(bans, &b)
+	}
+	return bans, rows.Err()
+}
+
+// BanAdd stores a new ban, setting its BanID
+func (s *Driver) BanAdd(b *store.Ban) error {
+	const q = `
+		INSERT INTO ban (kind, ban_value, reason, expires, created_on) 
+		VALUES (?, ?, ?, ?, ?)`
+	res, err := s.db.Exec(q, b.Kind, b.Value, b.Reason, nullTime(b.Expires), b.CreatedOn)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add ban")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get ban id")
+	}
+	b.BanID = uint32(id)
+	return nil
+}
+
+// BanDelete permanently removes a ban
+func (s *Driver) BanDelete(banID uint32) error {
+	const q = `DELETE FROM ban WHERE ban_id = ?`
+	res, err := s.db.Exec(q, banID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete ban")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return consts.ErrInvalidBan
+	}
+	return nil
+}
+
+// RetiredPasskeys returns every retired passkey, including those which have expired
+func (s *Driver) RetiredPasskeys() ([]*store.RetiredPasskey, error) {
+	const q = `
+		SELECT passkey, user_id, expires, created_on 
+		FROM retired_passkey`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query retired passkeys")
+	}
+	defer rows.Close()
+	var passkeys []*store.RetiredPasskey
+	for rows.Next() {
+		var p store.RetiredPasskey
+		if err := rows.Scan(&p.Passkey, &p.UserID, &p.Expires, &p.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan retired passkey")
+		}
+		passkeys = append(passkeys, &p)
+	}
+	return passkeys, rows.Err()
+}
+
+// RetiredPasskeyAdd stores a retired passkey, replacing any existing one with the same passkey
+func (s *Driver) RetiredPasskeyAdd(p *store.RetiredPasskey) error {
+	const q = `
+		INSERT INTO retired_passkey (passkey, user_id, expires, created_on) 
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE 
+		    user_id = VALUES(user_id), expires = VALUES(expires), created_on = VALUES(created_on)`
+	if _, err := s.db.Exec(q, p.Passkey, p.UserID, p.Expires, p.CreatedOn); err != nil {
+		return errors.Wrap(err, "Failed to add retired passkey")
+	}
+	return nil
+}
+
+// RetiredPasskeyDelete permanently removes a retired passkey
+func (s *Driver) RetiredPasskeyDelete(passkey string) error {
+	const q = `DELETE FROM retired_passkey WHERE passkey = ?`
+	if _, err := s.db.Exec(q, passkey); err != nil {
+		return errors.Wrap(err, "Failed to delete retired passkey")
+	}
+	return nil
+}
+
+// Tokens returns every freeleech token
+func (s *Driver) Tokens() ([]*store.FreeleechToken, error) {
+	const q = `
+		SELECT user_id, info_hash, downloaded, max_downloaded, expires, created_on 
+		FROM user_token`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query freeleech tokens")
+	}
+	defer rows.Close()
+	var tokens []*store.FreeleechToken
+	for rows.Next() {
+		var (
+			t       store.FreeleechToken
+			expires sql.NullTime
+		)
+		if err := rows.Scan(&t.UserID, &t.InfoHash, &t.Downloaded, &t.MaxDownloaded, &expires,
+			&t.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan freeleech token")
+		}
+		t.Expires = expires.Time
+		tokens = append(tokens, &t)
+	}
+	return tokens, rows.Err()
+}
+
+// TokenSync batch inserts or updates the freeleech tokens provided
+func (s *Driver) TokenSync(b []*store.FreeleechToken) error {
+	const q = `
+		INSERT INTO user_token (user_id, info_hash, downloaded, max_downloaded, expires, created_on) 
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE 
+		    downloaded = VALUES(downloaded), max_downloaded = VALUES(max_downloaded), 
+		    expires = VALUES(expires), created_on = VALUES(created_on)`
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to being token Sync() tx")
+	}
+	stmt, err := tx.Prepare(q)
+	if err != nil {
+		return errors.Wrap(err, "Failed to prepare token Sync() tx")
+	}
+	for _, t := range b {
+		_, err := stmt.Exec(t.UserID, t.InfoHash.Bytes(), t.Downloaded, t.MaxDownloaded, nullTime(t.Expires),
+			t.CreatedOn)
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				log.Errorf("Failed to roll back token Sync() tx")
+			}
+			return errors.Wrap(err, "Failed to exec token Sync() tx")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "Failed to commit token Sync() tx")
+	}
+	return nil
+}
+
+// TokenDelete permanently removes the freeleech token of the user for the torrent
+func (s *Driver) TokenDelete(userID uint32, ih store.InfoHash) error {
+	const q = `DELETE FROM user_token WHERE user_id = ? AND info_hash = ?`
+	res, err := s.db.Exec(q, userID, ih.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete freeleech token")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return consts.ErrInvalidToken
+	}
+	return nil
+}
+
+// CheatReports returns the cheat reports of the user, oldest first. A userID of 0 returns the
+// reports of every user.
+func (s *Driver) CheatReports(userID uint32) ([]*store.CheatReport, error) {
+	const q = `
+		SELECT cheat_report_id, user_id, info_hash, addr_ip, client, rule, action, uploaded, speed, detail, created_on 
+		FROM cheat_report 
+		WHERE ? = 0 OR user_id = ? 
+		ORDER BY cheat_report_id`
+	rows, err := s.db.Query(q, userID, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query cheat reports")
+	}
+	defer rows.Close()
+	var reports []*store.CheatReport
+	for rows.Next() {
+		var r store.CheatReport
+		if err := rows.Scan(&r.ReportID, &r.UserID, &r.InfoHash, &r.IP, &r.Client, &r.Rule, &r.Action,
+			&r.Uploaded, &r.Speed, &r.Detail, &r.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan cheat report")
+		}
+		reports = append(reports, &r)
+	}
+	return reports, rows.Err()
+}
+
+// CheatReportAdd records a new cheat report, setting its ReportID
+func (s *Driver) CheatReportAdd(r *store.CheatReport) error {
+	const q = `
+		INSERT INTO cheat_report (user_id, info_hash, addr_ip, client, rule, action, uploaded, speed, detail, created_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := s.db.Exec(q, r.UserID, r.InfoHash.Bytes(), r.IP, r.Client, r.Rule, r.Action, r.Uploaded,
+		r.Speed, r.Detail, r.CreatedOn)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add cheat report")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get cheat report id")
+	}
+	r.ReportID = uint32(id)
+	return nil
+}
+
+// TrapLeaks returns the trap leaks of the user, oldest first. A userID of 0 returns the leaks
+// of every user.
+func (s *Driver) TrapLeaks(userID uint32) ([]*store.TrapLeak, error) {
+	const q = `
+		SELECT trap_leak_id, info_hash, user_id, announcer_id, addr_ip, client, passkey_reset, created_on 
+		FROM trap_leak 
+		WHERE ? = 0 OR user_id = ? 
+		ORDER BY trap_leak_id`
+	rows, err := s.db.Query(q, userID, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query trap leaks")
+	}
+	defer rows.Close()
+	var leaks []*store.TrapLeak
+	for rows.Next() {
+		var l store.TrapLeak
+		if err := rows.Scan(&l.LeakID, &l.InfoHash, &l.UserID, &l.AnnouncerID, &l.IP, &l.Client,
+			&l.PasskeyReset, &l.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan trap leak")
+		}
+		leaks = append(leaks, &l)
+	}
+	return leaks, rows.Err()
+}
+
+// TrapLeakAdd records a new trap leak, setting its LeakID
+func (s *Driver) TrapLeakAdd(l *store.TrapLeak) error {
+	const q = `
+		INSERT INTO trap_leak (info_hash, user_id, announcer_id, addr_ip, client, passkey_reset, created_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := s.db.Exec(q, l.InfoHash.Bytes(), l.UserID, l.AnnouncerID, l.IP, l.Client, l.PasskeyReset,
+		l.CreatedOn)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add trap leak")
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get trap leak id")
+	}
+	l.LeakID = uint32(id)
+	return nil
+}
+
+// SpeedProfiles returns every historical speed profile
+func (s *Driver) SpeedProfiles() ([]*store.SpeedProfile, error) {
+	const q = `
+		SELECT kind, profile_key, samples, mean, speed_max, recent, updated_on 
+		FROM speed_profile`
+	rows, err := s.db.Query(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query speed profiles")
+	}
+	defer rows.Close()
+	var profiles []*store.SpeedProfile
+	for rows.Next() {
+		var (
+			p      store.SpeedProfile
+			recent string
+		)
+		if err := rows.Scan(&p.Kind, &p.Key, &p.Samples, &p.Mean, &p.Max, &recent, &p.UpdatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to scan speed profile")
+		}
+		p.SetRecent(recent)
+		profiles = append(profiles, &p)
+	}
+	return profiles, rows.Err()
+}
+
+// SpeedProfileSync batch inserts or updates the speed profiles provided
+func (s *Driver) SpeedProfileSync(b []*store.SpeedProfile) error {
+	const q = `
+		INSERT INTO speed_profile (kind, profile_key, samples, mean, speed_max, recent, updated_on) 
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE 
+		    samples = VALUES(samples), mean = VALUES(mean), speed_max = VALUES(speed_max), 
+		    recent = VALUES(recent), updated_on = VALUES(updated_on)`
+	ctx := context.Background()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Failed to being speed profile Sync() tx")
+	}
+	stmt, err := tx.Prepare(q)
+	if err != nil {
+		return errors.Wrap(err, "Failed to prepare speed profile Sync() tx")
+	}
+	for _, p := range b {
+		if _, err := stmt.Exec(p.Kind, p.Key, p.Samples, p.Mean, p.Max, p.RecentString(), p.UpdatedOn); err != nil {
+			if err := tx.Rollback(); err != nil {
+				log.Errorf("Failed to roll back speed profile Sync() tx")
+			}
+			return errors.Wrap(err, "Failed to exec speed profile Sync() tx")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "Failed to commit speed profile Sync() tx")
+	}
+	return nil
+}
+
+// SpeedProfileDelete permanently removes a speed profile
+func (s *Driver) SpeedProfileDelete(key store.SpeedProfileKey) error {
+	const q = `DELETE FROM speed_profile WHERE kind = ? AND profile_key = ?`
+	if _, err := s.db.Exec(q, key.Kind, key.Key); err != nil {
+		return errors.Wrap(err, "Failed to delete speed profile")
+	}
+	return nil
+}
+
 type driver struct{}
 
 // New creates a new mysql backed user store.
@@ -25,6 +25,9 @@ CREATE TABLE IF NOT EXISTS `role` (
   `multi_down` decimal(5,2) NOT NULL DEFAULT -1.00,
   `download_enabled` tinyint(1) NOT NULL DEFAULT 1,
   `upload_enabled` tinyint(1) NOT NULL DEFAULT 1,
+  `max_peers` int(10) unsigned NOT NULL DEFAULT 0,
+  `max_hnr` int(10) unsigned NOT NULL DEFAULT 0,
+  `max_leeching` int(10) unsigned NOT NULL DEFAULT 0,
   `created_on` timestamp NOT NULL DEFAULT current_timestamp(),
   `updated_on` timestamp NOT NULL DEFAULT current_timestamp(),
   PRIMARY KEY (`role_id`),
@@ -49,6 +52,10 @@ CREATE TABLE IF NOT EXISTS `torrent` (
   `reason` varchar(255) NOT NULL DEFAULT '',
   `multi_up` decimal(5,2) NOT NULL DEFAULT 1.00,
   `multi_dn` decimal(5,2) NOT NULL DEFAULT 1.00,
+  `max_peers` int(10) unsigned NOT NULL DEFAULT 0,
+  `size` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `category` varchar(64) NOT NULL DEFAULT '',
+  `trap_user_id` int(10) unsigned NOT NULL DEFAULT 0,
   `seeders` int(11) NOT NULL DEFAULT 0,
   `leechers` int(11) NOT NULL DEFAULT 0,
   `announces` int(11) NOT NULL DEFAULT 0,
@@ -75,6 +82,12 @@ CREATE TABLE IF NOT EXISTS `user` (
   `is_deleted` tinyint(1) NOT NULL DEFAULT 0,
   `downloaded` bigint(20) unsigned NOT NULL DEFAULT 0,
   `uploaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `downloaded_real` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `uploaded_real` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `multi_up` decimal(5,2) NOT NULL DEFAULT -1.00,
+  `multi_down` decimal(5,2) NOT NULL DEFAULT -1.00,
+  `max_leeching` int(10) unsigned NOT NULL DEFAULT 0,
+  `bonus_points` decimal(12,2) NOT NULL DEFAULT 0.00,
   `announces` int(11) NOT NULL DEFAULT 0,
   `passkey` varchar(40) NOT NULL,
   `download_enabled` tinyint(1) NOT NULL DEFAULT 1,
@@ -87,6 +100,185 @@ CREATE TABLE IF NOT EXISTS `user` (
 ) ENGINE=InnoDB AUTO_INCREMENT=1397 DEFAULT CHARSET=utf8mb4;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
+--
+-- Table structure for table `user_history`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `user_history` (
+  `user_id` int(10) unsigned NOT NULL,
+  `info_hash` binary(20) NOT NULL,
+  `uploaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `downloaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `seed_time` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `leech_time` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `announce_first` datetime NOT NULL DEFAULT current_timestamp(),
+  `announce_last` datetime NOT NULL DEFAULT current_timestamp(),
+  `completed_on` datetime NULL DEFAULT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 0,
+  `hnr` tinyint(1) NOT NULL DEFAULT 0,
+  `hnr_on` datetime NULL DEFAULT NULL,
+  PRIMARY KEY (`user_id`,`info_hash`),
+  KEY `user_history_info_hash_index` (`info_hash`),
+  CONSTRAINT `user_history_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `user_token`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `user_token` (
+  `user_id` int(10) unsigned NOT NULL,
+  `info_hash` binary(20) NOT NULL,
+  `downloaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `max_downloaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `expires` datetime NULL DEFAULT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`user_id`,`info_hash`),
+  CONSTRAINT `user_token_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `ratio_watch`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `ratio_watch` (
+  `ratio_watch_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(10) unsigned NOT NULL,
+  `state` varchar(16) NOT NULL,
+  `ratio` double NOT NULL DEFAULT 0,
+  `required` double NOT NULL DEFAULT 0,
+  `expires` datetime NULL DEFAULT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`ratio_watch_id`),
+  KEY `ratio_watch_user_id_index` (`user_id`),
+  CONSTRAINT `ratio_watch_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `event`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `event` (
+  `event_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `event_name` varchar(255) NOT NULL,
+  `multi_up` decimal(5,2) NOT NULL DEFAULT 1.00,
+  `multi_dn` decimal(5,2) NOT NULL DEFAULT 1.00,
+  `role_ids` varchar(255) NOT NULL DEFAULT '',
+  `categories` varchar(255) NOT NULL DEFAULT '',
+  `start_on` datetime NOT NULL,
+  `end_on` datetime NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `ban`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `ban` (
+  `ban_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `kind` varchar(8) NOT NULL,
+  `ban_value` varchar(64) NOT NULL,
+  `reason` varchar(255) NOT NULL DEFAULT '',
+  `expires` datetime NULL DEFAULT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`ban_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `retired_passkey`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `retired_passkey` (
+  `passkey` varchar(40) NOT NULL,
+  `user_id` int(10) unsigned NOT NULL,
+  `expires` datetime NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`passkey`),
+  KEY `retired_passkey_user_id_index` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `cheat_report`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `cheat_report` (
+  `cheat_report_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(10) unsigned NOT NULL,
+  `info_hash` binary(20) NOT NULL,
+  `addr_ip` varchar(45) NOT NULL DEFAULT '',
+  `client` varchar(255) NOT NULL DEFAULT '',
+  `rule` varchar(32) NOT NULL,
+  `action` varchar(16) NOT NULL,
+  `uploaded` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `speed` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `detail` text NOT NULL,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`cheat_report_id`),
+  KEY `cheat_report_user_id_index` (`user_id`),
+  CONSTRAINT `cheat_report_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `trap_leak`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `trap_leak` (
+  `trap_leak_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `info_hash` binary(20) NOT NULL,
+  `user_id` int(10) unsigned NOT NULL,
+  `announcer_id` int(10) unsigned NOT NULL DEFAULT 0,
+  `addr_ip` varchar(45) NOT NULL DEFAULT '',
+  `client` varchar(255) NOT NULL DEFAULT '',
+  `passkey_reset` tinyint(1) NOT NULL DEFAULT 0,
+  `created_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`trap_leak_id`),
+  KEY `trap_leak_user_id_index` (`user_id`),
+  CONSTRAINT `trap_leak_user_id_fk` FOREIGN KEY (`user_id`) REFERENCES `user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Table structure for table `speed_profile`
+--
+
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE IF NOT EXISTS `speed_profile` (
+  `kind` varchar(8) NOT NULL,
+  `profile_key` varchar(64) NOT NULL,
+  `samples` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `mean` double NOT NULL DEFAULT 0,
+  `speed_max` bigint(20) unsigned NOT NULL DEFAULT 0,
+  `recent` text NOT NULL,
+  `updated_on` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`kind`,`profile_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
 --
 -- Table structure for table `user_multi`
 --
@@ -113,9 +305,15 @@ CREATE TABLE IF NOT EXISTS `user_multi` (
 /*!40101 SET @saved_cs_client     = @@character_set_client */;
 /*!40101 SET character_set_client = utf8 */;
 CREATE TABLE IF NOT EXISTS `whitelist` (
-  `client_prefix` char(8) NOT NULL,
+  `whitelist_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `client_prefix` varchar(8) NOT NULL DEFAULT '',
   `client_name` varchar(20) NOT NULL,
-  PRIMARY KEY (`client_prefix`)
+  `client` varchar(64) NOT NULL DEFAULT '',
+  `min_version` varchar(16) NOT NULL DEFAULT '',
+  `max_version` varchar(16) NOT NULL DEFAULT '',
+  `action` varchar(8) NOT NULL DEFAULT 'allow',
+  `message` varchar(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`whitelist_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 /*!40101 SET character_set_client = @saved_cs_client */;
 /*!40103 SET TIME_ZONE=@OLD_TIME_ZONE */;
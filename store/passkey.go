@@ -0,0 +1,13 @@
+package store
+
+import (
+	"time"
+)
+
+// RetiredPasskey is a rotated passkey of a user which keeps working until it expires
+type RetiredPasskey struct {
+	Passkey   string    `db:"passkey" json:"passkey"`
+	UserID    uint32    `db:"user_id" json:"user_id"`
+	Expires   time.Time `db:"expires" json:"expires"`
+	CreatedOn time.Time `db:"created_on" json:"created_on"`
+}
@@ -7,10 +7,13 @@ import (
 	"github.com/viciious/mika/geo"
 	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
+	"math"
+	"math/rand"
 	"net"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -73,7 +76,7 @@ type Peer struct {
 	// Total amount downloaded as reported by client
 	Downloaded uint64 `db:"total_downloaded" redis:"total_downloaded" json:"total_downloaded"`
 	// Clients reported bytes left of the download
-	Left uint32 `db:"total_left" redis:"total_left" json:"total_left"`
+	Left uint64 `db:"total_left" redis:"total_left" json:"total_left"`
 	// Total active swarm participation time
 	TotalTime time.Duration `db:"total_time" redis:"total_time" json:"total_time"`
 	// Current speed up, bytes/sec
@@ -102,19 +105,73 @@ type Peer struct {
 	ASN         uint32      `db:"asn" json:"asn"`
 	AS          string      `db:"as_name" json:"as_name"`
 	UserID      uint32      `db:"user_id" redis:"user_id" json:"user_id"`
-	// Client is the user-agent header sent
+	// Client is the client and version parsed from the peer id
 	Client string `db:"client" json:"client"`
+	// UserAgent is the User-Agent header sent with HTTP announces
+	UserAgent string `db:"user_agent" json:"user_agent"`
 	// TODO Do we actually care about these times? Announce times likely enough
 	//CreatedOn time.Time `db:"created_on" redis:"created_on" json:"created_on"`
 	//UpdatedOn time.Time `db:"updated_on" redis:"updated_on" json:"updated_on"`
 	CryptoLevel consts.CryptoLevel `db:"crypto_level" json:"crypto_level"`
-	Paused      bool
+	// Paused is set for partial seeds which have sent a paused event (BEP-21)
+	Paused bool
+
+	// seeding records which of the swarm counts the peer is currently included in. It is only
+	// accessed by the Swarm while holding its lock.
+	seeding bool
+}
+
+// Expired checks if the peer has not announced to us within the ttl
+func (peer *Peer) Expired(ttl time.Duration) bool {
+	return time.Since(peer.AnnounceLast) > ttl
+}
+
+// Delta returns the amount uploaded and downloaded since the previous announce of the peer
+// session and records the new totals. Clients report cumulative totals since their last
+// started event so only the difference between announces can be credited.
+//
+// Nothing is credited for a started event or the first announce of a peer as there is no
+// previous total to compare against. A total lower than the previous one means the client
+// restarted its session without telling us, so the new total is credited in full.
+func (peer *Peer) Delta(uploaded uint64, downloaded uint64, event consts.AnnounceType) (uint64, uint64) {
+	prevUp := atomic.SwapUint64(&peer.Uploaded, uploaded)
+	prevDn := atomic.SwapUint64(&peer.Downloaded, downloaded)
+	if event == consts.STARTED || atomic.LoadUint32(&peer.Announces) == 0 {
+		return 0, 0
+	}
+	return counterDelta(prevUp, uploaded), counterDelta(prevDn, downloaded)
 }
 
-// Expired checks if the peer last lost contact with us
-// TODO remove hard coded expiration time
-func (peer *Peer) Expired() bool {
-	return time.Since(peer.AnnounceLast).Seconds() > 300
+func counterDelta(prev uint64, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// UpdateSpeed records the current and max transfer speeds of the peer using the amount
+// transferred since the previous announce. The elapsed time is raised to minElapsed, and to at
+// least a second, so announces made faster than clients are allowed cannot inflate the speeds
+// and the speeds never go stale.
+func (peer *Peer) UpdateSpeed(uploaded uint64, downloaded uint64, elapsed time.Duration, minElapsed time.Duration) {
+	if elapsed < minElapsed {
+		elapsed = minElapsed
+	}
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	speedUP := uint32(math.Min(float64(uploaded)/elapsed.Seconds(), math.MaxUint32))
+	speedDN := uint32(math.Min(float64(downloaded)/elapsed.Seconds(), math.MaxUint32))
+	atomic.StoreUint32(&peer.SpeedUP, speedUP)
+	atomic.StoreUint32(&peer.SpeedDN, speedDN)
+	atomic.StoreUint32(&peer.SpeedUPMax, util.UMax32(atomic.LoadUint32(&peer.SpeedUPMax), speedUP))
+	atomic.StoreUint32(&peer.SpeedDNMax, util.UMax32(atomic.LoadUint32(&peer.SpeedDNMax), speedDN))
+}
+
+// IsSeeder checks if the peer has completed the download. Paused partial seeds will not
+// download anything further so they are considered seeders too.
+func (peer *Peer) IsSeeder() bool {
+	return peer.Paused || atomic.LoadUint64(&peer.Left) == 0
 }
 
 // IsNew checks if the peer is making its first announce request
@@ -127,7 +184,11 @@ func (peer *Peer) Valid() bool {
 	return peer.UserID > 0 && peer.Port >= 1024 && util.IsPrivateIP(peer.IP)
 }
 
-// Swarm is a set of users participating in a torrent
+// Swarm is a set of users participating in a torrent.
+//
+// The Seeders and Leechers counts are maintained as peers are added, removed or change state
+// and are the source of truth for the torrents swarm counts. They must only be modified
+// through the Swarm methods.
 type Swarm struct {
 	Peers
 	Seeders  int
@@ -145,22 +206,97 @@ func NewSwarm() *Swarm {
 	}
 }
 
+// count adds delta to the seeder or leecher count. Must be called with the lock held.
+func (s *Swarm) count(seeding bool, delta int) {
+	if seeding {
+		s.Seeders += delta
+	} else {
+		s.Leechers += delta
+	}
+}
+
+// remove deletes the peer and its contribution to the counts. Must be called with the lock held.
+func (s *Swarm) remove(p *Peer) {
+	delete(s.Peers, p.PeerID)
+	s.count(p.seeding, -1)
+}
+
 // Remove removes a peer from a slice
-func (s Swarm) Remove(p PeerID) {
+func (s *Swarm) Remove(p PeerID) {
 	s.Lock()
-	delete(s.Peers, p)
+	if peer, found := s.Peers[p]; found {
+		s.remove(peer)
+	}
 	s.Unlock()
 }
 
-// Add inserts a new peer into the swarm
-func (s Swarm) Add(p *Peer) {
+// Add inserts a new peer into the swarm, replacing any existing peer with the same peer_id
+func (s *Swarm) Add(p *Peer) {
 	s.Lock()
+	if existing, found := s.Peers[p.PeerID]; found {
+		s.remove(existing)
+	}
+	p.seeding = p.IsSeeder()
 	s.Peers[p.PeerID] = p
+	s.count(p.seeding, 1)
 	s.Unlock()
 }
 
+// SetState updates the amount left to download and paused state of a swarm member, moving it
+// between the seeders and leechers as needed. Returns true when the peer went from leeching
+// to seeding.
+//
+// Counts only ever change when the peer actually changes state, so repeated or missing
+// events from a client cannot skew them.
+func (s *Swarm) SetState(p *Peer, left uint64, paused bool) bool {
+	s.Lock()
+	defer s.Unlock()
+	atomic.StoreUint64(&p.Left, left)
+	p.Paused = paused
+	if _, found := s.Peers[p.PeerID]; !found {
+		return false
+	}
+	seeding := p.IsSeeder()
+	if seeding == p.seeding {
+		return false
+	}
+	s.count(p.seeding, -1)
+	s.count(seeding, 1)
+	p.seeding = seeding
+	return seeding
+}
+
+// Counts returns the current number of seeders and leechers in the swarm
+func (s *Swarm) Counts() (uint32, uint32) {
+	if s == nil {
+		return 0, 0
+	}
+	s.RLock()
+	defer s.RUnlock()
+	return uint32(s.Seeders), uint32(s.Leechers)
+}
+
+// Reconcile recounts the seeders and leechers from the state of each peer and corrects the
+// counts if they have drifted. Returns true if a correction was made.
+func (s *Swarm) Reconcile() bool {
+	s.Lock()
+	defer s.Unlock()
+	seeders, leechers := 0, 0
+	for _, p := range s.Peers {
+		p.seeding = p.IsSeeder()
+		if p.seeding {
+			seeders++
+		} else {
+			leechers++
+		}
+	}
+	drifted := seeders != s.Seeders || leechers != s.Leechers
+	s.Seeders, s.Leechers = seeders, leechers
+	return drifted
+}
+
 // UpdatePeer will update a swarm member with new stats
-func (s Swarm) UpdatePeer(peerID PeerID, stats PeerStats) (*Peer, bool) {
+func (s *Swarm) UpdatePeer(peerID PeerID, stats PeerStats) (*Peer, bool) {
 	s.Lock()
 	peer, ok := s.Peers[peerID]
 	if !ok {
@@ -174,27 +310,54 @@ func (s Swarm) UpdatePeer(peerID PeerID, stats PeerStats) (*Peer, bool) {
 	}
 	peer.Announces += uint32(len(stats.Hist))
 	peer.Left = stats.Left
-	s.Peers[peerID] = peer
+	s.count(peer.seeding, -1)
+	peer.seeding = peer.IsSeeder()
+	s.count(peer.seeding, 1)
 	s.Unlock()
 	return peer, true
 }
 
-// ReapExpired will delete any peers from the swarm that are considered expired
-func (s Swarm) ReapExpired(infoHash InfoHash) []PeerHash {
+// ReapExpired will delete any peers from the swarm that have not announced within the ttl
+// and returns the removed peers
+func (s *Swarm) ReapExpired(ttl time.Duration) []*Peer {
 	s.Lock()
-	var peerHashes []PeerHash
-	for k, peer := range s.Peers {
-		if peer.Expired() {
-			delete(s.Peers, k)
-			peerHashes = append(peerHashes, NewPeerHash(infoHash, peer.PeerID))
+	var expired []*Peer
+	for _, peer := range s.Peers {
+		if peer.Expired(ttl) {
+			s.remove(peer)
+			expired = append(expired, peer)
 		}
 	}
 	s.Unlock()
-	return peerHashes
+	return expired
+}
+
+// UserSeeding checks if the user has a seeding peer in the swarm
+func (s *Swarm) UserSeeding(userID uint32) bool {
+	s.RLock()
+	defer s.RUnlock()
+	for _, p := range s.Peers {
+		if p.UserID == userID && p.seeding {
+			return true
+		}
+	}
+	return false
+}
+
+// UserLeeching checks if the user has a leeching peer in the swarm
+func (s *Swarm) UserLeeching(userID uint32) bool {
+	s.RLock()
+	defer s.RUnlock()
+	for _, p := range s.Peers {
+		if p.UserID == userID && !p.seeding {
+			return true
+		}
+	}
+	return false
 }
 
 // Get will copy a peer into the peer pointer passed in if it exists.
-func (s Swarm) Get(peerID PeerID) (*Peer, error) {
+func (s *Swarm) Get(peerID PeerID) (*Peer, error) {
 	s.RLock()
 	defer s.RUnlock()
 	p, found := s.Peers[peerID]
@@ -204,18 +367,48 @@ func (s Swarm) Get(peerID PeerID) (*Peer, error) {
 	return p, nil
 }
 
-// Get will copy a peer into the peer pointer passed in if it exists.
-func (s Swarm) GetN(n int) ([]*Peer, error) {
+// PeerQuery describes which peers of a swarm should be returned to an announcing peer
+type PeerQuery struct {
+	// Requester is the announcing peer, it is never included in the results
+	Requester *Peer
+	// Limit is the maximum number of peers to return
+	Limit int
+	// TTL is how long a peer can go without announcing before it is considered expired, 0
+	// includes all peers
+	TTL time.Duration
+	// ExcludeSeeders omits seeders from the results. Seeders have nothing to exchange with
+	// each other so this should be set when the requester has completed the download
+	ExcludeSeeders bool
+	// Exclude omits any peer it returns true for when set
+	Exclude func(p *Peer) bool
+}
+
+// Select returns the peers matching the query chosen by the PeerSelector. Expired peers are
+// never included, even if they have not been reaped yet. Candidates are shuffled before selection so repeated announces do not keep
+// receiving the same peers.
+func (s *Swarm) Select(sel PeerSelector, q PeerQuery) []*Peer {
+	if q.Limit <= 0 {
+		return nil
+	}
+	var seeders, leechers []*Peer
 	s.RLock()
-	defer s.RUnlock()
-	var peerSet []*Peer
 	for _, p := range s.Peers {
-		peerSet = append(peerSet, p)
-		if len(peerSet) >= n {
-			break
+		if p.PeerID == q.Requester.PeerID || (q.TTL > 0 && p.Expired(q.TTL)) ||
+			(q.Exclude != nil && q.Exclude(p)) {
+			continue
+		}
+		if p.IsSeeder() {
+			if !q.ExcludeSeeders {
+				seeders = append(seeders, p)
+			}
+		} else {
+			leechers = append(leechers, p)
 		}
 	}
-	return peerSet, nil
+	s.RUnlock()
+	rand.Shuffle(len(seeders), func(i, j int) { seeders[i], seeders[j] = seeders[j], seeders[i] })
+	rand.Shuffle(len(leechers), func(i, j int) { leechers[i], leechers[j] = leechers[j], leechers[i] })
+	return sel.Select(q.Requester, seeders, leechers, q.Limit)
 }
 
 // NewPeer create a new peer instance for inserting into a swarm
@@ -242,7 +435,7 @@ type UpdateState struct {
 	// Total amount downloaded as reported by client
 	Downloaded uint64
 	// Clients reported bytes left of the download
-	Left uint32
+	Left uint64
 	// Timestamp is the time the new stats were announced
 	Timestamp time.Time
 	Event     consts.AnnounceType
@@ -261,6 +454,44 @@ func (b BTClient) String() string {
 	return fmt.Sprintf("%s %d.%d.%d.%d", b.Name, b.Major, b.Minor, b.Patch, b.SubPatch)
 }
 
+// Version returns the version of the client
+func (b BTClient) Version() ClientVersion {
+	return ClientVersion{b.Major, b.Minor, b.Patch, b.SubPatch}
+}
+
+// ClientVersion is the major, minor, patch and sub patch version of a client
+type ClientVersion [4]int
+
+// Compare returns -1, 0 or 1 when the version is older, the same or newer than other
+func (v ClientVersion) Compare(other ClientVersion) int {
+	for i := range v {
+		if v[i] < other[i] {
+			return -1
+		}
+		if v[i] > other[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+// ParseClientVersion parses a dotted version of up to 4 parts, eg: 4.3.1. Missing parts are 0.
+func ParseClientVersion(s string) (ClientVersion, error) {
+	var v ClientVersion
+	parts := strings.Split(strings.TrimSpace(s), ".")
+	if len(parts) > len(v) {
+		return v, errors.Errorf("Too many version parts: %s", s)
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return v, errors.Errorf("Invalid version: %s", s)
+		}
+		v[i] = int(n)
+	}
+	return v, nil
+}
+
 const shadowCharSet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz.-"
 
 // ClientString transforms the peer id into a client and version description
@@ -2,8 +2,10 @@ package store
 
 import (
 	"fmt"
+	"github.com/viciious/mika/consts"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestClientString(t *testing.T) {
@@ -20,3 +22,95 @@ func TestClientString(t *testing.T) {
 		require.Equal(t, c.client, ClientString(c.peerID).String())
 	}
 }
+
+func TestPeer_Delta(t *testing.T) {
+	peer := GenerateTestPeer()
+	steps := []struct {
+		event  consts.AnnounceType
+		up, dn uint64
+		expUp  uint64
+		expDn  uint64
+	}{
+		// Nothing credited for a session start
+		{consts.STARTED, 100, 200, 0, 0},
+		{consts.ANNOUNCE, 1000, 5000, 900, 4800},
+		// No change
+		{consts.ANNOUNCE, 1000, 5000, 0, 0},
+		// Larger than 4GiB
+		{consts.ANNOUNCE, 1000, 5 << 30, 0, 5<<30 - 5000},
+		// Client restarted without a started event, counters reset
+		{consts.ANNOUNCE, 300, 400, 300, 400},
+		{consts.STOPPED, 500, 400, 200, 0},
+	}
+	for i, s := range steps {
+		up, dn := peer.Delta(s.up, s.dn, s.event)
+		require.Equal(t, s.expUp, up, "Invalid uploaded (%d)", i)
+		require.Equal(t, s.expDn, dn, "Invalid downloaded (%d)", i)
+		require.Equal(t, s.up, peer.Uploaded)
+		require.Equal(t, s.dn, peer.Downloaded)
+		peer.Announces++
+	}
+	newPeer := GenerateTestPeer()
+	up, dn := newPeer.Delta(1000, 1000, consts.ANNOUNCE)
+	require.Equal(t, uint64(0), up+dn, "New peers should not be credited")
+}
+
+func TestSwarm_ReapExpired(t *testing.T) {
+	swarm := NewSwarm()
+	fresh := GenerateTestPeer()
+	stale := GenerateTestPeer()
+	stale.AnnounceLast = time.Now().Add(-2 * time.Minute)
+	swarm.Add(fresh)
+	swarm.Add(stale)
+	require.Empty(t, swarm.ReapExpired(5*time.Minute))
+	require.Equal(t, []*Peer{stale}, swarm.ReapExpired(time.Minute))
+	_, err := swarm.Get(stale.PeerID)
+	require.Error(t, err)
+	_, err = swarm.Get(fresh.PeerID)
+	require.NoError(t, err)
+}
+
+func TestSwarm_Counts(t *testing.T) {
+	swarm := NewSwarm()
+	seeder := GenerateTestPeer()
+	leecher := GenerateTestPeer()
+	leecher.Left = 1000
+	swarm.Add(seeder)
+	swarm.Add(leecher)
+	// Re-adding the same peer must not count it twice
+	swarm.Add(leecher)
+	seeders, leechers := swarm.Counts()
+	require.Equal(t, uint32(1), seeders)
+	require.Equal(t, uint32(1), leechers)
+
+	require.True(t, swarm.SetState(leecher, 0, false))
+	// Duplicate completion
+	require.False(t, swarm.SetState(leecher, 0, false))
+	seeders, leechers = swarm.Counts()
+	require.Equal(t, uint32(2), seeders)
+	require.Equal(t, uint32(0), leechers)
+
+	// Paused partial seeds are counted as seeders
+	require.False(t, swarm.SetState(seeder, 500, true))
+	require.Equal(t, 2, swarm.Seeders)
+	require.False(t, swarm.SetState(seeder, 500, false))
+	require.Equal(t, 1, swarm.Seeders)
+	require.Equal(t, 1, swarm.Leechers)
+
+	swarm.Remove(leecher.PeerID)
+	swarm.Remove(leecher.PeerID)
+	seeders, leechers = swarm.Counts()
+	require.Equal(t, uint32(0), seeders)
+	require.Equal(t, uint32(1), leechers)
+
+	require.False(t, swarm.Reconcile())
+	swarm.Seeders = 5
+	require.True(t, swarm.Reconcile())
+	seeders, leechers = swarm.Counts()
+	require.Equal(t, uint32(0), seeders)
+	require.Equal(t, uint32(1), leechers)
+
+	var missing *Swarm
+	seeders, leechers = missing.Counts()
+	require.Equal(t, uint32(0), seeders+leechers)
+}
@@ -0,0 +1,44 @@
+--
+-- Upgrades the tables created by older versions of schema.sql. New tables are created by
+-- schema.sql itself so only the columns added to existing tables are handled here.
+--
+
+-- roles is not created by schema.sql yet but tables made for the role driver get the new limits
+alter table if exists roles
+    add column if not exists multi_up numeric(5,2) default -1 not null,
+    add column if not exists multi_down numeric(5,2) default -1 not null,
+    add column if not exists max_peers int default 0 not null,
+    add column if not exists max_hnr int default 0 not null,
+    add column if not exists max_leeching int default 0 not null;
+
+alter table if exists torrent
+    add column if not exists max_peers int default 0 not null,
+    add column if not exists size bigint default 0 not null,
+    add column if not exists category varchar(64) default '' not null,
+    add column if not exists trap_user_id int default 0 not null;
+
+alter table if exists users
+    add column if not exists downloaded_real bigint default 0 not null,
+    add column if not exists uploaded_real bigint default 0 not null,
+    add column if not exists multi_up numeric(5,2) default -1 not null,
+    add column if not exists multi_down numeric(5,2) default -1 not null,
+    add column if not exists max_leeching int default 0 not null,
+    add column if not exists bonus_points numeric(12,2) default 0 not null;
+
+-- whitelist_id replaces client_prefix as the primary key so a prefix can have several rules
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'whitelist')
+        AND NOT EXISTS (SELECT 1 FROM information_schema.columns
+                        WHERE table_name = 'whitelist' AND column_name = 'whitelist_id') THEN
+        ALTER TABLE whitelist DROP CONSTRAINT whitelist_pkey;
+        ALTER TABLE whitelist ADD COLUMN whitelist_id SERIAL PRIMARY KEY;
+    END IF;
+END $$;
+
+alter table if exists whitelist
+    alter column client_prefix set default '',
+    add column if not exists client varchar(64) default '' not null,
+    add column if not exists min_version varchar(16) default '' not null,
+    add column if not exists max_version varchar(16) default '' not null,
+    add column if not exists action varchar(8) default 'allow' not null,
+    add column if not exists message varchar(255) default '' not null;
@@ -6,12 +6,15 @@ import (
 	"context"
 	"fmt"
 	"github.com/jackc/pgx/v4"
+	"github.com/leighmacdonald/golib"
 	"github.com/viciious/mika/config"
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/store"
 	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"path"
 	"time"
 )
 
@@ -29,7 +32,20 @@ func (d *Driver) TorrentSave(torrent *store.Torrent) error {
 	return nil
 }
 
-func (d *Driver) Migrate() error { return nil }
+// Migrate upgrades the tables created by older versions of schema.sql
+func (d *Driver) Migrate() error {
+	migrateFile := golib.FindFile(path.Join("store", "postgres", "migrate.sql"), path.Join("mika"))
+	body, err := ioutil.ReadFile(migrateFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read migrate.sql")
+	}
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	if _, err := d.db.Exec(c, string(body)); err != nil {
+		return errors.Wrap(err, "failed to execute migrate.sql")
+	}
+	return nil
+}
 
 func (d *Driver) Users() (store.Users, error) {
 	panic("implement me")
@@ -69,14 +85,21 @@ func (d *Driver) UserSave(user *store.User) error {
 		    download_enabled = $3,
 		    downloaded = $4,
 		    uploaded = $5,
-		    announces = $6
+		    downloaded_real = $6,
+		    uploaded_real = $7,
+		    multi_up = $8,
+		    multi_down = $9,
+		    max_leeching = $10,
+		    bonus_points = $11,
+		    announces = $12
 		WHERE
-			user_id = $7
+			user_id = $13
 	`
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
 	_, err := d.db.Exec(c, q, user.Passkey, user.IsDeleted, user.DownloadEnabled,
-		user.Downloaded, user.Uploaded, user.Announces, user.UserID)
+		user.Downloaded, user.Uploaded, user.DownloadedReal, user.UploadedReal,
+		user.MultiUp, user.MultiDown, user.MaxLeeching, user.BonusPoints, user.Announces, user.UserID)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to update user: %d", user.UserID)
 	}
@@ -90,11 +113,14 @@ func (d *Driver) UserSync(batch []*store.User) error {
 		UPDATE 
 			users
 		SET
-			downloaded = (downloaded + $1),
-		    uploaded = (uploaded + $2),
-		    announces = (announces + $3)
+			downloaded = $1,
+		    uploaded = $2,
+		    downloaded_real = $3,
+		    uploaded_real = $4,
+		    announces = $5,
+		    bonus_points = $6
 		WHERE
-			passkey = $4
+			user_id = $7
 `
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(time.Second*10))
 	defer cancel()
@@ -108,8 +134,9 @@ func (d *Driver) UserSync(batch []*store.User) error {
 		return errors.Wrap(err, "postgres.Store.Sync Failed to being transaction")
 	}
 
-	for passkey, stats := range batch {
-		if _, err := tx.Exec(c, txName, stats.Downloaded, stats.Uploaded, stats.Announces, passkey); err != nil {
+	for _, u := range batch {
+		if _, err := tx.Exec(c, txName, u.Downloaded, u.Uploaded, u.DownloadedReal, u.UploadedReal,
+			u.Announces, u.BonusPoints, u.UserID); err != nil {
 			return errors.Wrapf(err, "postgres.Store.Sync failed to Exec tx")
 		}
 	}
@@ -125,11 +152,13 @@ func (d *Driver) UserAdd(user *store.User) error {
 	defer cancel()
 	const q = `
 		INSERT INTO users 
-		    (user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, announces) 
+		    (user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, 
+		     downloaded_real, uploaded_real, multi_up, multi_down, max_leeching, bonus_points, announces) 
 		VALUES
-		    ($1, $2, $3, $4, $5, $6, $7)`
+		    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
 	_, err := d.db.Exec(c, q, user.UserID, user.Passkey, user.DownloadEnabled, user.IsDeleted,
-		user.Downloaded, user.Uploaded, user.Announces)
+		user.Downloaded, user.Uploaded, user.DownloadedReal, user.UploadedReal,
+		user.MultiUp, user.MultiDown, user.MaxLeeching, user.BonusPoints, user.Announces)
 	if err != nil {
 		return errors.Wrap(err, "Failed to add user to store")
 	}
@@ -143,7 +172,8 @@ func (d *Driver) UserAdd(user *store.User) error {
 func (d *Driver) UserGetByPasskey(passkey string) (*store.User, error) {
 	const q = `
 		SELECT 
-		    user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, announces 
+		    user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, 
+		    downloaded_real, uploaded_real, multi_up, multi_down, max_leeching, bonus_points, announces 
 		FROM 
 		    users 
 		WHERE 
@@ -152,7 +182,8 @@ func (d *Driver) UserGetByPasskey(passkey string) (*store.User, error) {
 	defer cancel()
 	var user store.User
 	err := d.db.QueryRow(c, q, passkey).Scan(&user.UserID, &user.Passkey, &user.DownloadEnabled, &user.IsDeleted,
-		&user.Downloaded, &user.Uploaded, &user.Announces)
+		&user.Downloaded, &user.Uploaded, &user.DownloadedReal, &user.UploadedReal,
+		&user.MultiUp, &user.MultiDown, &user.MaxLeeching, &user.BonusPoints, &user.Announces)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch user by passkey")
 	}
@@ -163,7 +194,8 @@ func (d *Driver) UserGetByPasskey(passkey string) (*store.User, error) {
 func (d *Driver) UserGetByID(userID uint32) (*store.User, error) {
 	const q = `
 		SELECT 
-		    user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, announces 
+		    user_id, passkey, download_enabled, is_deleted, downloaded, uploaded, 
+		    downloaded_real, uploaded_real, multi_up, multi_down, max_leeching, bonus_points, announces 
 		FROM 
 		    users 
 		WHERE 
@@ -172,7 +204,8 @@ func (d *Driver) UserGetByID(userID uint32) (*store.User, error) {
 	defer cancel()
 	var user store.User
 	err := d.db.QueryRow(c, q, userID).Scan(&user.UserID, &user.Passkey, &user.DownloadEnabled, &user.IsDeleted,
-		&user.Downloaded, &user.Uploaded, &user.Announces)
+		&user.Downloaded, &user.Uploaded, &user.DownloadedReal, &user.UploadedReal,
+		&user.MultiUp, &user.MultiDown, &user.MaxLeeching, &user.BonusPoints, &user.Announces)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch user by user_id")
 	}
@@ -209,15 +242,21 @@ func (d *Driver) TorrentUpdate(torrent *store.Torrent) error {
 		    reason = $7,
 		    multi_up = $8,
 		    multi_dn = $9,
-		    announces = $10
+		    max_peers = $10,
+		    size = $11,
+		    category = $12,
+		    trap_user_id = $13,
+		    announces = $14
 		WHERE
-			info_hash = $11
+			info_hash = $15
 			`
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
 	_, err := d.db.Exec(c, q, torrent.InfoHash.Bytes(), torrent.Snatches,
 		torrent.Uploaded, torrent.Downloaded, torrent.IsDeleted, torrent.IsEnabled,
-		torrent.Reason, torrent.MultiUp, torrent.MultiDn, torrent.Announces)
+		torrent.Reason, torrent.MultiUp, torrent.MultiDn, torrent.MaxPeers, torrent.Size, torrent.Category,
+		torrent.TrapUserID, torrent.Announces,
+		torrent.InfoHash.Bytes())
 	if err != nil {
 		return errors.Wrapf(err, "Failed to update torrent: %s", torrent.InfoHash.String())
 	}
@@ -265,6 +304,99 @@ func (d *Driver) TorrentSync(batch []*store.Torrent) error {
 	return nil
 }
 
+// nullTime converts zero times to NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// Histories returns the transfer history of every user on every torrent
+func (d *Driver) Histories() ([]*store.History, error) {
+	const q = `
+		SELECT user_id, info_hash, uploaded, downloaded, seed_time, leech_time, announce_first,
+		       announce_last, completed_on, is_active, hnr, hnr_on
+		FROM user_history`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select user history")
+	}
+	defer rows.Close()
+	var hist []*store.History
+	for rows.Next() {
+		var (
+			h                   store.History
+			ih                  []byte
+			seedTime, leechTime int64
+			completedOn, hnrOn  *time.Time
+		)
+		if err := rows.Scan(&h.UserID, &ih, &h.Uploaded, &h.Downloaded, &seedTime, &leechTime,
+			&h.AnnounceFirst, &h.AnnounceLast, &completedOn, &h.Active, &h.HnR, &hnrOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch user history")
+		}
+		if err := store.InfoHashFromBytes(&h.InfoHash, ih); err != nil {
+			return nil, errors.Wrap(err, "Invalid user history info_hash")
+		}
+		h.SeedTime = time.Duration(seedTime) * time.Second
+		h.LeechTime = time.Duration(leechTime) * time.Second
+		if completedOn != nil {
+			h.CompletedOn = *completedOn
+		}
+		if hnrOn != nil {
+			h.HnROn = *hnrOn
+		}
+		hist = append(hist, &h)
+	}
+	return hist, rows.Err()
+}
+
+// HistorySync batch inserts or updates the users transfer histories provided
+func (d *Driver) HistorySync(batch []*store.History) error {
+	const txName = "historySync"
+	const q = `
+		INSERT INTO user_history 
+		    (user_id, info_hash, uploaded, downloaded, seed_time, leech_time, announce_first, 
+		     announce_last, completed_on, is_active, hnr, hnr_on) 
+		VALUES ($1, $2::bytea, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (user_id, info_hash) DO UPDATE 
+		SET 
+		    uploaded = excluded.uploaded,
+		    downloaded = excluded.downloaded,
+		    seed_time = excluded.seed_time,
+		    leech_time = excluded.leech_time,
+		    announce_last = excluded.announce_last,
+		    completed_on = excluded.completed_on,
+		    is_active = excluded.is_active,
+		    hnr = excluded.hnr,
+		    hnr_on = excluded.hnr_on
+`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(time.Second*10))
+	defer cancel()
+	tx, err := d.db.Begin(c)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.HistorySync Failed to being transaction")
+	}
+	defer func() { _ = tx.Rollback(c) }()
+	_, err = tx.Prepare(c, txName, q)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.HistorySync Failed to prepare statement")
+	}
+	for _, h := range batch {
+		if _, err := tx.Exec(c, txName, h.UserID, h.InfoHash.Bytes(), h.Uploaded, h.Downloaded,
+			int64(h.SeedTime.Seconds()), int64(h.LeechTime.Seconds()), h.AnnounceFirst, h.AnnounceLast,
+			nullTime(h.CompletedOn), h.Active, h.HnR, nullTime(h.HnROn)); err != nil {
+			return errors.Wrapf(err, "postgres.Store.HistorySync failed to Exec tx")
+		}
+	}
+	if err := tx.Commit(c); err != nil {
+		return errors.Wrapf(err, "postgres.Store.HistorySync failed to commit tx")
+	}
+	return nil
+}
+
 // Conn returns the underlying database driverInit
 func (d *Driver) Conn() interface{} {
 	return d.db
@@ -272,11 +404,11 @@ func (d *Driver) Conn() interface{} {
 
 // Add inserts a new torrent into the backing store
 func (d *Driver) TorrentAdd(t *store.Torrent) error {
-	const q = `INSERT INTO torrent (info_hash) VALUES($1::bytea)`
+	const q = `INSERT INTO torrent (info_hash, size, category, trap_user_id) VALUES($1::bytea, $2, $3, $4)`
 	//log.Println(t.InfoHash.Bytes())
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
-	commandTag, err := d.db.Exec(c, q, t.InfoHash.Bytes())
+	commandTag, err := d.db.Exec(c, q, t.InfoHash.Bytes(), t.Size, t.Category, t.TrapUserID)
 	if err != nil {
 		return err
 	}
@@ -314,7 +446,8 @@ func (d *Driver) TorrentGet(ih store.InfoHash, deletedOk bool) (*store.Torrent,
 	const q = `
 		SELECT 
 			info_hash::bytea, total_uploaded, total_downloaded, total_completed, 
-			is_deleted, is_enabled, reason, multi_up, multi_dn, announces, seeders, leechers
+			is_deleted, is_enabled, reason, multi_up, multi_dn, max_peers, size, category, trap_user_id, announces, 
+			seeders, leechers
 		FROM 
 		    torrent 
 		WHERE 
@@ -333,6 +466,10 @@ func (d *Driver) TorrentGet(ih store.InfoHash, deletedOk bool) (*store.Torrent,
 		&t.Reason,
 		&t.MultiUp,
 		&t.MultiDn,
+		&t.MaxPeers,
+		&t.Size,
+		&t.Category,
+		&t.TrapUserID,
 		&t.Announces,
 		&t.Seeders,
 		&t.Leechers,
@@ -357,40 +494,44 @@ func (d *Driver) Close() error {
 	return d.db.Close(c)
 }
 
-// WhiteListDelete removes a client from the global whitelist
+// WhiteListDelete removes a client rule from the global whitelist
 func (d *Driver) WhiteListDelete(client *store.WhiteListClient) error {
-	const q = `DELETE FROM whitelist WHERE client_prefix = $1`
+	const q = `DELETE FROM whitelist WHERE whitelist_id = $1`
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
-	commandTag, err := d.db.Exec(c, q, client.ClientPrefix)
+	commandTag, err := d.db.Exec(c, q, client.WhiteListID)
 	if err != nil {
 		return errors.Wrap(err, "Failed to delete client whitelist")
 	}
-	if commandTag.RowsAffected() != 1 {
-		return errors.New("insert ok, but no row modified")
+	if commandTag.RowsAffected() == 0 {
+		return consts.ErrInvalidClient
 	}
 	return nil
 }
 
-// WhiteListAdd will insert a new client prefix into the allowed clients list
+// WhiteListAdd will insert a new client rule into the global whitelist
 func (d *Driver) WhiteListAdd(client *store.WhiteListClient) error {
-	const q = `INSERT INTO whitelist (client_prefix, client_name) VALUES ($1, $2)`
+	const q = `
+		INSERT INTO whitelist (client_prefix, client_name, client, min_version, max_version, action, message) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING whitelist_id`
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
-	commandTag, err := d.db.Exec(c, q, client.ClientPrefix, client.ClientName)
+	err := d.db.QueryRow(c, q, client.ClientPrefix, client.ClientName, client.Client, client.MinVersion,
+		client.MaxVersion, client.Action, client.Message).Scan(&client.WhiteListID)
 	if err != nil {
 		return errors.Wrap(err, "Failed to insert new whitelist entry")
 	}
-	if commandTag.RowsAffected() != 1 {
-		return errors.New("Failed to insert, but no error?")
-	}
 	return nil
 }
 
-// WhiteListGetAll fetches all known whitelisted clients
+// WhiteListGetAll fetches all known client whitelist rules
 func (d *Driver) WhiteListGetAll() ([]*store.WhiteListClient, error) {
 	var wl []*store.WhiteListClient
-	const q = `SELECT client_prefix, client_name FROM whitelist`
+	const q = `
+		SELECT whitelist_id, client_prefix, client_name, client, min_version, max_version, action, message 
+		FROM whitelist 
+		ORDER BY whitelist_id`
 	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
 	defer cancel()
 	rows, err := d.db.Query(c, q)
@@ -400,7 +541,8 @@ func (d *Driver) WhiteListGetAll() ([]*store.WhiteListClient, error) {
 	defer rows.Close()
 	for rows.Next() {
 		var client store.WhiteListClient
-		err = rows.Scan(&client.ClientPrefix, &client.ClientName)
+		err = rows.Scan(&client.WhiteListID, &client.ClientPrefix, &client.ClientName, &client.Client,
+			&client.MinVersion, &client.MaxVersion, &client.Action, &client.Message)
 		if err != nil {
 			return nil, errors.Wrap(err, "Failed to fetch client whitelist")
 		}
@@ -432,6 +574,476 @@ func (d *Driver) Reap() []store.PeerHash {
 	return peerHashes
 }
 
+// RatioWatchEvents returns every recorded ratio watch transition, oldest first
+func (d *Driver) RatioWatchEvents() ([]*store.RatioWatchEvent, error) {
+	const q = `
+		SELECT user_id, state, ratio, required, expires, created_on 
+		FROM ratio_watch 
+		ORDER BY ratio_watch_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select ratio watch events")
+	}
+	defer rows.Close()
+	var events []*store.RatioWatchEvent
+	for rows.Next() {
+		var (
+			e       store.RatioWatchEvent
+			state   string
+			expires *time.Time
+		)
+		if err := rows.Scan(&e.UserID, &state, &e.Ratio, &e.Required, &expires, &e.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch ratio watch event")
+		}
+		e.State = store.RatioState(state)
+		if expires != nil {
+			e.Expires = *expires
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// RatioWatchAdd records a new ratio watch transition
+func (d *Driver) RatioWatchAdd(e *store.RatioWatchEvent) error {
+	const q = `
+		INSERT INTO ratio_watch (user_id, state, ratio, required, expires, created_on) 
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if _, err := d.db.Exec(c, q, e.UserID, string(e.State), e.Ratio, e.Required, nullTime(e.Expires),
+		e.CreatedOn); err != nil {
+		return errors.Wrap(err, "Failed to add ratio watch event")
+	}
+	return nil
+}
+
+// Events returns every scheduled multiplier event
+func (d *Driver) Events() ([]*store.Event, error) {
+	const q = `
+		SELECT event_id, event_name, multi_up, multi_dn, role_ids, categories, start_on, end_on, created_on 
+		FROM event 
+		ORDER BY event_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select events")
+	}
+	defer rows.Close()
+	var events []*store.Event
+	for rows.Next() {
+		var (
+			e                 store.Event
+			roles, categories string
+		)
+		if err := rows.Scan(&e.EventID, &e.Name, &e.MultiUp, &e.MultiDn, &roles, &categories,
+			&e.StartOn, &e.EndOn, &e.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch event")
+		}
+		e.SetScope(roles, categories)
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// EventAdd stores a new event, setting its EventID
+func (d *Driver) EventAdd(e *store.Event) error {
+	const q = `
+		INSERT INTO event (event_name, multi_up, multi_dn, role_ids, categories, start_on, end_on, created_on) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING event_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	roles, categories := e.Scope()
+	if err := d.db.QueryRow(c, q, e.Name, e.MultiUp, e.MultiDn, roles, categories, e.StartOn, e.EndOn,
+		e.CreatedOn).Scan(&e.EventID); err != nil {
+		return errors.Wrap(err, "Failed to add event")
+	}
+	return nil
+}
+
+// EventDelete permanently removes an event
+func (d *Driver) EventDelete(eventID uint32) error {
+	const q = `DELETE FROM event WHERE event_id = $1`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	commandTag, err := d.db.Exec(c, q, eventID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete event")
+	}
+	if commandTag.RowsAffected() == 0 {
+		return consts.ErrInvalidEvent
+	}
+	return nil
+}
+
+// Bans returns every ban, including those which have expired
+func (d *Driver) Bans() ([]*store.Ban, error) {
+	const q = `
+		SELECT ban_id, kind, ban_value, reason, expires, created_on 
+		FROM ban 
+		ORDER BY ban_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select bans")
+	}
+	defer rows.Close()
+	var bans []*store.Ban
+	for rows.Next() {
+		var (
+			b       store.Ban
+			expires *time.Time
+		)
+		if err := rows.Scan(&b.BanID, &b.Kind, &b.Value, &b.Reason, &expires, &b.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch ban")
+		}
+		if expires != nil {
+			b.Expires = *expires
+		}
+		bans = append(bans, &b)
+	}
+	return bans, rows.Err()
+}
+
+// BanAdd stores a new ban, setting its BanID
+func (d *Driver) BanAdd(b *store.Ban) error {
+	const q = `
+		INSERT INTO ban (kind, ban_value, reason, expires, created_on) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ban_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	err := d.db.QueryRow(c, q, b.Kind, b.Value, b.Reason, nullTime(b.Expires), b.CreatedOn).Scan(&b.BanID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to add ban")
+	}
+	return nil
+}
+
+// BanDelete permanently removes a ban
+func (d *Driver) BanDelete(banID uint32) error {
+	const q = `DELETE FROM ban WHERE ban_id = $1`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	commandTag, err := d.db.Exec(c, q, banID)
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete ban")
+	}
+	if commandTag.RowsAffected() == 0 {
+		return consts.ErrInvalidBan
+	}
+	return nil
+}
+
+// RetiredPasskeys returns every retired passkey, including those which have expired
+func (d *Driver) RetiredPasskeys() ([]*store.RetiredPasskey, error) {
+	const q = `
+		SELECT passkey, user_id, expires, created_on 
+		FROM retired_passkey`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select retired passkeys")
+	}
+	defer rows.Close()
+	var passkeys []*store.RetiredPasskey
+	for rows.Next() {
+		var p store.RetiredPasskey
+		if err := rows.Scan(&p.Passkey, &p.UserID, &p.Expires, &p.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch retired passkey")
+		}
+		passkeys = append(passkeys, &p)
+	}
+	return passkeys, rows.Err()
+}
+
+// RetiredPasskeyAdd stores a retired passkey, replacing any existing one with the same passkey
+func (d *Driver) RetiredPasskeyAdd(p *store.RetiredPasskey) error {
+	const q = `
+		INSERT INTO retired_passkey (passkey, user_id, expires, created_on) 
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (passkey) DO UPDATE 
+		SET user_id = excluded.user_id, expires = excluded.expires, created_on = excluded.created_on`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if _, err := d.db.Exec(c, q, p.Passkey, p.UserID, p.Expires, p.CreatedOn); err != nil {
+		return errors.Wrap(err, "Failed to add retired passkey")
+	}
+	return nil
+}
+
+// RetiredPasskeyDelete permanently removes a retired passkey
+func (d *Driver) RetiredPasskeyDelete(passkey string) error {
+	const q = `DELETE FROM retired_passkey WHERE passkey = $1`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if _, err := d.db.Exec(c, q, passkey); err != nil {
+		return errors.Wrap(err, "Failed to delete retired passkey")
+	}
+	return nil
+}
+
+// CheatReports returns the cheat reports of the user, oldest first. A userID of 0 returns the
+// reports of every user.
+func (d *Driver) CheatReports(userID uint32) ([]*store.CheatReport, error) {
+	const q = `
+		SELECT cheat_report_id, user_id, info_hash, addr_ip, client, rule, action, uploaded, speed, detail, created_on 
+		FROM cheat_report 
+		WHERE $1 = 0 OR user_id = $1 
+		ORDER BY cheat_report_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select cheat reports")
+	}
+	defer rows.Close()
+	var reports []*store.CheatReport
+	for rows.Next() {
+		var (
+			r  store.CheatReport
+			ih []byte
+		)
+		if err := rows.Scan(&r.ReportID, &r.UserID, &ih, &r.IP, &r.Client, &r.Rule, &r.Action, &r.Uploaded,
+			&r.Speed, &r.Detail, &r.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch cheat report")
+		}
+		if err := store.InfoHashFromBytes(&r.InfoHash, ih); err != nil {
+			return nil, errors.Wrap(err, "Invalid cheat report info_hash")
+		}
+		reports = append(reports, &r)
+	}
+	return reports, rows.Err()
+}
+
+// CheatReportAdd records a new cheat report, setting its ReportID
+func (d *Driver) CheatReportAdd(r *store.CheatReport) error {
+	const q = `
+		INSERT INTO cheat_report (user_id, info_hash, addr_ip, client, rule, action, uploaded, speed, detail, created_on) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING cheat_report_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if err := d.db.QueryRow(c, q, r.UserID, r.InfoHash.Bytes(), r.IP, r.Client, r.Rule, r.Action, r.Uploaded,
+		r.Speed, r.Detail, r.CreatedOn).Scan(&r.ReportID); err != nil {
+		return errors.Wrap(err, "Failed to add cheat report")
+	}
+	return nil
+}
+
+// TrapLeaks returns the trap leaks of the user, oldest first. A userID of 0 returns the leaks
+// of every user.
+func (d *Driver) TrapLeaks(userID uint32) ([]*store.TrapLeak, error) {
+	const q = `
+		SELECT trap_leak_id, info_hash, user_id, announcer_id, addr_ip, client, passkey_reset, created_on 
+		FROM trap_leak 
+		WHERE $1 = 0 OR user_id = $1 
+		ORDER BY trap_leak_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select trap leaks")
+	}
+	defer rows.Close()
+	var leaks []*store.TrapLeak
+	for rows.Next() {
+		var (
+			l  store.TrapLeak
+			ih []byte
+		)
+		if err := rows.Scan(&l.LeakID, &ih, &l.UserID, &l.AnnouncerID, &l.IP, &l.Client, &l.PasskeyReset,
+			&l.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch trap leak")
+		}
+		if err := store.InfoHashFromBytes(&l.InfoHash, ih); err != nil {
+			return nil, errors.Wrap(err, "Invalid trap leak info_hash")
+		}
+		leaks = append(leaks, &l)
+	}
+	return leaks, rows.Err()
+}
+
+// TrapLeakAdd records a new trap leak, setting its LeakID
+func (d *Driver) TrapLeakAdd(l *store.TrapLeak) error {
+	const q = `
+		INSERT INTO trap_leak (info_hash, user_id, announcer_id, addr_ip, client, passkey_reset, created_on) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING trap_leak_id`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if err := d.db.QueryRow(c, q, l.InfoHash.Bytes(), l.UserID, l.AnnouncerID, l.IP, l.Client,
+		l.PasskeyReset, l.CreatedOn).Scan(&l.LeakID); err != nil {
+		return errors.Wrap(err, "Failed to add trap leak")
+	}
+	return nil
+}
+
+// SpeedProfiles returns every historical speed profile
+func (d *Driver) SpeedProfiles() ([]*store.SpeedProfile, error) {
+	const q = `
+		SELECT kind, profile_key, samples, mean, speed_max, recent, updated_on 
+		FROM speed_profile`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select speed profiles")
+	}
+	defer rows.Close()
+	var profiles []*store.SpeedProfile
+	for rows.Next() {
+		var (
+			p      store.SpeedProfile
+			recent string
+		)
+		if err := rows.Scan(&p.Kind, &p.Key, &p.Samples, &p.Mean, &p.Max, &recent, &p.UpdatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch speed profile")
+		}
+		p.SetRecent(recent)
+		profiles = append(profiles, &p)
+	}
+	return profiles, rows.Err()
+}
+
+// SpeedProfileSync batch inserts or updates the speed profiles provided
+func (d *Driver) SpeedProfileSync(batch []*store.SpeedProfile) error {
+	const txName = "speedProfileSync"
+	const q = `
+		INSERT INTO speed_profile (kind, profile_key, samples, mean, speed_max, recent, updated_on) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (kind, profile_key) DO UPDATE 
+		SET 
+		    samples = excluded.samples,
+		    mean = excluded.mean,
+		    speed_max = excluded.speed_max,
+		    recent = excluded.recent,
+		    updated_on = excluded.updated_on
+`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(time.Second*10))
+	defer cancel()
+	tx, err := d.db.Begin(c)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.SpeedProfileSync Failed to being transaction")
+	}
+	defer func() { _ = tx.Rollback(c) }()
+	_, err = tx.Prepare(c, txName, q)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.SpeedProfileSync Failed to prepare statement")
+	}
+	for _, p := range batch {
+		if _, err := tx.Exec(c, txName, p.Kind, p.Key, p.Samples, p.Mean, p.Max, p.RecentString(),
+			p.UpdatedOn); err != nil {
+			return errors.Wrapf(err, "postgres.Store.SpeedProfileSync failed to Exec tx")
+		}
+	}
+	if err := tx.Commit(c); err != nil {
+		return errors.Wrapf(err, "postgres.Store.SpeedProfileSync failed to commit tx")
+	}
+	return nil
+}
+
+// SpeedProfileDelete permanently removes a speed profile
+func (d *Driver) SpeedProfileDelete(key store.SpeedProfileKey) error {
+	const q = `DELETE FROM speed_profile WHERE kind = $1 AND profile_key = $2`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	if _, err := d.db.Exec(c, q, key.Kind, key.Key); err != nil {
+		return errors.Wrap(err, "Failed to delete speed profile")
+	}
+	return nil
+}
+
+// Tokens returns every freeleech token
+func (d *Driver) Tokens() ([]*store.FreeleechToken, error) {
+	const q = `
+		SELECT user_id, info_hash, downloaded, max_downloaded, expires, created_on 
+		FROM user_token`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(30*time.Second))
+	defer cancel()
+	rows, err := d.db.Query(c, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to select freeleech tokens")
+	}
+	defer rows.Close()
+	var tokens []*store.FreeleechToken
+	for rows.Next() {
+		var (
+			t       store.FreeleechToken
+			ih      []byte
+			expires *time.Time
+		)
+		if err := rows.Scan(&t.UserID, &ih, &t.Downloaded, &t.MaxDownloaded, &expires, &t.CreatedOn); err != nil {
+			return nil, errors.Wrap(err, "Failed to fetch freeleech token")
+		}
+		if err := store.InfoHashFromBytes(&t.InfoHash, ih); err != nil {
+			return nil, errors.Wrap(err, "Invalid freeleech token info_hash")
+		}
+		if expires != nil {
+			t.Expires = *expires
+		}
+		tokens = append(tokens, &t)
+	}
+	return tokens, rows.Err()
+}
+
+// TokenSync batch inserts or updates the freeleech tokens provided
+func (d *Driver) TokenSync(batch []*store.FreeleechToken) error {
+	const txName = "tokenSync"
+	const q = `
+		INSERT INTO user_token (user_id, info_hash, downloaded, max_downloaded, expires, created_on) 
+		VALUES ($1, $2::bytea, $3, $4, $5, $6)
+		ON CONFLICT (user_id, info_hash) DO UPDATE 
+		SET 
+		    downloaded = excluded.downloaded,
+		    max_downloaded = excluded.max_downloaded,
+		    expires = excluded.expires,
+		    created_on = excluded.created_on
+`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(time.Second*10))
+	defer cancel()
+	tx, err := d.db.Begin(c)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.TokenSync Failed to being transaction")
+	}
+	defer func() { _ = tx.Rollback(c) }()
+	_, err = tx.Prepare(c, txName, q)
+	if err != nil {
+		return errors.Wrap(err, "postgres.Store.TokenSync Failed to prepare statement")
+	}
+	for _, t := range batch {
+		if _, err := tx.Exec(c, txName, t.UserID, t.InfoHash.Bytes(), t.Downloaded, t.MaxDownloaded,
+			nullTime(t.Expires), t.CreatedOn); err != nil {
+			return errors.Wrapf(err, "postgres.Store.TokenSync failed to Exec tx")
+		}
+	}
+	if err := tx.Commit(c); err != nil {
+		return errors.Wrapf(err, "postgres.Store.TokenSync failed to commit tx")
+	}
+	return nil
+}
+
+// TokenDelete permanently removes the freeleech token of the user for the torrent
+func (d *Driver) TokenDelete(userID uint32, ih store.InfoHash) error {
+	const q = `DELETE FROM user_token WHERE user_id = $1 AND info_hash = $2::bytea`
+	c, cancel := context.WithDeadline(d.ctx, time.Now().Add(5*time.Second))
+	defer cancel()
+	commandTag, err := d.db.Exec(c, q, userID, ih.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete freeleech token")
+	}
+	if commandTag.RowsAffected() == 0 {
+		return consts.ErrInvalidToken
+	}
+	return nil
+}
+
 type driverInit struct{}
 
 // New initialize a Store implementation using the postgres backing store
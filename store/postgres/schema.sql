@@ -19,6 +19,10 @@ create table torrent
     reason varchar(255) default '' not null,
     multi_up decimal(5,2) default 1.00 not null,
     multi_dn decimal(5,2) default 1.00 not null,
+    max_peers int default 0 not null,
+    size bigint default 0 not null,
+    category varchar(64) default '' not null,
+    trap_user_id int default 0 not null,
     announces int default 0 not null,
     seeders int default 0 not null,
     leechers int default 0 not null
@@ -33,11 +37,142 @@ create table users
     is_deleted bool default 'f' not null,
     downloaded bigint default 0 not null,
     uploaded bigint default 0 not null,
+    downloaded_real bigint default 0 not null,
+    uploaded_real bigint default 0 not null,
+    multi_up numeric(5,2) default -1 not null,
+    multi_down numeric(5,2) default -1 not null,
+    max_leeching int default 0 not null,
+    bonus_points numeric(12,2) default 0 not null,
     announces int default 0 not null,
     constraint user_passkey_uindex
         unique (passkey)
 );
 
+create table user_history
+(
+    user_id int not null,
+    info_hash bytea check (octet_length(info_hash) = 20) not null,
+    uploaded bigint default 0 not null,
+    downloaded bigint default 0 not null,
+    seed_time bigint default 0 not null,
+    leech_time bigint default 0 not null,
+    announce_first timestamptz not null,
+    announce_last timestamptz not null,
+    completed_on timestamptz,
+    is_active bool default 'f' not null,
+    hnr bool default 'f' not null,
+    hnr_on timestamptz,
+    primary key (user_id, info_hash)
+);
+
+create index user_history_info_hash_index on user_history (info_hash);
+
+create table user_token
+(
+    user_id int not null,
+    info_hash bytea check (octet_length(info_hash) = 20) not null,
+    downloaded bigint default 0 not null,
+    max_downloaded bigint default 0 not null,
+    expires timestamptz,
+    created_on timestamptz not null,
+    primary key (user_id, info_hash)
+);
+
+create table ratio_watch
+(
+    ratio_watch_id SERIAL
+        primary key,
+    user_id int not null,
+    state varchar(16) not null,
+    ratio double precision default 0 not null,
+    required double precision default 0 not null,
+    expires timestamptz,
+    created_on timestamptz not null
+);
+
+create index ratio_watch_user_id_index on ratio_watch (user_id);
+
+create table event
+(
+    event_id SERIAL
+        primary key,
+    event_name varchar(255) not null,
+    multi_up double precision default 1 not null,
+    multi_dn double precision default 1 not null,
+    role_ids varchar(255) default '' not null,
+    categories varchar(255) default '' not null,
+    start_on timestamptz not null,
+    end_on timestamptz not null,
+    created_on timestamptz not null
+);
+
+create table ban
+(
+    ban_id SERIAL
+        primary key,
+    kind varchar(8) not null,
+    ban_value varchar(64) not null,
+    reason varchar(255) default '' not null,
+    expires timestamptz,
+    created_on timestamptz not null
+);
+
+create table retired_passkey
+(
+    passkey varchar(20) not null
+        primary key,
+    user_id int not null,
+    expires timestamptz not null,
+    created_on timestamptz not null
+);
+
+create index retired_passkey_user_id_index on retired_passkey (user_id);
+
+create table cheat_report
+(
+    cheat_report_id SERIAL
+        primary key,
+    user_id int not null,
+    info_hash bytea check (octet_length(info_hash) = 20) not null,
+    addr_ip varchar(45) default '' not null,
+    client varchar(255) default '' not null,
+    rule varchar(32) not null,
+    action varchar(16) not null,
+    uploaded bigint default 0 not null,
+    speed bigint default 0 not null,
+    detail text default '' not null,
+    created_on timestamptz not null
+);
+
+create index cheat_report_user_id_index on cheat_report (user_id);
+
+create table trap_leak
+(
+    trap_leak_id SERIAL
+        primary key,
+    info_hash bytea check (octet_length(info_hash) = 20) not null,
+    user_id int not null,
+    announcer_id int default 0 not null,
+    addr_ip varchar(45) default '' not null,
+    client varchar(255) default '' not null,
+    passkey_reset bool default 'f' not null,
+    created_on timestamptz not null
+);
+
+create index trap_leak_user_id_index on trap_leak (user_id);
+
+create table speed_profile
+(
+    kind varchar(8) not null,
+    profile_key varchar(64) not null,
+    samples bigint default 0 not null,
+    mean double precision default 0 not null,
+    speed_max bigint default 0 not null,
+    recent text default '' not null,
+    updated_on timestamptz not null,
+    primary key (kind, profile_key)
+);
+
 create table peers
 (
     peer_id bytea  check (octet_length(peer_id) = 20) not null,
@@ -62,7 +197,13 @@ create table peers
 
 create table whitelist
 (
-    client_prefix varchar(10) not null
+    whitelist_id SERIAL
         primary key,
-    client_name varchar(20) not null
+    client_prefix varchar(10) default '' not null,
+    client_name varchar(20) not null,
+    client varchar(64) default '' not null,
+    min_version varchar(16) default '' not null,
+    max_version varchar(16) default '' not null,
+    action varchar(8) default 'allow' not null,
+    message varchar(255) default '' not null
 );
\ No newline at end of file
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"github.com/viciious/mika/util"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of speed profiles
+const (
+	ProfileUser = "user"
+	ProfileIP   = "ip"
+	ProfileASN  = "asn"
+)
+
+// SpeedProfileKey identifies a speed profile
+type SpeedProfileKey struct {
+	Kind string
+	Key  string
+}
+
+// SpeedProfile holds the historical upload speeds of a user, IP or ASN which are used to spot
+// announces which are far faster than normal for it
+type SpeedProfile struct {
+	// Kind is what the profile describes, user|ip|asn
+	Kind string `db:"kind" json:"kind"`
+	// Key is the user id, IP or ASN the profile describes
+	Key string `db:"profile_key" json:"profile_key"`
+	// Samples is the total number of speeds recorded
+	Samples uint64 `db:"samples" json:"samples"`
+	// Mean is the mean of every speed recorded in bytes/sec
+	Mean float64 `db:"mean" json:"mean"`
+	// Max is the fastest speed recorded in bytes/sec
+	Max uint64 `db:"speed_max" json:"speed_max"`
+	// Recent holds the most recent speeds recorded, oldest first, which the percentiles are
+	// calculated from
+	Recent    []uint64  `db:"-" json:"recent"`
+	UpdatedOn time.Time `db:"updated_on" json:"updated_on"`
+
+	// Keeps track of how often the values have been changes
+	Writes uint32 `db:"-" json:"-"`
+}
+
+// ProfileKey returns the kind and key of the profile
+func (p SpeedProfile) ProfileKey() SpeedProfileKey {
+	return SpeedProfileKey{Kind: p.Kind, Key: p.Key}
+}
+
+// Add records a new speed, keeping at most window recent speeds
+func (p *SpeedProfile) Add(speed uint64, max uint64, window int, now time.Time) {
+	p.Samples++
+	p.Mean += (float64(speed) - p.Mean) / float64(p.Samples)
+	p.Max = util.UMax64(p.Max, util.UMax64(speed, max))
+	p.Recent = append(p.Recent, speed)
+	if len(p.Recent) > window {
+		p.Recent = p.Recent[len(p.Recent)-window:]
+	}
+	p.UpdatedOn = now
+	p.Writes++
+}
+
+// Percentile returns the nearest rank percentile, 0.0-1.0, of the recent speeds
+func (p SpeedProfile) Percentile(pct float64) uint64 {
+	if len(p.Recent) == 0 {
+		return 0
+	}
+	sorted := append([]uint64{}, p.Recent...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(pct*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// RecentString returns the recent speeds as a comma separated list so they can be stored in
+// a single column
+func (p SpeedProfile) RecentString() string {
+	speeds := make([]string, len(p.Recent))
+	for i, s := range p.Recent {
+		speeds[i] = strconv.FormatUint(s, 10)
+	}
+	return strings.Join(speeds, ",")
+}
+
+// SetRecent parses the comma separated list produced by RecentString
+func (p *SpeedProfile) SetRecent(recent string) {
+	p.Recent = nil
+	for _, s := range strings.Split(recent, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			p.Recent = append(p.Recent, util.StringToUInt64(s, 0))
+		}
+	}
+}
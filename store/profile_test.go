@@ -0,0 +1,30 @@
+package store
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSpeedProfile(t *testing.T) {
+	var p SpeedProfile
+	now := time.Now()
+	for i := uint64(1); i <= 10; i++ {
+		p.Add(i*100, 0, 5, now)
+	}
+	require.Equal(t, uint64(10), p.Samples)
+	require.InDelta(t, 550.0, p.Mean, 0.001)
+	require.Equal(t, uint64(1000), p.Max)
+	require.Equal(t, []uint64{600, 700, 800, 900, 1000}, p.Recent)
+	require.Equal(t, uint64(800), p.Percentile(0.5))
+	require.Equal(t, uint64(1000), p.Percentile(0.95))
+	require.Equal(t, uint64(600), p.Percentile(0))
+	p.Add(10, 5000, 5, now)
+	require.Equal(t, uint64(5000), p.Max)
+
+	var parsed SpeedProfile
+	parsed.SetRecent(p.RecentString())
+	require.Equal(t, p.Recent, parsed.Recent)
+	parsed.SetRecent("")
+	require.Empty(t, parsed.Recent)
+}
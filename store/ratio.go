@@ -0,0 +1,33 @@
+package store
+
+import (
+	"time"
+)
+
+// RatioState is the ratio watch status of a user
+type RatioState string
+
+const (
+	// RatioOK users meet the ratio required by their role
+	RatioOK RatioState = "ok"
+	// RatioWatch users are below their required ratio and are warned until the grace
+	// period expires
+	RatioWatch RatioState = "watch"
+	// RatioRestricted users stayed below their required ratio past the grace period and
+	// cannot download until it is recovered
+	RatioRestricted RatioState = "restricted"
+)
+
+// RatioWatchEvent records a user moving between ratio watch states. The most recent event
+// of a user is their current state.
+type RatioWatchEvent struct {
+	UserID uint32     `db:"user_id" json:"user_id"`
+	State  RatioState `db:"state" json:"state"`
+	// Ratio is the users ratio at the time of the transition
+	Ratio float64 `db:"ratio" json:"ratio"`
+	// Required is the ratio the users role required at the time of the transition
+	Required float64 `db:"required" json:"required"`
+	// Expires is when a user on watch will be restricted if their ratio has not recovered
+	Expires   time.Time `db:"expires" json:"expires"`
+	CreatedOn time.Time `db:"created_on" json:"created_on"`
+}
@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/go-redis/redis/v7"
 	"github.com/viciious/mika/config"
@@ -9,6 +10,7 @@ import (
 	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -24,12 +26,21 @@ const (
 	prefixPeer      = "p"
 	prefixUser      = "u"
 	prefixRole      = "r"
+	prefixHistory   = "h"
+	prefixRatio     = "rw"
+	prefixEvent     = "ev"
+	prefixToken     = "ft"
+	prefixCheat     = "cr"
+	prefixTrap      = "tl"
+	prefixBan       = "ban"
+	prefixPasskey   = "rpk"
+	prefixProfile   = "sp"
 	prefixUserID    = "user_id_pk"
 	prefixRoleID    = "role_id_pk"
 )
 
-func whiteListKey(prefix string) string {
-	return fmt.Sprintf("%s%s", prefixWhitelist, prefix)
+func whiteListKey(whiteListID uint32) string {
+	return fmt.Sprintf("%s:%d", prefixWhitelist, whiteListID)
 }
 
 func torrentKey(t store.InfoHash) string {
@@ -56,6 +67,18 @@ func roleIDKey(roleID uint32) string {
 	return fmt.Sprintf("%s:%d", prefixRole, roleID)
 }
 
+func historyKey(userID uint32, ih store.InfoHash) string {
+	return fmt.Sprintf("%s:%d:%s", prefixHistory, userID, ih.String())
+}
+
+func tokenKey(userID uint32, ih store.InfoHash) string {
+	return fmt.Sprintf("%s:%d:%s", prefixToken, userID, ih.String())
+}
+
+func profileKey(kind string, key string) string {
+	return fmt.Sprintf("%s:%s:%s", prefixProfile, kind, key)
+}
+
 // Driver is the redis backed store.StoreI implementation
 type Driver struct {
 	client  *redis.Client
@@ -110,6 +133,9 @@ func resultToRole(r map[string]string, role *store.Role) {
 	role.MultiDown = util.StringToFloat64(r["multi_down"], 1.0)
 	role.DownloadEnabled = util.StringToBool(r["download_enabled"], true)
 	role.UploadEnabled = util.StringToBool(r["upload_enabled"], true)
+	role.MaxPeers = util.StringToUInt32(r["max_peers"], 0)
+	role.MaxHnR = util.StringToUInt32(r["max_hnr"], 0)
+	role.MaxLeeching = util.StringToUInt32(r["max_leeching"], 0)
 	role.CreatedOn = util.StringToTime(r["created_on"])
 	role.UpdatedOn = util.StringToTime(r["updated_on"])
 }
@@ -172,34 +198,21 @@ func (d *Driver) RoleDelete(roleID uint32) error {
 }
 
 // Sync batch updates the backing store with the new UserStats provided
-// TODO leverage cache layer so we can pipeline the updates w/o query first
 func (d *Driver) UserSync(b []*store.User) error {
-	//for passkey, stats := range b {
-	//	old, err := d.client.HGetAll(userKey(passkey)).Result()
-	//	if err != nil {
-	//		return errors.Wrap(err, "Failed to get user from redis")
-	//	}
-	//	var downloaded uint64
-	//	var uploaded uint64
-	//	var announces uint32
-	//	downloadedStr, found := old["downloaded"]
-	//	if found {
-	//		downloaded = util.StringToUInt64(downloadedStr, 0)
-	//	}
-	//	uploadedStr, found := old["uploaded"]
-	//	if found {
-	//		uploaded = util.StringToUInt64(uploadedStr, 0)
-	//	}
-	//	announcesStr, found := old["announces"]
-	//	if found {
-	//		announces = util.StringToUInt32(announcesStr, 0)
-	//	}
-	//	d.client.HSet(userKey(passkey), map[string]interface{}{
-	//		"downloaded": downloaded + stats.Downloaded,
-	//		"uploaded":   uploaded + stats.Uploaded,
-	//		"announces":  announces + stats.Announces,
-	//	})
-	//}
+	pipe := d.client.TxPipeline()
+	for _, u := range b {
+		pipe.HSet(userKey(u.Passkey), map[string]interface{}{
+			"downloaded":      u.Downloaded,
+			"uploaded":        u.Uploaded,
+			"downloaded_real": u.DownloadedReal,
+			"uploaded_real":   u.UploadedReal,
+			"announces":       u.Announces,
+			"bonus_points":    u.BonusPoints,
+		})
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return errors.Wrap(err, "Failed to sync users")
+	}
 	return nil
 }
 
@@ -211,6 +224,12 @@ func userMap(u *store.User) map[string]interface{} {
 		"is_deleted":       u.IsDeleted,
 		"downloaded":       u.Downloaded,
 		"uploaded":         u.Uploaded,
+		"downloaded_real":  u.DownloadedReal,
+		"uploaded_real":    u.UploadedReal,
+		"multi_up":         u.MultiUp,
+		"multi_down":       u.MultiDown,
+		"max_leeching":     u.MaxLeeching,
+		"bonus_points":     u.BonusPoints,
 		"announces":        u.Announces,
 		"passkey":          u.Passkey,
 		"download_enabled": u.DownloadEnabled,
@@ -229,6 +248,9 @@ func roleMap(r *store.Role) map[string]interface{} {
 		"multi_down":       r.MultiDown,
 		"download_enabled": r.DownloadEnabled,
 		"upload_enabled":   r.UploadEnabled,
+		"max_peers":        r.MaxPeers,
+		"max_hnr":          r.MaxHnR,
+		"max_leeching":     r.MaxLeeching,
 		"created_on":       r.CreatedOn.Format(time.RFC1123Z),
 		"updated_on":       r.UpdatedOn.Format(time.RFC1123Z),
 	}
@@ -269,6 +291,12 @@ func (d *Driver) UserGetByPasskey(passkey string) (*store.User, error) {
 	user.RemoteID = util.StringToUInt64(v["remote_id"], 0)
 	user.Downloaded = util.StringToUInt64(v["downloaded"], 0)
 	user.Uploaded = util.StringToUInt64(v["uploaded"], 0)
+	user.DownloadedReal = util.StringToUInt64(v["downloaded_real"], 0)
+	user.UploadedReal = util.StringToUInt64(v["uploaded_real"], 0)
+	user.MultiUp = util.StringToFloat64(v["multi_up"], -1)
+	user.MultiDown = util.StringToFloat64(v["multi_down"], -1)
+	user.MaxLeeching = util.StringToUInt32(v["max_leeching"], 0)
+	user.BonusPoints = util.StringToFloat64(v["bonus_points"], 0)
 	user.Announces = util.StringToUInt32(v["announces"], 0)
 	user.DownloadEnabled = util.StringToBool(v["download_enabled"], false)
 	user.IsDeleted = util.StringToBool(v["is_deleted"], false)
@@ -305,14 +333,20 @@ func (d *Driver) UserDelete(user *store.User) error {
 }
 
 func (d *Driver) UserSave(user *store.User) error {
-	exists, err := d.client.Exists(userKey(user.Passkey)).Result()
-	if err != nil || exists == 0 {
+	oldPasskey, err := d.client.Get(userIDKey(user.UserID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
 		return err
 	}
 	pipe := d.client.TxPipeline()
+	if oldPasskey != user.Passkey {
+		// Users are keyed by passkey so a changed passkey moves the user to a new key
+		pipe.Del(userKey(oldPasskey))
+	}
 	pipe.HSet(userKey(user.Passkey), userMap(user))
 	pipe.Set(userIDKey(user.UserID), user.Passkey, 0)
-	// TODO handle changing passkeys properly, this will not remove the old
 	if _, err := pipe.Exec(); err != nil {
 		return errors.Wrap(err, "Failed to add user to store")
 	}
@@ -354,9 +388,9 @@ func (d *Driver) Conn() interface{} {
 	return d.client
 }
 
-// WhiteListDelete removes a client from the global whitelist
+// WhiteListDelete removes a client rule from the global whitelist
 func (d *Driver) WhiteListDelete(client *store.WhiteListClient) error {
-	res, err := d.client.Del(whiteListKey(client.ClientPrefix)).Result()
+	res, err := d.client.Del(whiteListKey(client.WhiteListID)).Result()
 	if err != nil {
 		return errors.Wrap(err, "Failed to remove whitelisted client")
 	}
@@ -366,39 +400,485 @@ func (d *Driver) WhiteListDelete(client *store.WhiteListClient) error {
 	return nil
 }
 
-// WhiteListAdd will insert a new client prefix into the allowed clients list
+// WhiteListAdd will insert a new client rule into the global whitelist
 func (d *Driver) WhiteListAdd(client *store.WhiteListClient) error {
+	newID, err := d.client.Incr(prefixWhitelist + "_id_seq").Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get whitelist id")
+	}
+	client.WhiteListID = uint32(newID)
 	valueMap := map[string]interface{}{
+		"whitelist_id":  client.WhiteListID,
 		"client_prefix": client.ClientPrefix,
 		"client_name":   client.ClientName,
+		"client":        client.Client,
+		"min_version":   client.MinVersion,
+		"max_version":   client.MaxVersion,
+		"action":        client.Action,
+		"message":       client.Message,
 	}
-	err := d.client.HSet(whiteListKey(client.ClientPrefix), valueMap).Err()
+	err = d.client.HSet(whiteListKey(client.WhiteListID), valueMap).Err()
 	if err != nil {
-		return errors.Wrapf(err, "failed to add new whitelisted client prefix: %s", client.ClientPrefix)
+		return errors.Wrapf(err, "failed to add new whitelisted client: %s", client.ClientName)
 	}
 	return nil
 }
 
-// WhiteListGetAll fetches all known whitelisted clients
+// WhiteListGetAll fetches all known client whitelist rules
 func (d *Driver) WhiteListGetAll() ([]*store.WhiteListClient, error) {
-	prefixes, err := d.client.Keys(fmt.Sprintf("%s*", prefixWhitelist)).Result()
+	keys, err := d.client.Keys(prefixWhitelist + ":*").Result()
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to fetch whitelist keys")
 	}
 	var wl []*store.WhiteListClient
-	for _, prefix := range prefixes {
-		valueMap, err := d.client.HGetAll(whiteListKey(prefix)).Result()
+	for _, key := range keys {
+		valueMap, err := d.client.HGetAll(key).Result()
 		if err != nil {
-			return nil, errors.Wrapf(err, "Failed to fetch whitelist value for: %s", whiteListKey(prefix))
+			return nil, errors.Wrapf(err, "Failed to fetch whitelist value for: %s", key)
 		}
 		wl = append(wl, &store.WhiteListClient{
+			WhiteListID:  util.StringToUInt32(valueMap["whitelist_id"], 0),
 			ClientPrefix: valueMap["client_prefix"],
 			ClientName:   valueMap["client_name"],
+			Client:       valueMap["client"],
+			MinVersion:   valueMap["min_version"],
+			MaxVersion:   valueMap["max_version"],
+			Action:       valueMap["action"],
+			Message:      valueMap["message"],
 		})
 	}
+	sort.Slice(wl, func(i, j int) bool {
+		return wl[i].WhiteListID < wl[j].WhiteListID
+	})
 	return wl, nil
 }
 
+// optionalTime formats a time which may be unset, zero times are stored as an empty string
+func optionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC1123Z)
+}
+
+// parseOptionalTime is the inverse of optionalTime
+func parseOptionalTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	return util.StringToTime(s)
+}
+
+func historyMap(h *store.History) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":        h.UserID,
+		"info_hash":      h.InfoHash.String(),
+		"uploaded":       h.Uploaded,
+		"downloaded":     h.Downloaded,
+		"seed_time":      int64(h.SeedTime.Seconds()),
+		"leech_time":     int64(h.LeechTime.Seconds()),
+		"announce_first": h.AnnounceFirst.Format(time.RFC1123Z),
+		"announce_last":  h.AnnounceLast.Format(time.RFC1123Z),
+		"completed_on":   optionalTime(h.CompletedOn),
+		"is_active":      h.Active,
+		"hnr":            h.HnR,
+		"hnr_on":         optionalTime(h.HnROn),
+	}
+}
+
+// Histories returns the transfer history of every user on every torrent
+func (d *Driver) Histories() ([]*store.History, error) {
+	keys, err := d.client.Keys(prefixHistory + ":*").Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch history keys")
+	}
+	var hist []*store.History
+	for _, key := range keys {
+		v, err := d.client.HGetAll(key).Result()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to fetch history: %s", key)
+		}
+		var h store.History
+		if err := store.InfoHashFromHex(&h.InfoHash, v["info_hash"]); err != nil {
+			return nil, errors.Wrapf(err, "Invalid history info_hash: %s", key)
+		}
+		h.UserID = util.StringToUInt32(v["user_id"], 0)
+		h.Uploaded = util.StringToUInt64(v["uploaded"], 0)
+		h.Downloaded = util.StringToUInt64(v["downloaded"], 0)
+		h.SeedTime = time.Duration(util.StringToUInt64(v["seed_time"], 0)) * time.Second
+		h.LeechTime = time.Duration(util.StringToUInt64(v["leech_time"], 0)) * time.Second
+		h.AnnounceFirst = util.StringToTime(v["announce_first"])
+		h.AnnounceLast = util.StringToTime(v["announce_last"])
+		h.CompletedOn = parseOptionalTime(v["completed_on"])
+		h.Active = util.StringToBool(v["is_active"], false)
+		h.HnR = util.StringToBool(v["hnr"], false)
+		h.HnROn = parseOptionalTime(v["hnr_on"])
+		hist = append(hist, &h)
+	}
+	return hist, nil
+}
+
+// HistorySync batch inserts or updates the users transfer histories provided
+func (d *Driver) HistorySync(b []*store.History) error {
+	pipe := d.client.TxPipeline()
+	for _, h := range b {
+		pipe.HSet(historyKey(h.UserID, h.InfoHash), historyMap(h))
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return errors.Wrap(err, "Failed to sync history")
+	}
+	return nil
+}
+
+// RatioWatchEvents returns every recorded ratio watch transition, oldest first
+func (d *Driver) RatioWatchEvents() ([]*store.RatioWatchEvent, error) {
+	values, err := d.client.LRange(prefixRatio, 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch ratio watch events")
+	}
+	var events []*store.RatioWatchEvent
+	for _, v := range values {
+		var e store.RatioWatchEvent
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			return nil, errors.Wrap(err, "Invalid ratio watch event")
+		}
+		events = append(events, &e)
+	}
+	return events, nil
+}
+
+// RatioWatchAdd records a new ratio watch transition
+func (d *Driver) RatioWatchAdd(e *store.RatioWatchEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode ratio watch event")
+	}
+	if err := d.client.RPush(prefixRatio, b).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add ratio watch event")
+	}
+	return nil
+}
+
+// CheatReports returns the cheat reports of the user, oldest first. A userID of 0 returns the
+// reports of every user.
+func (d *Driver) CheatReports(userID uint32) ([]*store.CheatReport, error) {
+	values, err := d.client.LRange(prefixCheat, 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch cheat reports")
+	}
+	var reports []*store.CheatReport
+	for _, v := range values {
+		var r store.CheatReport
+		if err := json.Unmarshal([]byte(v), &r); err != nil {
+			return nil, errors.Wrap(err, "Invalid cheat report")
+		}
+		if userID == 0 || r.UserID == userID {
+			reports = append(reports, &r)
+		}
+	}
+	return reports, nil
+}
+
+// CheatReportAdd records a new cheat report, setting its ReportID
+func (d *Driver) CheatReportAdd(r *store.CheatReport) error {
+	newID, err := d.client.Incr(prefixCheat + "_id_seq").Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get cheat report id")
+	}
+	r.ReportID = uint32(newID)
+	b, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode cheat report")
+	}
+	if err := d.client.RPush(prefixCheat, b).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add cheat report")
+	}
+	return nil
+}
+
+// TrapLeaks returns the trap leaks of the user, oldest first. A userID of 0 returns the leaks
+// of every user.
+func (d *Driver) TrapLeaks(userID uint32) ([]*store.TrapLeak, error) {
+	values, err := d.client.LRange(prefixTrap, 0, -1).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch trap leaks")
+	}
+	var leaks []*store.TrapLeak
+	for _, v := range values {
+		var l store.TrapLeak
+		if err := json.Unmarshal([]byte(v), &l); err != nil {
+			return nil, errors.Wrap(err, "Invalid trap leak")
+		}
+		if userID == 0 || l.UserID == userID {
+			leaks = append(leaks, &l)
+		}
+	}
+	return leaks, nil
+}
+
+// TrapLeakAdd records a new trap leak, setting its LeakID
+func (d *Driver) TrapLeakAdd(l *store.TrapLeak) error {
+	newID, err := d.client.Incr(prefixTrap + "_id_seq").Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get trap leak id")
+	}
+	l.LeakID = uint32(newID)
+	b, err := json.Marshal(l)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode trap leak")
+	}
+	if err := d.client.RPush(prefixTrap, b).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add trap leak")
+	}
+	return nil
+}
+
+// Events returns every scheduled multiplier event
+func (d *Driver) Events() ([]*store.Event, error) {
+	values, err := d.client.HGetAll(prefixEvent).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch events")
+	}
+	var events []*store.Event
+	for _, v := range values {
+		var e store.Event
+		if err := json.Unmarshal([]byte(v), &e); err != nil {
+			return nil, errors.Wrap(err, "Invalid event")
+		}
+		events = append(events, &e)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].EventID < events[j].EventID
+	})
+	return events, nil
+}
+
+// EventAdd stores a new event, setting its EventID
+func (d *Driver) EventAdd(e *store.Event) error {
+	newID, err := d.client.Incr(prefixEvent + "_id_seq").Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get event id")
+	}
+	e.EventID = uint32(newID)
+	b, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode event")
+	}
+	if err := d.client.HSet(prefixEvent, strconv.FormatUint(uint64(e.EventID), 10), b).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add event")
+	}
+	return nil
+}
+
+// EventDelete permanently removes an event
+func (d *Driver) EventDelete(eventID uint32) error {
+	n, err := d.client.HDel(prefixEvent, strconv.FormatUint(uint64(eventID), 10)).Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete event")
+	}
+	if n == 0 {
+		return consts.ErrInvalidEvent
+	}
+	return nil
+}
+
+// Bans returns every ban
+func (d *Driver) Bans() ([]*store.Ban, error) {
+	values, err := d.client.HGetAll(prefixBan).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch bans")
+	}
+	var bans []*store.Ban
+	for _, v := range values {
+		var b store.Ban
+		if err := json.Unmarshal([]byte(v), &b); err != nil {
+			return nil, errors.Wrap(err, "Invalid ban")
+		}
+		bans = append(bans, &b)
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].BanID < bans[j].BanID
+	})
+	return bans, nil
+}
+
+// BanAdd stores a new ban, setting its BanID
+func (d *Driver) BanAdd(b *store.Ban) error {
+	newID, err := d.client.Incr(prefixBan + "_id_seq").Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get ban id")
+	}
+	b.BanID = uint32(newID)
+	v, err := json.Marshal(b)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode ban")
+	}
+	if err := d.client.HSet(prefixBan, strconv.FormatUint(uint64(b.BanID), 10), v).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add ban")
+	}
+	return nil
+}
+
+// BanDelete permanently removes a ban
+func (d *Driver) BanDelete(banID uint32) error {
+	n, err := d.client.HDel(prefixBan, strconv.FormatUint(uint64(banID), 10)).Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete ban")
+	}
+	if n == 0 {
+		return consts.ErrInvalidBan
+	}
+	return nil
+}
+
+// RetiredPasskeys returns every retired passkey
+func (d *Driver) RetiredPasskeys() ([]*store.RetiredPasskey, error) {
+	values, err := d.client.HGetAll(prefixPasskey).Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch retired passkeys")
+	}
+	var passkeys []*store.RetiredPasskey
+	for _, v := range values {
+		var p store.RetiredPasskey
+		if err := json.Unmarshal([]byte(v), &p); err != nil {
+			return nil, errors.Wrap(err, "Invalid retired passkey")
+		}
+		passkeys = append(passkeys, &p)
+	}
+	return passkeys, nil
+}
+
+// RetiredPasskeyAdd stores a retired passkey, replacing any existing one with the same passkey
+func (d *Driver) RetiredPasskeyAdd(p *store.RetiredPasskey) error {
+	v, err := json.Marshal(p)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode retired passkey")
+	}
+	if err := d.client.HSet(prefixPasskey, p.Passkey, v).Err(); err != nil {
+		return errors.Wrap(err, "Failed to add retired passkey")
+	}
+	return nil
+}
+
+// RetiredPasskeyDelete permanently removes a retired passkey
+func (d *Driver) RetiredPasskeyDelete(passkey string) error {
+	if err := d.client.HDel(prefixPasskey, passkey).Err(); err != nil {
+		return errors.Wrap(err, "Failed to delete retired passkey")
+	}
+	return nil
+}
+
+// Tokens returns every freeleech token
+func (d *Driver) Tokens() ([]*store.FreeleechToken, error) {
+	keys, err := d.client.Keys(prefixToken + ":*").Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch freeleech token keys")
+	}
+	var tokens []*store.FreeleechToken
+	for _, key := range keys {
+		v, err := d.client.HGetAll(key).Result()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to fetch freeleech token: %s", key)
+		}
+		var t store.FreeleechToken
+		if err := store.InfoHashFromHex(&t.InfoHash, v["info_hash"]); err != nil {
+			return nil, errors.Wrapf(err, "Invalid freeleech token info_hash: %s", key)
+		}
+		t.UserID = util.StringToUInt32(v["user_id"], 0)
+		t.Downloaded = util.StringToUInt64(v["downloaded"], 0)
+		t.MaxDownloaded = util.StringToUInt64(v["max_downloaded"], 0)
+		t.Expires = parseOptionalTime(v["expires"])
+		t.CreatedOn = util.StringToTime(v["created_on"])
+		tokens = append(tokens, &t)
+	}
+	return tokens, nil
+}
+
+// TokenSync batch inserts or updates the freeleech tokens provided
+func (d *Driver) TokenSync(b []*store.FreeleechToken) error {
+	pipe := d.client.TxPipeline()
+	for _, t := range b {
+		pipe.HSet(tokenKey(t.UserID, t.InfoHash), map[string]interface{}{
+			"user_id":        t.UserID,
+			"info_hash":      t.InfoHash.String(),
+			"downloaded":     t.Downloaded,
+			"max_downloaded": t.MaxDownloaded,
+			"expires":        optionalTime(t.Expires),
+			"created_on":     t.CreatedOn.Format(time.RFC1123Z),
+		})
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return errors.Wrap(err, "Failed to sync freeleech tokens")
+	}
+	return nil
+}
+
+// TokenDelete permanently removes the freeleech token of the user for the torrent
+func (d *Driver) TokenDelete(userID uint32, ih store.InfoHash) error {
+	n, err := d.client.Del(tokenKey(userID, ih)).Result()
+	if err != nil {
+		return errors.Wrap(err, "Failed to delete freeleech token")
+	}
+	if n == 0 {
+		return consts.ErrInvalidToken
+	}
+	return nil
+}
+
+// SpeedProfiles returns every historical speed profile
+func (d *Driver) SpeedProfiles() ([]*store.SpeedProfile, error) {
+	keys, err := d.client.Keys(prefixProfile + ":*").Result()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to fetch speed profile keys")
+	}
+	var profiles []*store.SpeedProfile
+	for _, key := range keys {
+		v, err := d.client.HGetAll(key).Result()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to fetch speed profile: %s", key)
+		}
+		p := store.SpeedProfile{
+			Kind:      v["kind"],
+			Key:       v["profile_key"],
+			Samples:   util.StringToUInt64(v["samples"], 0),
+			Mean:      util.StringToFloat64(v["mean"], 0),
+			Max:       util.StringToUInt64(v["speed_max"], 0),
+			UpdatedOn: util.StringToTime(v["updated_on"]),
+		}
+		p.SetRecent(v["recent"])
+		profiles = append(profiles, &p)
+	}
+	return profiles, nil
+}
+
+// SpeedProfileSync batch inserts or updates the speed profiles provided
+func (d *Driver) SpeedProfileSync(b []*store.SpeedProfile) error {
+	pipe := d.client.TxPipeline()
+	for _, p := range b {
+		pipe.HSet(profileKey(p.Kind, p.Key), map[string]interface{}{
+			"kind":        p.Kind,
+			"profile_key": p.Key,
+			"samples":     p.Samples,
+			"mean":        p.Mean,
+			"speed_max":   p.Max,
+			"recent":      p.RecentString(),
+			"updated_on":  p.UpdatedOn.Format(time.RFC1123Z),
+		})
+	}
+	if _, err := pipe.Exec(); err != nil {
+		return errors.Wrap(err, "Failed to sync speed profiles")
+	}
+	return nil
+}
+
+// SpeedProfileDelete permanently removes a speed profile
+func (d *Driver) SpeedProfileDelete(key store.SpeedProfileKey) error {
+	if err := d.client.Del(profileKey(key.Kind, key.Key)).Err(); err != nil {
+		return errors.Wrap(err, "Failed to delete speed profile")
+	}
+	return nil
+}
+
 func torrentMap(t *store.Torrent) map[string]interface{} {
 	return map[string]interface{}{
 		"total_completed":  t.Snatches,
@@ -407,6 +887,10 @@ func torrentMap(t *store.Torrent) map[string]interface{} {
 		"reason":           t.Reason,
 		"multi_up":         t.MultiUp,
 		"multi_dn":         t.MultiDn,
+		"max_peers":        t.MaxPeers,
+		"size":             t.Size,
+		"category":         t.Category,
+		"trap_user_id":     t.TrapUserID,
 		"info_hash":        t.InfoHash.String(),
 		"is_deleted":       t.IsDeleted,
 		"is_enabled":       t.IsEnabled,
@@ -468,6 +952,10 @@ func (d *Driver) TorrentGet(hash store.InfoHash, deletedOk bool) (*store.Torrent
 	t.Reason = v["reason"]
 	t.MultiUp = util.StringToFloat64(v["multi_up"], 1.0)
 	t.MultiDn = util.StringToFloat64(v["multi_dn"], 1.0)
+	t.MaxPeers = util.StringToUInt32(v["max_peers"], 0)
+	t.Size = util.StringToUInt64(v["size"], 0)
+	t.Category = v["category"]
+	t.TrapUserID = util.StringToUInt32(v["trap_user_id"], 0)
 	t.Announces = util.StringToUInt64(v["announces"], 0)
 	t.Seeders = util.StringToUInt32(v["seeders"], 0)
 	t.Leechers = util.StringToUInt32(v["leechers"], 0)
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/geo"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// PeerSelector defines a strategy used to choose which members of a swarm are returned
+// to an announcing peer.
+//
+// The seeders and leechers passed in have already been shuffled and had any stale peers, and
+// the requesting peer itself, removed. Implementations only need to order them by preference
+// and pick n of them, making sure enough seeders are included to keep the torrent available.
+type PeerSelector interface {
+	Select(requester *Peer, seeders []*Peer, leechers []*Peer, n int) []*Peer
+}
+
+// NewPeerSelector returns the PeerSelector registered under the name provided
+//
+// random|geo|completion|speed
+//
+// minSeederShare is the minimum fraction of the returned peers which must be seeders when
+// enough are available. preferFast controls the direction of the speed strategy.
+func NewPeerSelector(name string, minSeederShare float64, preferFast bool) (PeerSelector, error) {
+	switch name {
+	case "", "random":
+		return randomSelector{minSeederShare: minSeederShare}, nil
+	case "geo":
+		return geoSelector{minSeederShare: minSeederShare}, nil
+	case "completion":
+		return completionSelector{minSeederShare: minSeederShare}, nil
+	case "speed":
+		return speedSelector{minSeederShare: minSeederShare, preferFast: preferFast}, nil
+	default:
+		return nil, errors.Wrapf(consts.ErrInvalidConfig, "Unknown peer selector: %s", name)
+	}
+}
+
+// randomSelector keeps the order of the shuffled swarm
+type randomSelector struct {
+	minSeederShare float64
+}
+
+// Select implements PeerSelector
+func (s randomSelector) Select(_ *Peer, seeders []*Peer, leechers []*Peer, n int) []*Peer {
+	return pickPeers(seeders, leechers, n, s.minSeederShare)
+}
+
+// geoSelector favours the peers closest to the requesting peer. This is the only strategy
+// which is also applied to the leechers.
+type geoSelector struct {
+	minSeederShare float64
+}
+
+// Select implements PeerSelector
+func (s geoSelector) Select(requester *Peer, seeders []*Peer, leechers []*Peer, n int) []*Peer {
+	distance := func(p *Peer) float64 {
+		return geo.Distance(requester.Location, p.Location)
+	}
+	rankPeers(seeders, distance)
+	rankPeers(leechers, distance)
+	return pickPeers(seeders, leechers, n, s.minSeederShare)
+}
+
+// completionSelector favours the seeders which have uploaded the least during their current
+// session so users with slower connections get a chance to build ratio
+type completionSelector struct {
+	minSeederShare float64
+}
+
+// Select implements PeerSelector
+func (s completionSelector) Select(_ *Peer, seeders []*Peer, leechers []*Peer, n int) []*Peer {
+	rankPeers(seeders, func(p *Peer) float64 {
+		return float64(atomic.LoadUint64(&p.Uploaded))
+	})
+	return pickPeers(seeders, leechers, n, s.minSeederShare)
+}
+
+// speedSelector favours either the slowest or fastest peers. Seeders are ranked by upload
+// speed and leechers by download speed.
+type speedSelector struct {
+	minSeederShare float64
+	preferFast     bool
+}
+
+// Select implements PeerSelector
+func (s speedSelector) Select(_ *Peer, seeders []*Peer, leechers []*Peer, n int) []*Peer {
+	sign := 1.0
+	if s.preferFast {
+		sign = -1.0
+	}
+	rankPeers(seeders, func(p *Peer) float64 {
+		return sign * float64(atomic.LoadUint32(&p.SpeedUP))
+	})
+	rankPeers(leechers, func(p *Peer) float64 {
+		return sign * float64(atomic.LoadUint32(&p.SpeedDN))
+	})
+	return pickPeers(seeders, leechers, n, s.minSeederShare)
+}
+
+// rankedPeers sorts a set of peers by a precomputed score, lowest first
+type rankedPeers struct {
+	peers  []*Peer
+	scores []float64
+}
+
+func (r rankedPeers) Len() int           { return len(r.peers) }
+func (r rankedPeers) Less(i, j int) bool { return r.scores[i] < r.scores[j] }
+func (r rankedPeers) Swap(i, j int) {
+	r.peers[i], r.peers[j] = r.peers[j], r.peers[i]
+	r.scores[i], r.scores[j] = r.scores[j], r.scores[i]
+}
+
+// rankPeers orders the peers by score, lowest first. The sort is stable so peers with equal
+// scores keep their shuffled order and the same peers are not always returned.
+func rankPeers(peers []*Peer, score func(p *Peer) float64) {
+	r := rankedPeers{peers: peers, scores: make([]float64, len(peers))}
+	for i, p := range peers {
+		r.scores[i] = score(p)
+	}
+	sort.Stable(r)
+}
+
+// pickPeers takes up to n peers from the front of the seeders and leechers. Seeders make up
+// at least their share of the swarm, or minSeederShare if higher, and always at least one
+// when any are available. Slots that cannot be filled by leechers are given to seeders.
+func pickPeers(seeders []*Peer, leechers []*Peer, n int, minSeederShare float64) []*Peer {
+	total := len(seeders) + len(leechers)
+	n = util.Min(n, total)
+	if n <= 0 {
+		return nil
+	}
+	numSeeders := util.Max(int(math.Ceil(float64(n)*minSeederShare)),
+		int(math.Round(float64(n*len(seeders))/float64(total))))
+	if len(seeders) > 0 {
+		numSeeders = util.Max(numSeeders, 1)
+	}
+	numSeeders = util.Min(util.Min(numSeeders, n), len(seeders))
+	numSeeders = util.Max(numSeeders, n-len(leechers))
+	peers := make([]*Peer, 0, n)
+	peers = append(peers, seeders[:numSeeders]...)
+	return append(peers, leechers[:n-numSeeders]...)
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"github.com/viciious/mika/geo"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newTestSwarm(seeders int, leechers int) (*Swarm, []*Peer) {
+	swarm := NewSwarm()
+	var peers []*Peer
+	for i := 0; i < seeders+leechers; i++ {
+		p := GenerateTestPeer()
+		if i >= seeders {
+			p.Left = 1000
+		}
+		swarm.Add(p)
+		peers = append(peers, p)
+	}
+	return swarm, peers
+}
+
+func countSeeders(peers []*Peer) int {
+	n := 0
+	for _, p := range peers {
+		if p.IsSeeder() {
+			n++
+		}
+	}
+	return n
+}
+
+func TestNewPeerSelector(t *testing.T) {
+	for _, name := range []string{"", "random", "geo", "completion", "speed"} {
+		_, err := NewPeerSelector(name, 0.2, false)
+		require.NoError(t, err, name)
+	}
+	_, err := NewPeerSelector("nope", 0.2, false)
+	require.Error(t, err)
+}
+
+func TestSwarm_Select(t *testing.T) {
+	sel, _ := NewPeerSelector("random", 0.25, false)
+	swarm, peers := newTestSwarm(5, 50)
+	requester := peers[10]
+	stale := peers[11]
+	stale.AnnounceLast = time.Now().Add(-time.Hour)
+	for i := 0; i < 10; i++ {
+		selected := sel.Select(requester, nil, nil, 10)
+		require.Empty(t, selected)
+		selected = swarm.Select(sel, PeerQuery{Requester: requester, Limit: 20, TTL: time.Minute})
+		require.Len(t, selected, 20)
+		require.Equal(t, 5, countSeeders(selected), "minimum seeder share not met")
+		for _, p := range selected {
+			require.NotEqual(t, requester.PeerID, p.PeerID)
+			require.NotEqual(t, stale.PeerID, p.PeerID)
+		}
+	}
+	// Not enough leechers to fill the response
+	swarm, peers = newTestSwarm(20, 2)
+	selected := swarm.Select(sel, PeerQuery{Requester: peers[0], Limit: 10})
+	require.Len(t, selected, 10)
+	require.Equal(t, 9, countSeeders(selected))
+	// Seeders only get leechers
+	selected = swarm.Select(sel, PeerQuery{Requester: peers[0], Limit: 10, ExcludeSeeders: true})
+	require.Len(t, selected, 2)
+	require.Equal(t, 0, countSeeders(selected))
+	require.Empty(t, swarm.Select(sel, PeerQuery{Requester: peers[0], Limit: 0}))
+	selected = swarm.Select(sel, PeerQuery{Requester: peers[0], Limit: 10, Exclude: func(p *Peer) bool {
+		return p.IsSeeder()
+	}})
+	require.Len(t, selected, 2)
+	require.Equal(t, 0, countSeeders(selected))
+	// Always at least one seeder
+	sel, _ = NewPeerSelector("random", 0, false)
+	swarm, peers = newTestSwarm(1, 100)
+	require.Equal(t, 1, countSeeders(swarm.Select(sel, PeerQuery{Requester: peers[50], Limit: 10})))
+}
+
+func TestPeerSelector_Bias(t *testing.T) {
+	_, peers := newTestSwarm(3, 0)
+	peers[0].Location = geo.LatLong{Latitude: 10, Longitude: 10}
+	peers[1].Location = geo.LatLong{Latitude: 50, Longitude: 50}
+	peers[2].Location = geo.LatLong{Latitude: 1, Longitude: 1}
+	peers[0].SpeedUP, peers[1].SpeedUP, peers[2].SpeedUP = 500, 1000, 10
+	peers[0].Uploaded, peers[1].Uploaded, peers[2].Uploaded = 5000, 0, 10000
+	requester := &Peer{Location: geo.LatLong{Latitude: 0, Longitude: 0}}
+	order := func(name string, fast bool) []*Peer {
+		sel, err := NewPeerSelector(name, 0, fast)
+		require.NoError(t, err)
+		return sel.Select(requester, append([]*Peer{}, peers...), nil, 3)
+	}
+	require.Equal(t, []*Peer{peers[2], peers[0], peers[1]}, order("geo", false))
+	require.Equal(t, []*Peer{peers[1], peers[0], peers[2]}, order("completion", false))
+	require.Equal(t, []*Peer{peers[2], peers[0], peers[1]}, order("speed", false))
+	require.Equal(t, []*Peer{peers[1], peers[0], peers[2]}, order("speed", true))
+}
+
+func TestPeer_UpdateSpeed(t *testing.T) {
+	p := GenerateTestPeer()
+	p.UpdateSpeed(10000, 5000, 10*time.Second, 0)
+	require.Equal(t, uint32(1000), p.SpeedUP)
+	require.Equal(t, uint32(500), p.SpeedDN)
+	p.UpdateSpeed(0, 0, 10*time.Second, 0)
+	require.Equal(t, uint32(0), p.SpeedUP)
+	require.Equal(t, uint32(1000), p.SpeedUPMax)
+	require.Equal(t, uint32(500), p.SpeedDNMax)
+	// Announces faster than the minimum interval are measured over the minimum interval
+	p.UpdateSpeed(8000, 0, time.Second, 10*time.Second)
+	require.Equal(t, uint32(800), p.SpeedUP)
+	require.Equal(t, uint32(1000), p.SpeedUPMax)
+	p.UpdateSpeed(500, 0, 0, 0)
+	require.Equal(t, uint32(500), p.SpeedUP)
+}
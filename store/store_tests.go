@@ -35,6 +35,8 @@ func GenerateTestUser() User {
 		Downloaded:      1000,
 		Uploaded:        2000,
 		Announces:       500,
+		MultiUp:         -1,
+		MultiDown:       -1,
 	}
 }
 
@@ -104,6 +106,22 @@ func TestStore(t *testing.T, s Store) {
 	require.NoError(t, s.WhiteListDelete(wlClients[0]))
 	clientsUpdated, _ := s.WhiteListGetAll()
 	require.Equal(t, len(wlClients)-1, len(clientsUpdated))
+	require.Equal(t, consts.ErrInvalidClient, s.WhiteListDelete(wlClients[0]))
+	require.NoError(t, s.WhiteListDelete(wlClients[1]))
+	wlRules := []*WhiteListClient{
+		{ClientPrefix: "-qB", ClientName: "qBittorrent 4.3+", Client: "qBittorrent", MinVersion: "4.3",
+			Action: WhiteListAllow},
+		{ClientName: "Broken qBittorrent", Client: "qBittorrent", MinVersion: "4.4.0", MaxVersion: "4.4.0",
+			Action: WhiteListDeny, Message: "Please upgrade"},
+	}
+	for _, c := range wlRules {
+		require.NoError(t, s.WhiteListAdd(c))
+	}
+	require.NotEqual(t, wlRules[0].WhiteListID, wlRules[1].WhiteListID)
+	rules, _ := s.WhiteListGetAll()
+	require.Len(t, rules, 2)
+	require.Equal(t, *wlRules[0], *rules[0])
+	require.Equal(t, *wlRules[1], *rules[1])
 
 	roles := []*Role{
 		{
@@ -197,6 +215,197 @@ func TestStore(t *testing.T, s Store) {
 	require.Equal(t, newUser.Downloaded, fetchedNewUser.Downloaded)
 	require.Equal(t, newUser.Uploaded, fetchedNewUser.Uploaded)
 	require.Equal(t, newUser.Announces, fetchedNewUser.Announces)
+
+	now := time.Now().Truncate(time.Second)
+	hist := &History{
+		UserID:        newUser.UserID,
+		InfoHash:      torrentA.InfoHash,
+		Uploaded:      5000,
+		Downloaded:    1000,
+		SeedTime:      time.Hour,
+		LeechTime:     time.Minute,
+		AnnounceFirst: now.Add(-2 * time.Hour),
+		AnnounceLast:  now,
+		CompletedOn:   now.Add(-time.Hour),
+		Active:        true,
+	}
+	require.NoError(t, s.HistorySync([]*History{hist}))
+	hist.Uploaded = 10000
+	hist.Active = false
+	require.NoError(t, s.HistorySync([]*History{hist}))
+	fetchedHistory, err := s.Histories()
+	require.NoError(t, err)
+	require.Len(t, fetchedHistory, 1)
+	require.Equal(t, hist.Key(), fetchedHistory[0].Key())
+	require.Equal(t, hist.Uploaded, fetchedHistory[0].Uploaded)
+	require.Equal(t, hist.SeedTime, fetchedHistory[0].SeedTime)
+	require.Equal(t, hist.LeechTime, fetchedHistory[0].LeechTime)
+	require.True(t, hist.CompletedOn.Equal(fetchedHistory[0].CompletedOn))
+	require.True(t, fetchedHistory[0].HnROn.IsZero())
+	require.False(t, fetchedHistory[0].Active)
+
+	watch := &RatioWatchEvent{UserID: newUser.UserID, State: RatioWatch, Ratio: 0.2, Required: 0.5,
+		Expires: now.Add(time.Hour), CreatedOn: now}
+	require.NoError(t, s.RatioWatchAdd(watch))
+	require.NoError(t, s.RatioWatchAdd(&RatioWatchEvent{UserID: newUser.UserID, State: RatioOK,
+		Ratio: 0.6, Required: 0.5, CreatedOn: now}))
+	events, err := s.RatioWatchEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, RatioWatch, events[0].State)
+	require.Equal(t, watch.Required, events[0].Required)
+	require.True(t, watch.Expires.Equal(events[0].Expires))
+	require.Equal(t, RatioOK, events[1].State)
+	require.True(t, events[1].Expires.IsZero())
+
+	// Events
+	freeleech := &Event{Name: "Freeleech weekend", MultiUp: 1, MultiDn: 0, RoleIDs: []uint32{1, 2},
+		Categories: []string{"movies"}, StartOn: now, EndOn: now.Add(48 * time.Hour), CreatedOn: now}
+	require.NoError(t, s.EventAdd(freeleech))
+	require.NotZero(t, freeleech.EventID)
+	require.NoError(t, s.EventAdd(&Event{Name: "Double up", MultiUp: 2, MultiDn: 1, StartOn: now,
+		EndOn: now.Add(time.Hour), CreatedOn: now}))
+	evs, err := s.Events()
+	require.NoError(t, err)
+	require.Len(t, evs, 2)
+	require.Equal(t, freeleech.EventID, evs[0].EventID)
+	require.Equal(t, freeleech.RoleIDs, evs[0].RoleIDs)
+	require.Equal(t, freeleech.Categories, evs[0].Categories)
+	require.True(t, freeleech.EndOn.Equal(evs[0].EndOn))
+	require.Empty(t, evs[1].RoleIDs)
+	require.NoError(t, s.EventDelete(freeleech.EventID))
+	require.Equal(t, consts.ErrInvalidEvent, s.EventDelete(freeleech.EventID))
+	evs, err = s.Events()
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+
+	// Freeleech tokens
+	token := &FreeleechToken{UserID: newUser.UserID, InfoHash: torrentA.InfoHash, MaxDownloaded: 1000,
+		Expires: now.Add(time.Hour), CreatedOn: now}
+	require.NoError(t, s.TokenSync([]*FreeleechToken{token}))
+	token.Downloaded = 500
+	require.NoError(t, s.TokenSync([]*FreeleechToken{token}))
+	tokens, err := s.Tokens()
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, token.Key(), tokens[0].Key())
+	require.Equal(t, uint64(500), tokens[0].Downloaded)
+	require.Equal(t, uint64(1000), tokens[0].MaxDownloaded)
+	require.True(t, token.Expires.Equal(tokens[0].Expires))
+	require.NoError(t, s.TokenDelete(token.UserID, token.InfoHash))
+	require.Equal(t, consts.ErrInvalidToken, s.TokenDelete(token.UserID, token.InfoHash))
+	tokens, err = s.Tokens()
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+
+	// Cheat reports
+	report := &CheatReport{UserID: newUser.UserID, InfoHash: torrentA.InfoHash, IP: "12.34.56.78",
+		Client: "qBittorrent 4.3.0", Rule: "max_speed", Action: "drop", Uploaded: 5000, Speed: 500,
+		Detail: "500 B/s exceeds the maximum of 100 B/s", CreatedOn: now}
+	require.NoError(t, s.CheatReportAdd(report))
+	require.NotZero(t, report.ReportID)
+	reports, err := s.CheatReports(newUser.UserID)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, report.ReportID, reports[0].ReportID)
+	require.Equal(t, report.InfoHash, reports[0].InfoHash)
+	require.Equal(t, report.Rule, reports[0].Rule)
+	require.Equal(t, report.Uploaded, reports[0].Uploaded)
+	require.Equal(t, report.Detail, reports[0].Detail)
+	reports, err = s.CheatReports(newUser.UserID + 1)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+	reports, err = s.CheatReports(0)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	// Trap leaks
+	leak := &TrapLeak{InfoHash: torrentA.InfoHash, UserID: newUser.UserID, AnnouncerID: 0, IP: "12.34.56.78",
+		Client: "qBittorrent 4.3.0", PasskeyReset: true, CreatedOn: now}
+	require.NoError(t, s.TrapLeakAdd(leak))
+	require.NotZero(t, leak.LeakID)
+	leaks, err := s.TrapLeaks(newUser.UserID)
+	require.NoError(t, err)
+	require.Len(t, leaks, 1)
+	require.Equal(t, leak.LeakID, leaks[0].LeakID)
+	require.Equal(t, leak.InfoHash, leaks[0].InfoHash)
+	require.Equal(t, leak.IP, leaks[0].IP)
+	require.Equal(t, leak.Client, leaks[0].Client)
+	require.True(t, leaks[0].PasskeyReset)
+	leaks, err = s.TrapLeaks(newUser.UserID + 1)
+	require.NoError(t, err)
+	require.Empty(t, leaks)
+	leaks, err = s.TrapLeaks(0)
+	require.NoError(t, err)
+	require.Len(t, leaks, 1)
+
+	// Bans
+	banA := &Ban{Kind: BanCIDR, Value: "10.0.0.0/8", Reason: "Abuse", CreatedOn: now}
+	banB := &Ban{Kind: BanASN, Value: "64496", Expires: now.Add(time.Hour), CreatedOn: now}
+	require.NoError(t, s.BanAdd(banA))
+	require.NoError(t, s.BanAdd(banB))
+	require.NotZero(t, banA.BanID)
+	require.NotEqual(t, banA.BanID, banB.BanID)
+	bans, err := s.Bans()
+	require.NoError(t, err)
+	require.Len(t, bans, 2)
+	require.Equal(t, banA.Value, bans[0].Value)
+	require.Equal(t, banA.Reason, bans[0].Reason)
+	require.True(t, bans[0].Expires.IsZero())
+	require.Equal(t, banB.Kind, bans[1].Kind)
+	require.True(t, banB.Expires.Equal(bans[1].Expires))
+	require.NoError(t, s.BanDelete(banA.BanID))
+	require.Equal(t, consts.ErrInvalidBan, s.BanDelete(banA.BanID))
+	bans, err = s.Bans()
+	require.NoError(t, err)
+	require.Len(t, bans, 1)
+	require.NoError(t, s.BanDelete(banB.BanID))
+
+	// Retired passkeys
+	retired := &RetiredPasskey{Passkey: "retiredpasskey000001", UserID: newUser.UserID,
+		Expires: now.Add(time.Hour), CreatedOn: now}
+	require.NoError(t, s.RetiredPasskeyAdd(retired))
+	retired.Expires = now.Add(2 * time.Hour)
+	require.NoError(t, s.RetiredPasskeyAdd(retired))
+	passkeys, err := s.RetiredPasskeys()
+	require.NoError(t, err)
+	require.Len(t, passkeys, 1)
+	require.Equal(t, retired.Passkey, passkeys[0].Passkey)
+	require.Equal(t, retired.UserID, passkeys[0].UserID)
+	require.True(t, retired.Expires.Equal(passkeys[0].Expires))
+	require.NoError(t, s.RetiredPasskeyDelete(retired.Passkey))
+	require.NoError(t, s.RetiredPasskeyDelete(retired.Passkey))
+	passkeys, err = s.RetiredPasskeys()
+	require.NoError(t, err)
+	require.Empty(t, passkeys)
+
+	// Speed profiles
+	profile := &SpeedProfile{Kind: ProfileIP, Key: "2001:db8::1"}
+	profile.Add(1000, 1500, 10, now)
+	profile.Add(3000, 0, 10, now)
+	require.NoError(t, s.SpeedProfileSync([]*SpeedProfile{profile}))
+	profile.Add(2000, 0, 10, now)
+	require.NoError(t, s.SpeedProfileSync([]*SpeedProfile{profile,
+		{Kind: ProfileASN, Key: "64496", Samples: 1, Mean: 10, Max: 10, Recent: []uint64{10}, UpdatedOn: now}}))
+	profiles, err := s.SpeedProfiles()
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	for _, p := range profiles {
+		if p.Kind != ProfileIP {
+			continue
+		}
+		require.Equal(t, profile.ProfileKey(), p.ProfileKey())
+		require.Equal(t, uint64(3), p.Samples)
+		require.InDelta(t, 2000.0, p.Mean, 0.001)
+		require.Equal(t, uint64(3000), p.Max)
+		require.Equal(t, []uint64{1000, 3000, 2000}, p.Recent)
+	}
+	require.NoError(t, s.SpeedProfileDelete(profile.ProfileKey()))
+	require.NoError(t, s.SpeedProfileDelete(profile.ProfileKey()))
+	profiles, err = s.SpeedProfiles()
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	require.Equal(t, ProfileASN, profiles[0].Kind)
 }
 
 func init() {
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"time"
+)
+
+// FreeleechToken makes a single torrent freeleech for a single user, usually bought by the
+// user on the site. Downloads are only counted as free until the token expires or the
+// download cap is reached.
+type FreeleechToken struct {
+	UserID   uint32   `db:"user_id" json:"user_id"`
+	InfoHash InfoHash `db:"info_hash" json:"info_hash"`
+	// Downloaded is the amount downloaded for free using the token
+	Downloaded uint64 `db:"downloaded" json:"downloaded"`
+	// MaxDownloaded caps the amount the token covers, 0 for no limit
+	MaxDownloaded uint64 `db:"max_downloaded" json:"max_downloaded"`
+	// Expires is when the token stops applying, zero if it never expires
+	Expires   time.Time `db:"expires" json:"expires"`
+	CreatedOn time.Time `db:"created_on" json:"created_on"`
+
+	// Keeps track of how often the values have been changes
+	Writes uint32 `db:"-" json:"-"`
+}
+
+// Key returns the user and torrent the token applies to
+func (t FreeleechToken) Key() HistoryKey {
+	return HistoryKey{UserID: t.UserID, InfoHash: t.InfoHash}
+}
+
+// Expired returns true once the tokens expiry has passed
+func (t FreeleechToken) Expired(now time.Time) bool {
+	return !t.Expires.IsZero() && !now.Before(t.Expires)
+}
+
+// Remaining returns how much more can be downloaded for free with the token at the time provided
+func (t FreeleechToken) Remaining(now time.Time) uint64 {
+	if t.Expired(now) {
+		return 0
+	}
+	if t.MaxDownloaded == 0 {
+		return ^uint64(0)
+	}
+	if t.Downloaded >= t.MaxDownloaded {
+		return 0
+	}
+	return t.MaxDownloaded - t.Downloaded
+}
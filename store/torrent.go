@@ -9,6 +9,7 @@ import (
 	"github.com/viciious/mika/util"
 	log "github.com/sirupsen/logrus"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -141,6 +142,15 @@ type Torrent struct {
 	IsEnabled bool `db:"is_enabled" json:"is_enabled"`
 	// Reason when set will return a message to the torrent client
 	Reason string `db:"reason" json:"reason"`
+	// Maximum number of peers returned per announce, 0 defers to the role and tracker limits
+	MaxPeers uint32 `db:"max_peers" json:"max_peers"`
+	// Total size of the torrent contents in bytes, used when calculating bonus points
+	Size uint64 `db:"size" json:"size"`
+	// Category is a free form label used to scope events to a group of torrents
+	Category string `db:"category" json:"category"`
+	// TrapUserID marks the torrent as a trap which only the user with this id was given. Any
+	// announce for it from anybody else means the user leaked it. 0 for normal torrents.
+	TrapUserID uint32 `db:"trap_user_id" json:"trap_user_id"`
 	// Upload multiplier added to the users totals
 	MultiUp float64 `db:"multi_up" json:"multi_up"`
 	// Download multiplier added to the users totals
@@ -160,6 +170,15 @@ type Torrent struct {
 	Writes uint32 `db:"-" json:"-"`
 }
 
+// UpdateCounts copies the current swarm counts into the Seeders and Leechers fields so they
+// can be persisted by the backing store. Returns true if either value changed.
+func (t *Torrent) UpdateCounts() bool {
+	seeders, leechers := t.Peers.Counts()
+	prevSeeders := atomic.SwapUint32(&t.Seeders, seeders)
+	prevLeechers := atomic.SwapUint32(&t.Leechers, leechers)
+	return prevSeeders != seeders || prevLeechers != leechers
+}
+
 func (t *Torrent) Log() *log.Entry {
 	return log.WithFields(log.Fields{
 		"seeders":  t.Seeders,
@@ -205,7 +224,7 @@ type AnnounceHist struct {
 
 // PeerStats is any info to batch peer updates
 type PeerStats struct {
-	Left   uint32
+	Left   uint64
 	Hist   []AnnounceHist
 	Paused bool
 }
@@ -259,15 +278,62 @@ func NewTorrent(ih InfoHash) Torrent {
 // Torrents is a basic type alias for multiple torrents
 type Torrents map[InfoHash]*Torrent
 
-// WhiteListClient defines a whitelisted bittorrent client allowed to participate
+// Actions of client whitelist rules
+const (
+	// WhiteListAllow lets matching clients participate. Once any allow rule exists only the
+	// clients matching one are allowed.
+	WhiteListAllow = "allow"
+	// WhiteListDeny rejects matching clients even if they also match an allow rule. A list of
+	// only deny rules acts as a blacklist.
+	WhiteListDeny = "deny"
+)
+
+// WhiteListClient defines a rule allowing or denying bittorrent clients from participating
 // in swarms. This is not a foolproof solution as its fairly trivial for a motivated
 // attacker to fake this.
 type WhiteListClient struct {
+	WhiteListID uint32 `db:"whitelist_id" json:"whitelist_id"`
+	// ClientPrefix matches the start of the peer id, empty matches any peer id
 	ClientPrefix string `db:"client_prefix" json:"client_prefix"`
-	ClientName   string `db:"client_name" json:"client_name"`
+	// ClientName describes the rule
+	ClientName string `db:"client_name" json:"client_name"`
+	// Client matches the client name parsed by ClientString, case insensitive. Empty matches
+	// any client.
+	Client string `db:"client" json:"client"`
+	// MinVersion and MaxVersion are the inclusive range of client versions matched, eg: 4.3.
+	// Missing version parts are 0 and an empty version leaves that end of the range open.
+	MinVersion string `db:"min_version" json:"min_version"`
+	MaxVersion string `db:"max_version" json:"max_version"`
+	// Action is what happens to matching clients, allow|deny
+	Action string `db:"action" json:"action"`
+	// Message is sent to clients rejected by a deny rule instead of the default error
+	Message string `db:"message" json:"message"`
+}
+
+// Denies returns true if matching clients are rejected
+func (wl WhiteListClient) Denies() bool {
+	return wl.Action == WhiteListDeny
 }
 
-// Match returns true if the client matches this prefix
-func (wl WhiteListClient) Match(client string) bool {
-	return strings.HasPrefix(client, wl.ClientPrefix)
+// Match returns true if the client matches the prefix, name and version range of the rule
+func (wl WhiteListClient) Match(peerID PeerID, client BTClient) bool {
+	if !strings.HasPrefix(string(peerID[:]), wl.ClientPrefix) {
+		return false
+	}
+	if wl.Client != "" && !strings.EqualFold(wl.Client, client.Name) {
+		return false
+	}
+	if wl.MinVersion != "" {
+		min, err := ParseClientVersion(wl.MinVersion)
+		if err != nil || client.Version().Compare(min) < 0 {
+			return false
+		}
+	}
+	if wl.MaxVersion != "" {
+		max, err := ParseClientVersion(wl.MaxVersion)
+		if err != nil || client.Version().Compare(max) > 0 {
+			return false
+		}
+	}
+	return true
 }
@@ -15,3 +15,33 @@ func TestInfoHash(t *testing.T) {
 	require.Equal(t, hexEncoded, ih1.String())
 	require.Equal(t, bytes, ih1.Bytes())
 }
+
+func TestWhiteListClient_Match(t *testing.T) {
+	_, err := ParseClientVersion("1.2.3.4.5")
+	require.Error(t, err)
+	_, err = ParseClientVersion("4.x")
+	require.Error(t, err)
+	v, err := ParseClientVersion("4.3")
+	require.NoError(t, err)
+	require.Equal(t, ClientVersion{4, 3, 0, 0}, v)
+
+	rule := WhiteListClient{Client: "qbittorrent", MinVersion: "4.3", MaxVersion: "4.4.1"}
+	for _, c := range []struct {
+		peerID string
+		match  bool
+	}{
+		{"-qB4170-u-rGseINmloG", false},
+		{"-qB4300-u-rGseINmloG", true},
+		{"-qB4410-u-rGseINmloG", true},
+		{"-qB4420-u-rGseINmloG", false},
+		{"-DE13F0-u-rGseINmloG", false},
+	} {
+		pid := PeerIDFromString(c.peerID)
+		require.Equal(t, c.match, rule.Match(pid, ClientString(pid)), c.peerID)
+	}
+	rule = WhiteListClient{ClientPrefix: "-qB44"}
+	pid := PeerIDFromString("-qB4410-u-rGseINmloG")
+	require.True(t, rule.Match(pid, ClientString(pid)))
+	pid = PeerIDFromString("-qB4300-u-rGseINmloG")
+	require.False(t, rule.Match(pid, ClientString(pid)))
+}
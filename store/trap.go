@@ -0,0 +1,25 @@
+package store
+
+import (
+	"time"
+)
+
+// TrapLeak records an announce for a trap torrent which was not made by the user the trap was
+// registered to. As the info_hash of a trap is only ever handed to a single user any such
+// announce means the torrent was leaked by that user.
+type TrapLeak struct {
+	LeakID   uint32   `db:"trap_leak_id" json:"trap_leak_id"`
+	InfoHash InfoHash `db:"info_hash" json:"info_hash"`
+	// UserID is the user the trap was registered to and who leaked it
+	UserID uint32 `db:"user_id" json:"user_id"`
+	// AnnouncerID is the user who announced the trap, 0 when the announce had no valid passkey
+	// such as announces made to a public tracker
+	AnnouncerID uint32 `db:"announcer_id" json:"announcer_id"`
+	// IP is the address the announce was made from
+	IP string `db:"addr_ip" json:"addr_ip"`
+	// Client is the client string of the announcing peer
+	Client string `db:"client" json:"client"`
+	// PasskeyReset is true when the passkey of the user was reset because of the leak
+	PasskeyReset bool      `db:"passkey_reset" json:"passkey_reset"`
+	CreatedOn    time.Time `db:"created_on" json:"created_on"`
+}
@@ -18,7 +18,13 @@ type User struct {
 	DownloadEnabled bool      `db:"download_enabled" json:"download_enabled"`
 	Downloaded      uint64    `db:"downloaded" json:"downloaded"`
 	Uploaded        uint64    `db:"uploaded" json:"uploaded"`
+	DownloadedReal  uint64    `db:"downloaded_real" json:"downloaded_real"`
+	UploadedReal    uint64    `db:"uploaded_real" json:"uploaded_real"`
 	Announces       uint32    `db:"announces" json:"announces"`
+	MultiUp         float64   `db:"multi_up" json:"multi_up"`         // Personal multiplier, < 0 is unset
+	MultiDown       float64   `db:"multi_down" json:"multi_down"`     // Personal multiplier, < 0 is unset
+	MaxLeeching     uint32    `db:"max_leeching" json:"max_leeching"` // Overrides the role limit, 0 is unset
+	BonusPoints     float64   `db:"bonus_points" json:"bonus_points"`
 	CreatedOn       time.Time `db:"created_on" json:"created_on"`
 	UpdatedOn       time.Time `db:"updated_on" json:"updated_on"`
 	Role            *Role     `json:"role" db:"-"`
@@ -38,6 +44,31 @@ func (u User) Valid() bool {
 	return u.Passkey != "" && !u.IsDeleted
 }
 
+// Multipliers returns the effective upload and download multipliers to apply to a users transfer
+// on a torrent. This is the product of the torrent, role and user multipliers.
+//
+// Torrent multipliers are always applied, so a MultiDn of 0 is freeleech. Negative role and
+// user multipliers are considered unset and do not change the result.
+func Multipliers(t *Torrent, u *User) (float64, float64) {
+	up := t.MultiUp
+	down := t.MultiDn
+	if u.Role != nil {
+		if u.Role.MultiUp >= 0 {
+			up *= u.Role.MultiUp
+		}
+		if u.Role.MultiDown >= 0 {
+			down *= u.Role.MultiDown
+		}
+	}
+	if u.MultiUp >= 0 {
+		up *= u.MultiUp
+	}
+	if u.MultiDown >= 0 {
+		down *= u.MultiDown
+	}
+	return up, down
+}
+
 // Users is a slice of known users
 type Users map[string]*User
 
@@ -49,8 +80,8 @@ func (r Roles) Get(roleID uint32) *Role {
 	return r[roleID]
 }
 
-// WhiteList is a map of whitelisted clients by 8 chars of client prefix
-type WhiteList map[string]*WhiteListClient
+// WhiteList is a map of client whitelist rules by their id
+type WhiteList map[uint32]*WhiteListClient
 
 // Remove removes a users from a Users slice
 func (users Users) Remove(p *User) {
@@ -66,6 +97,9 @@ type Role struct {
 	MultiDown       float64   `json:"multi_down" db:"multi_down"`
 	DownloadEnabled bool      `json:"download_enabled" db:"download_enabled"`
 	UploadEnabled   bool      `json:"upload_enabled" db:"upload_enabled"`
+	MaxPeers        uint32    `json:"max_peers" db:"max_peers"`
+	MaxHnR          uint32    `json:"max_hnr" db:"max_hnr"`
+	MaxLeeching     uint32    `json:"max_leeching" db:"max_leeching"`
 	CreatedOn       time.Time `json:"created_on" db:"created_on"`
 	UpdatedOn       time.Time `json:"updated_on" db:"updated_on"`
 }
@@ -0,0 +1,26 @@
+package store
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMultipliers(t *testing.T) {
+	tor := &Torrent{MultiUp: 1, MultiDn: 0.5}
+	usr := &User{MultiUp: -1, MultiDown: -1, Role: &Role{MultiUp: -1, MultiDown: 2}}
+	up, down := Multipliers(tor, usr)
+	require.Equal(t, 1.0, up)
+	require.Equal(t, 1.0, down)
+	usr.MultiUp = 3
+	up, down = Multipliers(tor, usr)
+	require.Equal(t, 3.0, up)
+	require.Equal(t, 1.0, down)
+	// A personal multiplier of 0 removes the credit like a role multiplier of 0 does
+	usr.MultiUp = 0
+	up, _ = Multipliers(tor, usr)
+	require.Equal(t, 0.0, up)
+	// Freeleech torrents are never charged
+	tor.MultiDn = 0
+	_, down = Multipliers(tor, usr)
+	require.Equal(t, 0.0, down)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"net"
+	"strings"
 	"sync/atomic"
 	"time"
 )
@@ -25,20 +26,20 @@ type announceRequest struct {
 	// The total amount downloaded (since the client sent the 'started' event to the tracker) in
 	// base ten ASCII. While not explicitly stated in the official specification, the consensus is that
 	// this should be the total number of bytes downloaded.
-	Downloaded uint32
+	Downloaded uint64
 
 	// The number of bytes this peer still has to download, encoded in base ten ascii.
 	// Note that this can't be computed from downloaded and the file length since it
 	// might be a resume, and there's a chance that some of the downloaded data failed an
 	// integrity check and had to be re-downloaded.
-	Left uint32
+	Left uint64
 
 	// The total amount uploaded (since the client sent the 'started' event to the tracker) in base ten
 	// ASCII. While not explicitly stated in the official specification, the consensus is that this should
 	// be the total number of bytes uploaded.
-	Uploaded uint32
+	Uploaded uint64
 
-	Corrupt uint32
+	Corrupt uint64
 
 	// This is an optional key which maps to started, completed, or stopped (or empty,
 	// which is the same as not being present). If not present, this is one of the
@@ -91,6 +92,9 @@ type announceRequest struct {
 	Key string
 
 	CryptoLevel consts.CryptoLevel
+
+	// UserAgent is the User-Agent header of HTTP announces
+	UserAgent string
 }
 
 // Parse the query string into an announceRequest struct
@@ -134,76 +138,86 @@ func newAnnounce(c *gin.Context) (*announceRequest, errCode) {
 	}
 	return &announceRequest{
 		Compact:     true, // Ignored and always set to true
-		Corrupt:     getUint32Key(q, paramCorrupt, 0),
-		Downloaded:  getUint32Key(q, paramDownloaded, 0),
+		Corrupt:     getUint64Key(q, paramCorrupt, 0),
+		Downloaded:  getUint64Key(q, paramDownloaded, 0),
 		Event:       consts.ParseAnnounceType(q.Params[paramEvent]),
 		IPv6:        ipv6,
 		IP:          ipAddr,
 		InfoHash:    infoHash,
-		Left:        getUint32Key(q, paramLeft, 0),
+		Left:        getUint64Key(q, paramLeft, 0),
 		NumWant:     getUintKey(q, paramNumWant, 30),
 		PeerID:      store.PeerIDFromString(peerID),
+		Passkey:     c.Param("passkey"),
 		Port:        port,
 		Key:         q.Params[paramKey],
-		Uploaded:    getUint32Key(q, paramUploaded, 0),
+		Uploaded:    getUint64Key(q, paramUploaded, 0),
 		CryptoLevel: cryptoLevel,
+		UserAgent:   c.Request.UserAgent(),
 	}, msgOk
 }
 
-// The meaty bits.
-// NOTE we ONLY support compact response formats (binary format) by design even though its
-// technically breaking the protocol specs.
-// There is no reason to support the older less efficient model for private needs
-func announce(c *gin.Context) {
-	// Check that the user is valid before parsing anything
-	start := time.Now()
-	atomic.AddInt64(&metrics.AnnounceTotal, 1)
-	pk := c.Param("passkey")
-	var usr store.User
-	if valid := preFlightChecks(&usr, pk, c); !valid {
-		oops(c, msgInvalidAuth)
-		atomic.AddInt64(&metrics.AnnounceStatusUnauthorized, 1)
-		return
+// warnUploadDisabled is sent to users whose role does not earn upload credit
+const warnUploadDisabled = "Upload credit disabled for your user class"
+
+// announceResponse holds the transport independent result of a successful announce
+type announceResponse struct {
+	Seeders     uint32
+	Leechers    uint32
+	Interval    time.Duration
+	IntervalMin time.Duration
+	Peers       []*store.Peer
+	// PeerID is the requesting peers id so it can be excluded from the peer list
+	PeerID store.PeerID
+	// Warning is sent to the client along with a successful response when set
+	Warning string
+}
+
+// handleAnnounce performs the announce against the swarm once a request has been
+// authenticated and parsed. This is shared between the HTTP and UDP front ends.
+//
+// A non-empty reason string is returned for bans, denied clients and torrents disabled with a
+// custom message which should be sent to the client instead of the default message for the errCode.
+func handleAnnounce(usr *store.User, req *announceRequest) (*announceResponse, errCode, string) {
+	// The peer address may differ from the address of the request when clients supply their own
+	if ban, found := banned(req.IP); found {
+		return nil, msgBanned, banMessage(ban)
 	}
-	// Parse the announce into an announceRequest
-	req, code := newAnnounce(c)
-	if code != msgOk {
-		oops(c, code)
-		atomic.AddInt64(&metrics.AnnounceStatusMalformed, 1)
-		return
+	if ban, found := userBanned(usr.UserID); found {
+		return nil, msgBanned, banMessage(ban)
 	}
 	// TODO save this check
-	if !ClientWhitelisted(req.PeerID) {
-		oops(c, msgBadClient)
-		return
-	}
-	if pk == "" && config.Tracker.Public {
-		// Use client key to track user stats for public mode
-		pk = req.Key
+	if allowed, msg := ClientWhitelisted(req.PeerID); !allowed {
+		return nil, msgBadClient, msg
 	}
+	recordPasskeyUse(usr, req.Passkey, req.IP, util.Now())
 	// Get & Validate the torrent associated with the info_hash supplies
 	tor, errGet := TorrentGet(req.InfoHash, false)
 	if errGet != nil || tor.IsDeleted {
 		if !errors.Is(errGet, consts.ErrInvalidInfoHash) {
 			log.Errorf("Error fetching torrent: %v", errGet)
-			oops(c, msgGenericError)
-			return
+			return nil, msgGenericError, ""
 		}
 		if config.Tracker.AutoRegister {
 			newTor := store.NewTorrent(req.InfoHash)
 			if err := TorrentAdd(&newTor); err != nil {
 				log.Errorf("Failed to auto register torrent: %s", err.Error())
-				oops(c, msgGenericError)
-				return
+				return nil, msgGenericError, ""
 			}
 			tor = &newTor
 		} else {
 			log.Debugf("No torrent found matching: %x", req.InfoHash.Bytes())
-			oops(c, msgInvalidInfoHash)
 			atomic.AddInt64(&metrics.AnnounceStatusInvalidInfoHash, 1)
-			return
+			return nil, msgInvalidInfoHash, ""
 		}
 	}
+	// Leakers are not told the torrent is a trap
+	if trapLeaked(tor, usr, req.IP, req.PeerID) {
+		return nil, msgInvalidInfoHash, ""
+	}
+	// Clients spoofing the peer id of another client rarely bother to match its User-Agent
+	if userAgentRejected(req, tor, usr) {
+		return nil, msgClientMismatch, ""
+	}
 	// If disabled and reason is set, the reason is returned to the client
 	// This is mostly useful for when a torrent has been "trumped" by another torrent so it
 	// should be downloaded instead
@@ -211,8 +225,27 @@ func announce(c *gin.Context) {
 	// TODO send this as a "warning message" field of a normal announce response instead?
 	if !tor.IsEnabled && tor.Reason != "" {
 		log.Debugf("Torrent found but is disabled: %x", req.InfoHash.Bytes())
-		c.Data(int(msgInvalidInfoHash), gin.MIMEPlain, responseError(tor.Reason))
-		return
+		return nil, msgInvalidInfoHash, tor.Reason
+	}
+	// Users which are not allowed to download can still seed
+	if req.Left > 0 && !publicUser(usr) {
+		if !usr.DownloadEnabled {
+			return nil, msgDownloadDisabled, ""
+		}
+		if usr.Role != nil && !usr.Role.DownloadEnabled {
+			return nil, msgRoleDownloadDisabled, ""
+		}
+		if hnrLimited(usr) {
+			return nil, msgHitAndRunLimit, ""
+		}
+		if ratioRestricted(usr) {
+			return nil, msgRatioRestricted, ""
+		}
+		// Any peer joining the swarm counts, clients are free to leave out the started event
+		if _, err := tor.Peers.Get(req.PeerID); err == consts.ErrInvalidPeerID &&
+			req.Event != consts.STOPPED && leechingLimited(usr, req.InfoHash) {
+			return nil, msgLeechingLimit, ""
+		}
 	}
 	peer, err := tor.Peers.Get(req.PeerID)
 	if err != nil {
@@ -220,8 +253,9 @@ func announce(c *gin.Context) {
 			// Create a new peer for the swarm
 			peer = store.NewPeer(usr.UserID, req.PeerID, req.IP, req.Port)
 			// Dont add download/upload stats because they would be doubled if applied in the
-			// state update. Left is set because its always a static value being set and a (safe) data race
-			// can occur for counting seeder/leecher states
+			// state update. Left is set so the peer joins the swarm counted as the correct type
+			peer.Left = req.Left
+			peer.Paused = req.Event == consts.PAUSED
 			peer.Client = store.ClientString(req.PeerID).String()
 			// TODO allow this to be updated in the perm storage when a client changes settings
 			peer.CryptoLevel = req.CryptoLevel
@@ -232,89 +266,197 @@ func announce(c *gin.Context) {
 			peer.CountryCode = l.ISOCode
 			tor.Peers.Add(peer)
 		} else {
-			oops(c, msgGenericError)
-			return
+			return nil, msgGenericError, ""
 		}
+	}
+	// Clients can change their User-Agent without changing their peer id
+	peer.UserAgent = req.UserAgent
+	updateStates(req, peer, tor, usr)
+	var peersFound []*store.Peer
+	seeders, leechers := tor.Peers.Counts()
+	if probing(usr.UserID) {
+		// Probed users only ever see ghost peers which nobody can connect to
+		peersFound = ghostPeers(usr.UserID, tor.InfoHash, peerLimit(req.NumWant, usr, tor), req.IPv6)
+		seeders, leechers = 0, uint32(len(peersFound))
 	} else {
-		peer.AnnounceLast = time.Now()
+		peersFound = tor.Peers.Select(selector, store.PeerQuery{
+			Requester:      peer,
+			Limit:          peerLimit(req.NumWant, usr, tor),
+			TTL:            peerTTL(),
+			ExcludeSeeders: peer.IsSeeder(),
+			// Probed users must not receive real connections either, or honest uploads to
+			// them would be flagged
+			Exclude: probedPeer,
+		})
 	}
-	atomic.SwapUint32(&peer.Left, req.Left)
-	peersFound, err2 := tor.Peers.GetN(config.Tracker.MaxPeers)
-	if err2 != nil {
-		log.Errorf("Could not read peers from swarm: %s", err2.Error())
-		oops(c, msgGenericError)
+	resp := &announceResponse{
+		Seeders:     seeders,
+		Leechers:    leechers,
+		Interval:    config.Tracker.AnnounceIntervalParsed,
+		IntervalMin: config.Tracker.AnnounceIntervalMinimumParsed,
+		Peers:       peersFound,
+		PeerID:      peer.PeerID,
+	}
+	var warnings []string
+	if !uploadEnabled(usr) {
+		warnings = append(warnings, warnUploadDisabled)
+	}
+	if warning := ratioWarning(usr); warning != "" {
+		warnings = append(warnings, warning)
+	}
+	if req.Passkey != "" && passkeyRetired(req.Passkey) {
+		warnings = append(warnings, warnPasskeyRetired)
+	}
+	resp.Warning = strings.Join(warnings, ". ")
+	tor.Log().Debug("Announced")
+	return resp, msgOk, ""
+}
+
+// uploadEnabled checks if the user earns upload credit. Roles with uploading disabled still
+// receive peers but their uploads are not credited.
+func uploadEnabled(usr *store.User) bool {
+	return usr.Role == nil || usr.Role.UploadEnabled
+}
+
+// peerLimit returns the number of peers to send in an announce response. The clients numwant
+// is capped by the tracker max_peers and the role and torrent limits when they are set.
+func peerLimit(numWant uint, usr *store.User, tor *store.Torrent) int {
+	limit := config.Tracker.MaxPeers
+	if usr.Role != nil && usr.Role.MaxPeers > 0 {
+		limit = util.Min(limit, int(usr.Role.MaxPeers))
+	}
+	if tor.MaxPeers > 0 {
+		limit = util.Min(limit, int(tor.MaxPeers))
+	}
+	if numWant < uint(limit) {
+		limit = int(numWant)
+	}
+	return limit
+}
+
+// The meaty bits.
+// NOTE we ONLY support compact response formats (binary format) by design even though its
+// technically breaking the protocol specs.
+// There is no reason to support the older less efficient model for private needs
+func announce(c *gin.Context) {
+	// Check that the user is valid before parsing anything
+	start := time.Now()
+	atomic.AddInt64(&metrics.AnnounceTotal, 1)
+	if requestBanned(c) {
+		return
+	}
+	usr, valid := preFlightChecks(c.Param("passkey"), c)
+	if !valid {
+		atomic.AddInt64(&metrics.AnnounceStatusUnauthorized, 1)
+		trapUnauthorized(c)
+		return
+	}
+	// Parse the announce into an announceRequest
+	req, code := newAnnounce(c)
+	if code != msgOk {
+		oops(c, code)
+		atomic.AddInt64(&metrics.AnnounceStatusMalformed, 1)
+		return
+	}
+	resp, code, reason := handleAnnounce(usr, req)
+	if code != msgOk {
+		if reason != "" {
+			c.Data(int(code), gin.MIMEPlain, responseError(reason))
+			return
+		}
+		oops(c, code)
 		return
 	}
 	dict := bencode.Dict{
-		"complete":     tor.Seeders,
-		"incomplete":   tor.Leechers,
-		"interval":     int(config.Tracker.AnnounceIntervalParsed.Seconds()),
-		"min interval": int(config.Tracker.AnnounceIntervalMinimumParsed.Seconds()),
+		"complete":     resp.Seeders,
+		"incomplete":   resp.Leechers,
+		"interval":     int(resp.Interval.Seconds()),
+		"min interval": int(resp.IntervalMin.Seconds()),
+	}
+	if resp.Warning != "" {
+		dict["warning message"] = resp.Warning
 	}
 	// TODO IP.To16() != nil validation for v4 in v6 addresses
 	if !req.IPv6 || (req.IPv6 && !config.Tracker.IPv6Only) {
-		dict["peers"] = makeCompactPeers(peersFound, peer.PeerID, false, req.CryptoLevel)
+		dict["peers"] = makeCompactPeers(resp.Peers, resp.PeerID, false, req.CryptoLevel)
 	}
 	if req.IPv6 {
-		dict["peers6"] = makeCompactPeers(peersFound, peer.PeerID, true, req.CryptoLevel)
+		dict["peers6"] = makeCompactPeers(resp.Peers, resp.PeerID, true, req.CryptoLevel)
 	}
 	var outBytes bytes.Buffer
 	if err := bencode.NewEncoder(&outBytes).Encode(dict); err != nil {
 		oops(c, msgGenericError)
 		return
 	}
-	updateStates(req, peer, tor, &usr)
 	c.Data(int(msgOk), gin.MIMEPlain, outBytes.Bytes())
 	metrics.AddAnnounceTime(time.Since(start).Nanoseconds())
-	tor.Log().Debug("Announced")
 }
 
 func updateStates(req *announceRequest, peer *store.Peer, tor *store.Torrent, user *store.User) {
-	switch req.Event {
-	case consts.PAUSED:
-		if !peer.Paused {
-			atomic.AddUint32(&tor.Seeders, 1)
-		}
-	case consts.STARTED:
-		if peer.Left == 0 {
-			atomic.AddUint32(&tor.Seeders, 1)
-		} else {
-			atomic.AddUint32(&tor.Leechers, 1)
+	// The state since the previous announce decides if the elapsed time counts as seeding
+	wasSeeding := peer.IsSeeder()
+	snatched := false
+	public := publicUser(user)
+	// Swarm membership follows the state reported by the peer rather than the event sent, so
+	// duplicate or missing events cannot skew the seeder and leecher counts
+	if req.Event == consts.STOPPED {
+		tor.Peers.Remove(peer.PeerID)
+	} else {
+		// Partial seeds stay paused until they send another event
+		paused := req.Event == consts.PAUSED || (peer.Paused && req.Event == consts.ANNOUNCE)
+		completed := tor.Peers.SetState(peer, req.Left, paused)
+		// Repeated completed events from a peer which is already seeding are not counted again
+		snatched = req.Event == consts.COMPLETED && (completed || (peer.IsNew() && peer.IsSeeder()))
+		if snatched {
+			atomic.AddUint32(&tor.Snatches, 1)
 		}
-	case consts.COMPLETED:
-		atomic.AddUint32(&tor.Snatches, 1)
-		atomic.AddUint32(&tor.Seeders, 1)
-		if tor.Leechers >= 1 {
-			atomic.SwapUint32(&tor.Leechers, tor.Leechers-1)
+		if !peer.IsSeeder() && !public {
+			trackLeeching(user.UserID, tor.InfoHash)
 		}
-	case consts.STOPPED:
-		// Paused considered a seeder
-		if peer.Paused || peer.Left == 0 {
-			if tor.Seeders > 0 {
-				atomic.SwapUint32(&tor.Seeders, tor.Seeders-1)
-			}
-		} else {
-			if tor.Leechers > 0 {
-				atomic.SwapUint32(&tor.Leechers, tor.Leechers-1)
-			}
+	}
+	// Only credit the amount transferred since the previous announce of this session
+	uploaded, downloaded := peer.Delta(req.Uploaded, req.Downloaded, req.Event)
+	now := time.Now()
+	elapsed := now.Sub(peer.AnnounceLast)
+	peer.UpdateSpeed(uploaded, downloaded, elapsed, config.Tracker.AnnounceIntervalMinimumParsed)
+	// The public pseudo user is shared by every anonymous peer so has no history of its own
+	if !public {
+		// Uploads flagged by the cheat detection rules may not be credited at all
+		uploaded = checkCheats(peer, tor, user, uploaded, downloaded, now)
+		updateHistory(user.UserID, tor.InfoHash, uploaded, downloaded, elapsed, wasSeeding, snatched, now)
+		if wasSeeding {
+			accrueBonus(user, tor, elapsed, now)
 		}
-		tor.Peers.Remove(peer.PeerID)
-		//if err := peerDelete(u.InfoHash, u.PeerID); err != nil {
-		//	log.Errorf("Could not remove peer from swarm: %s", err.Error())
-		//}
 	}
+	peer.AnnounceLast = now
 	atomic.AddInt64(&metrics.AnnounceStatusOK, 1)
 	atomic.AddUint32(&peer.Announces, 1)
-	atomic.SwapUint32(&peer.Left, req.Left)
-	atomic.AddUint64(&peer.Downloaded, uint64(req.Downloaded))
-	atomic.AddUint64(&peer.Uploaded, uint64(req.Uploaded))
 	atomic.AddUint64(&tor.Announces, 1)
-	atomic.AddUint64(&tor.Uploaded, uint64(float64(req.Uploaded)*tor.MultiUp))
-	atomic.AddUint64(&tor.Downloaded, uint64(float64(req.Downloaded)*tor.MultiDn))
-	atomic.AddUint64(&tor.UploadedReal, uint64(req.Uploaded))
-	atomic.AddUint64(&tor.DownloadedReal, uint64(req.Downloaded))
+	// Active events apply on top of every other multiplier
+	eventUp, eventDn := eventMultipliers(tor, user, now)
+	atomic.AddUint64(&tor.Uploaded, uint64(float64(uploaded)*tor.MultiUp*eventUp))
+	atomic.AddUint64(&tor.Downloaded, uint64(float64(downloaded)*tor.MultiDn*eventDn))
+	atomic.AddUint64(&tor.UploadedReal, uploaded)
+	atomic.AddUint64(&tor.DownloadedReal, downloaded)
+	multiUp, multiDn := store.Multipliers(tor, user)
+	multiUp *= eventUp
+	multiDn *= eventDn
+	if !uploadEnabled(user) {
+		multiUp = 0
+	}
+	atomic.AddUint32(&user.Announces, 1)
+	atomic.AddUint64(&user.Uploaded, uint64(float64(uploaded)*multiUp))
+	// Downloads covered by a personal freeleech token are not counted
+	free := tokenFreeleech(user.UserID, tor.InfoHash, downloaded, now)
+	atomic.AddUint64(&user.Downloaded, uint64(float64(downloaded-free)*multiDn))
+	atomic.AddUint64(&user.UploadedReal, uploaded)
+	atomic.AddUint64(&user.DownloadedReal, downloaded)
+	tor.UpdateCounts()
 	atomic.AddUint32(&tor.Writes, 1)
 	atomic.AddUint32(&user.Writes, 1)
+	if req.Event == consts.STOPPED {
+		endSession(tor, peer, false)
+	}
 }
 
 // Generate a compact peer field array containing the byte representations
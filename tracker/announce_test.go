@@ -2,9 +2,11 @@ package tracker
 
 import (
 	"fmt"
+	"github.com/chihaya/bencode"
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/store"
 	_ "github.com/viciious/mika/store/mysql"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -16,7 +18,7 @@ func TestBitTorrentHandler_Announce(t *testing.T) {
 	type stateExpected struct {
 		Uploaded   uint64
 		Downloaded uint64
-		Left       uint32
+		Left       uint64
 		Seeders    int
 		Leechers   int
 		Port       uint16
@@ -75,7 +77,7 @@ func TestBitTorrentHandler_Announce(t *testing.T) {
 		// 8. IPv6 routable
 		{testReq{Ih: testTorrents[0].InfoHash, PID: testLeechers[0].PeerID, IP: "2600::1",
 			Port: "4000", Uploaded: "0", Downloaded: "0", left: "5000", PK: testUsers[0].Passkey},
-			stateExpected{Status: msgOk, HasPeer: true, Left: 5000, Port: 4000, IP: "2600::1", SwarmSize: 1},
+			stateExpected{Status: msgOk, HasPeer: true, Left: 5000, Leechers: 1, Port: 4000, IP: "2600::1", SwarmSize: 1},
 		},
 		// 9. IPv6 routable
 		{testReq{Ih: unregisteredTorrent.InfoHash, PID: testLeechers[0].PeerID, IP: "12.34.56.78",
@@ -104,7 +106,7 @@ func TestBitTorrentHandler_Announce(t *testing.T) {
 		// 13. 2 Seeders, 1 completed leecher
 		{testReq{Ih: testTorrents[0].InfoHash, PID: testLeechers[0].PeerID, IP: "2600::1", event: string(consts.COMPLETED),
 			Port: "4000", Uploaded: "0", Downloaded: "5000", left: "0", PK: testUsers[0].Passkey},
-			stateExpected{Uploaded: 0, Downloaded: 10000, Left: 0,
+			stateExpected{Uploaded: 0, Downloaded: 5000, Left: 0,
 				Seeders: 2, Leechers: 0, Snatches: 1, Port: 4000, IP: "2600::1", HasPeer: true, Status: msgOk,
 				SwarmSize: 2},
 		},
@@ -146,14 +148,160 @@ func TestBitTorrentHandler_Announce(t *testing.T) {
 				_, err2 := tor.Peers.Get(a.req.PID)
 				require.Error(t, err2, "Got peer when we shouldn't (%d)", i)
 			}
-			peers, err := tor.Peers.GetN(1000)
-			require.NoError(t, err, "Failed to fetch all peers (%d)", i)
 			torrent, err := TorrentGet(tor.InfoHash, false)
 			require.NoError(t, err)
-			require.Equal(t, a.state.SwarmSize, len(peers), "Invalid swarm size (%d)", i)
+			require.Equal(t, a.state.SwarmSize, len(tor.Peers.Peers), "Invalid swarm size (%d)", i)
 			require.Equal(t, int(a.state.Seeders), int(torrent.Seeders), "Invalid seeder count (%d)", i)
 			require.Equal(t, int(a.state.Leechers), int(torrent.Leechers), "Invalid leecher count (%d)", i)
 			require.Equal(t, int(a.state.Snatches), int(torrent.Snatches), "invalid snatch count (%d)", i)
 		}
 	}
 }
+
+func TestBitTorrentHandler_AnnounceCredit(t *testing.T) {
+	rh := NewBitTorrentHandler()
+	role := store.GenerateTestRole()
+	role.MultiUp = 2
+	require.NoError(t, RoleAdd(&role))
+	usr := store.GenerateTestUser()
+	usr.RoleID = role.RoleID
+	usr.MultiUp = 1.5
+	require.NoError(t, UserAdd(&usr))
+	tor := store.GenerateTestTorrent()
+	tor.MultiUp = 1
+	tor.MultiDn = 0.5
+	require.NoError(t, TorrentAdd(&tor))
+
+	for _, req := range []testReq{
+		{Ih: tor.InfoHash, PID: testLeechers[0].PeerID, IP: "12.34.56.78", Port: "4000",
+			Uploaded: "0", Downloaded: "0", left: "5000", event: "started", PK: usr.Passkey},
+		{Ih: tor.InfoHash, PID: testLeechers[0].PeerID, IP: "12.34.56.78", Port: "4000",
+			Uploaded: "1000", Downloaded: "1000", left: "4000", PK: usr.Passkey},
+	} {
+		u := fmt.Sprintf("/announce/%s?%s", req.PK, req.ToValues().Encode())
+		w := performRequest(rh, "GET", u, nil, nil)
+		require.Equal(t, 200, w.Code)
+	}
+	// Credited on top of the seeded user totals: up 1000*1*2*1.5, down 1000*0.5*1
+	require.Equal(t, uint64(2000+3000), usr.Uploaded)
+	require.Equal(t, uint64(1000+500), usr.Downloaded)
+	require.Equal(t, uint64(1000), usr.UploadedReal)
+	require.Equal(t, uint64(1000), usr.DownloadedReal)
+	require.Equal(t, uint32(500+2), usr.Announces)
+}
+
+func TestBitTorrentHandler_AnnouncePeers(t *testing.T) {
+	rh := NewBitTorrentHandler()
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	announcePeers := func(req testReq) int {
+		u := fmt.Sprintf("/announce/%s?%s", req.PK, req.ToValues().Encode())
+		w := performRequest(rh, "GET", u, nil, nil)
+		require.Equal(t, 200, w.Code, w.Body.String())
+		resp, err := bencode.NewDecoder(w.Body).Decode()
+		require.NoError(t, err)
+		peers, ok := resp.(bencode.Dict)["peers"].(string)
+		require.True(t, ok)
+		return len(peers) / 6
+	}
+	newReq := func(i int, left string) testReq {
+		pid := testLeechers[0].PeerID
+		pid[19] = byte(i)
+		return testReq{Ih: tor.InfoHash, PID: pid, IP: "12.34.56.78", Port: fmt.Sprintf("%d", 4000+i),
+			Uploaded: "0", Downloaded: "0", left: left, event: "started", PK: testUsers[0].Passkey}
+	}
+	for i := 0; i < 5; i++ {
+		announcePeers(newReq(i, "0"))
+	}
+	for i := 5; i < 10; i++ {
+		announcePeers(newReq(i, "1000"))
+	}
+	// Seeders only receive leechers
+	require.Equal(t, 5, announcePeers(newReq(0, "0")))
+	// numwant is honoured
+	req := newReq(5, "1000")
+	req.numWant = "3"
+	require.Equal(t, 3, announcePeers(req))
+	req.numWant = "0"
+	require.Equal(t, 0, announcePeers(req))
+	// Capped by the torrent limit
+	tor.MaxPeers = 4
+	req.numWant = "50"
+	require.Equal(t, 4, announcePeers(req))
+}
+
+func TestBitTorrentHandler_AnnounceDisabled(t *testing.T) {
+	s := newTestSwarm(t)
+	usr, role := s.usr, s.role
+	announce := func(uploaded, left string) (errCode, bencode.Dict) {
+		return s.announce(testReq{Uploaded: uploaded, left: left})
+	}
+	usr.DownloadEnabled = false
+	code, resp := announce("0", "1000")
+	require.Equal(t, msgDownloadDisabled, code)
+	require.Equal(t, responseStringMap[msgDownloadDisabled].Error(), resp["failure reason"])
+	code, _ = announce("0", "0")
+	require.Equal(t, msgOk, code)
+
+	usr.DownloadEnabled = true
+	role.DownloadEnabled = false
+	code, resp = announce("0", "1000")
+	require.Equal(t, msgRoleDownloadDisabled, code)
+	require.Equal(t, responseStringMap[msgRoleDownloadDisabled].Error(), resp["failure reason"])
+	code, _ = announce("0", "0")
+	require.Equal(t, msgOk, code)
+
+	// Peers are still returned without any upload credit
+	role.DownloadEnabled = true
+	role.UploadEnabled = false
+	uploaded, uploadedReal := usr.Uploaded, usr.UploadedReal
+	code, resp = announce("5000", "0")
+	require.Equal(t, msgOk, code)
+	require.Equal(t, warnUploadDisabled, resp["warning message"])
+	require.Contains(t, resp, "peers")
+	require.Equal(t, uploaded, usr.Uploaded)
+	require.Equal(t, uploadedReal+5000, usr.UploadedReal)
+}
+
+func TestBitTorrentHandler_AnnounceClientRules(t *testing.T) {
+	rh := NewBitTorrentHandler()
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	announce := func(peerID string) errCode {
+		req := testReq{Ih: tor.InfoHash, PID: store.PeerIDFromString(peerID), IP: "12.34.56.78", Port: "4000",
+			Uploaded: "0", Downloaded: "0", left: "1000", PK: testUsers[0].Passkey}
+		w := performRequest(rh, "GET", fmt.Sprintf("/announce/%s?%s", req.PK, req.ToValues().Encode()), nil, nil)
+		return errCode(w.Code)
+	}
+	for _, wl := range []store.WhiteListClient{
+		{ClientName: "no match"},
+		{ClientName: "bad action", Client: "qBittorrent", Action: "maybe"},
+		{ClientName: "bad version", Client: "qBittorrent", MinVersion: "4.a"},
+		{ClientName: "bad range", Client: "qBittorrent", MinVersion: "4.4", MaxVersion: "4.3"},
+		{ClientName: "long prefix", ClientPrefix: "-qB4400-u"},
+	} {
+		require.True(t, errors.Is(WhiteListAdd(&wl), consts.ErrMalformedRequest), wl.ClientName)
+	}
+
+	// Versions older than the existing prefix rule are not allowed
+	require.Equal(t, msgBadClient, announce("-qB4200-u-rGseINmloG"))
+	allow := store.WhiteListClient{ClientName: "qBittorrent 4.3+", Client: "qBittorrent", MinVersion: "4.3"}
+	deny := store.WhiteListClient{ClientName: "Broken qBittorrent", Client: "qbittorrent", MinVersion: "4.4.0",
+		MaxVersion: "4.4.0", Action: store.WhiteListDeny, Message: "qBittorrent 4.4.0 is broken, please upgrade"}
+	require.NoError(t, WhiteListAdd(&allow))
+	require.Equal(t, store.WhiteListAllow, allow.Action)
+	require.NoError(t, WhiteListAdd(&deny))
+	defer func() {
+		require.NoError(t, WhiteListDelete(&allow))
+		require.NoError(t, WhiteListDelete(&deny))
+	}()
+	require.Equal(t, msgBadClient, announce("-qB4200-u-rGseINmloG"))
+	require.Equal(t, msgOk, announce("-qB4410-u-rGseINmloG"))
+	require.Equal(t, msgBadClient, announce("-qB4400-u-rGseINmloG"))
+	allowed, msg := ClientWhitelisted(store.PeerIDFromString("-qB4400-u-rGseINmloG"))
+	require.False(t, allowed)
+	require.Equal(t, deny.Message, msg)
+	allowed, msg = ClientWhitelisted(store.PeerIDFromString("-qB4200-u-rGseINmloG"))
+	require.False(t, allowed)
+	require.Empty(t, msg)
+}
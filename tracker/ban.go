@@ -0,0 +1,296 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// banTrie is a binary trie of the banned IP ranges. Each bit of an address selects a child so
+// the ranges containing an address are found in at most 128 steps however many are held.
+// IPv4 ranges are stored as their IPv4-mapped IPv6 range.
+type banTrie struct {
+	children [2]*banTrie
+	bans     []*store.Ban
+}
+
+// insert adds a ban for the range
+func (t *banTrie) insert(n *net.IPNet, ban *store.Ban) {
+	ip := n.IP.To16()
+	ones, bits := n.Mask.Size()
+	if bits == net.IPv4len*8 {
+		ones += (net.IPv6len - net.IPv4len) * 8
+	}
+	node := t
+	for i := 0; i < ones; i++ {
+		bit := ip[i/8] >> (7 - uint(i%8)) & 1
+		if node.children[bit] == nil {
+			node.children[bit] = &banTrie{}
+		}
+		node = node.children[bit]
+	}
+	node.bans = append(node.bans, ban)
+}
+
+// match returns the active ban of the most specific range containing the address
+func (t *banTrie) match(ip net.IP, now time.Time) *store.Ban {
+	ip = ip.To16()
+	if ip == nil {
+		return nil
+	}
+	var found *store.Ban
+	node := t
+	for i := 0; node != nil; i++ {
+		for _, b := range node.bans {
+			if !b.Expired(now) {
+				found = b
+				break
+			}
+		}
+		if i == len(ip)*8 {
+			break
+		}
+		node = node.children[ip[i/8]>>(7-uint(i%8))&1]
+	}
+	return found
+}
+
+// banList indexes the bans by what they match
+type banList struct {
+	all       []*store.Ban
+	nets      *banTrie
+	asns      map[uint32][]*store.Ban
+	countries map[string][]*store.Ban
+	users     map[uint32][]*store.Ban
+}
+
+func newBanList(all []*store.Ban) *banList {
+	l := &banList{
+		all:       all,
+		nets:      &banTrie{},
+		asns:      make(map[uint32][]*store.Ban),
+		countries: make(map[string][]*store.Ban),
+		users:     make(map[uint32][]*store.Ban),
+	}
+	for _, b := range all {
+		switch b.Kind {
+		case store.BanIP, store.BanCIDR:
+			nets, err := util.ParseCIDRs([]string{b.Value})
+			if err != nil {
+				log.Errorf("Ignoring invalid ban %d: %v", b.BanID, err)
+				continue
+			}
+			l.nets.insert(nets[0], b)
+		case store.BanASN:
+			asn := util.StringToUInt32(b.Value, 0)
+			l.asns[asn] = append(l.asns[asn], b)
+		case store.BanCountry:
+			l.countries[b.Value] = append(l.countries[b.Value], b)
+		case store.BanUser:
+			userID := util.StringToUInt32(b.Value, 0)
+			l.users[userID] = append(l.users[userID], b)
+		}
+	}
+	return l
+}
+
+var (
+	bansMu = &sync.RWMutex{}
+	bans   = newBanList(nil)
+)
+
+// loadBans reads the bans from the store. This is also called by the StatWorker so bans added
+// to the store directly are picked up without a restart.
+func loadBans() error {
+	all, err := db.Bans()
+	if err != nil {
+		return err
+	}
+	newBans := newBanList(all)
+	bansMu.Lock()
+	bans = newBans
+	bansMu.Unlock()
+	return nil
+}
+
+// Bans returns a copy of every ban, including those which have expired but not yet been pruned
+func Bans() []store.Ban {
+	bansMu.RLock()
+	defer bansMu.RUnlock()
+	all := make([]store.Ban, 0, len(bans.all))
+	for _, b := range bans.all {
+		all = append(all, *b)
+	}
+	return all
+}
+
+// normaliseBan validates the value of the ban, converting it to the form it is matched in
+func normaliseBan(b *store.Ban) error {
+	value := strings.TrimSpace(b.Value)
+	switch b.Kind {
+	case store.BanIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return errors.Wrapf(consts.ErrMalformedRequest, "Invalid ip: %s", value)
+		}
+		b.Value = ip.String()
+	case store.BanCIDR:
+		_, n, err := net.ParseCIDR(value)
+		if err != nil {
+			return errors.Wrapf(consts.ErrMalformedRequest, "Invalid cidr: %s", value)
+		}
+		b.Value = n.String()
+	case store.BanASN:
+		if len(value) > 2 && strings.EqualFold(value[:2], "AS") {
+			value = value[2:]
+		}
+		asn, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || asn == 0 {
+			return errors.Wrapf(consts.ErrMalformedRequest, "Invalid asn: %s", b.Value)
+		}
+		b.Value = strconv.FormatUint(asn, 10)
+	case store.BanCountry:
+		if len(value) != 2 {
+			return errors.Wrapf(consts.ErrMalformedRequest, "Invalid country code: %s", value)
+		}
+		b.Value = strings.ToUpper(value)
+	case store.BanUser:
+		userID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || userID == 0 {
+			return errors.Wrapf(consts.ErrMalformedRequest, "Invalid user id: %s", value)
+		}
+		b.Value = strconv.FormatUint(userID, 10)
+	default:
+		return errors.Wrapf(consts.ErrMalformedRequest, "Invalid ban kind: %s", b.Kind)
+	}
+	return nil
+}
+
+// BanAdd validates and persists a new ban, which applies to requests immediately
+func BanAdd(b *store.Ban) error {
+	if err := normaliseBan(b); err != nil {
+		return err
+	}
+	now := util.Now()
+	if b.Expired(now) {
+		return errors.Wrap(consts.ErrMalformedRequest, "Ban expiry must be in the future")
+	}
+	if b.CreatedOn.IsZero() {
+		b.CreatedOn = now
+	}
+	if err := db.BanAdd(b); err != nil {
+		return err
+	}
+	ban := *b
+	bansMu.Lock()
+	bans = newBanList(append(append([]*store.Ban{}, bans.all...), &ban))
+	bansMu.Unlock()
+	return nil
+}
+
+// BanDelete lifts a ban
+func BanDelete(banID uint32) error {
+	if err := db.BanDelete(banID); err != nil {
+		return err
+	}
+	bansMu.Lock()
+	defer bansMu.Unlock()
+	var remaining []*store.Ban
+	for _, b := range bans.all {
+		if b.BanID != banID {
+			remaining = append(remaining, b)
+		}
+	}
+	bans = newBanList(remaining)
+	return nil
+}
+
+// pruneBans removes the bans which have expired, returning the number removed
+func pruneBans(now time.Time) int {
+	var expired []uint32
+	bansMu.RLock()
+	for _, b := range bans.all {
+		if b.Expired(now) {
+			expired = append(expired, b.BanID)
+		}
+	}
+	bansMu.RUnlock()
+	removed := 0
+	for _, banID := range expired {
+		if err := BanDelete(banID); err != nil {
+			log.Errorf("Failed to remove expired ban: %v", err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// banned returns the active ban matching the address. Addresses are checked against the banned
+// ranges first as they do not require a geo database lookup.
+func banned(ip net.IP) (store.Ban, bool) {
+	now := time.Now()
+	bansMu.RLock()
+	defer bansMu.RUnlock()
+	if b := bans.nets.match(ip, now); b != nil {
+		return *b, true
+	}
+	if len(bans.asns) == 0 && len(bans.countries) == 0 {
+		return store.Ban{}, false
+	}
+	loc := geodb.GetLocation(ip)
+	for _, matched := range [][]*store.Ban{bans.asns[loc.ASN], bans.countries[loc.ISOCode]} {
+		for _, b := range matched {
+			if !b.Expired(now) {
+				return *b, true
+			}
+		}
+	}
+	return store.Ban{}, false
+}
+
+// userBanned returns the active ban of the user
+func userBanned(userID uint32) (store.Ban, bool) {
+	now := time.Now()
+	bansMu.RLock()
+	defer bansMu.RUnlock()
+	for _, b := range bans.users[userID] {
+		if !b.Expired(now) {
+			return *b, true
+		}
+	}
+	return store.Ban{}, false
+}
+
+// banMessage is the error sent to banned clients
+func banMessage(b store.Ban) string {
+	msg := responseStringMap[msgBanned].Error()
+	if b.Reason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, b.Reason)
+	}
+	return msg
+}
+
+// requestBanned checks the address a HTTP request was made from against the bans, sending the
+// ban to the client if it matched
+func requestBanned(c *gin.Context) bool {
+	ip, _, err := getIP(&query{}, false, c)
+	if err != nil {
+		return false
+	}
+	b, found := banned(ip)
+	if !found {
+		return false
+	}
+	c.Data(int(msgBanned), gin.MIMEPlain, responseError(banMessage(b)))
+	return true
+}
@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/geo"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+type banTestGeo struct{}
+
+func (g banTestGeo) GetLocation(ip net.IP) geo.Location {
+	if ip.Equal(net.ParseIP("40.40.40.40")) {
+		return geo.Location{ISOCode: "XX", ASN: 64496}
+	}
+	if ip.Equal(net.ParseIP("41.41.41.41")) {
+		return geo.Location{ISOCode: "YY", ASN: 64497}
+	}
+	return geo.Location{}
+}
+
+func (g banTestGeo) Close() {}
+
+func TestBanTrie(t *testing.T) {
+	now := time.Now()
+	trie := &banTrie{}
+	insert := func(cidr string, ban *store.Ban) {
+		nets, err := util.ParseCIDRs([]string{cidr})
+		require.NoError(t, err)
+		trie.insert(nets[0], ban)
+	}
+	wide := &store.Ban{BanID: 1}
+	narrow := &store.Ban{BanID: 2}
+	expired := &store.Ban{BanID: 3, Expires: now.Add(-time.Minute)}
+	v6 := &store.Ban{BanID: 4}
+	insert("10.0.0.0/8", wide)
+	insert("10.1.0.0/16", narrow)
+	insert("10.1.2.3", expired)
+	insert("2001:db8::/32", v6)
+	for _, tc := range []struct {
+		ip  string
+		ban *store.Ban
+	}{
+		{"10.200.0.1", wide},
+		{"10.1.0.1", narrow},
+		{"10.1.2.3", narrow},
+		{"11.0.0.1", nil},
+		{"2001:db8::1", v6},
+		{"2001:db9::1", nil},
+		{"::ffff:10.1.0.1", narrow},
+	} {
+		require.Equal(t, tc.ban, trie.match(net.ParseIP(tc.ip), now), tc.ip)
+	}
+	require.Nil(t, trie.match(nil, now))
+}
+
+func TestBans(t *testing.T) {
+	oldGeo := geodb
+	defer func() { geodb = oldGeo }()
+	geodb = banTestGeo{}
+	defer func() {
+		for _, b := range Bans() {
+			_ = BanDelete(b.BanID)
+		}
+	}()
+
+	for _, b := range []store.Ban{
+		{Kind: store.BanIP, Value: "1.2.3"},
+		{Kind: store.BanCIDR, Value: "10.0.0.0/33"},
+		{Kind: store.BanASN, Value: "ASX"},
+		{Kind: store.BanASN, Value: "0"},
+		{Kind: store.BanCountry, Value: "USA"},
+		{Kind: store.BanUser, Value: "0"},
+		{Kind: store.BanUser, Value: "bob"},
+		{Kind: "account", Value: "1"},
+		{Kind: store.BanIP, Value: "1.2.3.4", Expires: time.Now().Add(-time.Minute)},
+	} {
+		require.True(t, errors.Is(BanAdd(&b), consts.ErrMalformedRequest), b.Value)
+	}
+
+	cidr := store.Ban{Kind: store.BanCIDR, Value: "30.30.30.1/24", Reason: "Abusive network"}
+	asn := store.Ban{Kind: store.BanASN, Value: "as64496"}
+	country := store.Ban{Kind: store.BanCountry, Value: "yy", Expires: time.Now().Add(time.Hour)}
+	for _, b := range []*store.Ban{&cidr, &asn, &country} {
+		require.NoError(t, BanAdd(b))
+		require.NotZero(t, b.BanID)
+	}
+	require.Equal(t, "30.30.30.0/24", cidr.Value)
+	require.Equal(t, "64496", asn.Value)
+	require.Equal(t, "YY", country.Value)
+	require.Len(t, Bans(), 3)
+
+	for _, tc := range []struct {
+		ip    string
+		banID uint32
+	}{
+		{"30.30.30.30", cidr.BanID},
+		{"30.30.31.30", 0},
+		{"40.40.40.40", asn.BanID},
+		{"41.41.41.41", country.BanID},
+	} {
+		b, found := banned(net.ParseIP(tc.ip))
+		require.Equal(t, tc.banID != 0, found, tc.ip)
+		require.Equal(t, tc.banID, b.BanID, tc.ip)
+	}
+	b, _ := banned(net.ParseIP("30.30.30.30"))
+	require.Equal(t, "Banned: Abusive network", banMessage(b))
+
+	// Bans are checked against the announced peer address and the address of the request
+	rh := NewBitTorrentHandler()
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	announce := func(ip string) errCode {
+		req := testReq{Ih: tor.InfoHash, PID: testLeechers[0].PeerID, IP: ip, Port: "4000",
+			Uploaded: "0", Downloaded: "0", left: "1000", event: string(consts.STARTED), PK: testUsers[0].Passkey}
+		w := performRequest(rh, "GET", fmt.Sprintf("/announce/%s?%s", testUsers[0].Passkey,
+			req.ToValues().Encode()), nil, nil)
+		return errCode(w.Code)
+	}
+	require.Equal(t, msgBanned, announce("30.30.30.30"))
+	require.Equal(t, msgOk, announce("30.30.31.30"))
+	remote := store.Ban{Kind: store.BanIP, Value: "50.50.50.50"}
+	require.NoError(t, BanAdd(&remote))
+	require.Equal(t, msgBanned, announce("30.30.31.30"))
+	scrape := scrapeReq{PK: testUsers[0].Passkey, InfoHashes: []store.InfoHash{tor.InfoHash}}
+	w := performRequest(rh, "GET", fmt.Sprintf("/scrape/%s?%s", scrape.PK, scrape.ToValues().Encode()), nil, nil)
+	require.Equal(t, msgBanned, errCode(w.Code))
+
+	// Lifting and expiring bans
+	require.NoError(t, BanDelete(remote.BanID))
+	require.Equal(t, consts.ErrInvalidBan, BanDelete(remote.BanID))
+	require.Equal(t, msgOk, announce("30.30.31.30"))
+	require.Equal(t, 0, pruneBans(time.Now()))
+	require.Equal(t, 1, pruneBans(time.Now().Add(2*time.Hour)))
+	_, found := banned(net.ParseIP("41.41.41.41"))
+	require.False(t, found)
+	require.Len(t, Bans(), 2)
+
+	// User bans block the account wherever it announces from
+	account := store.Ban{Kind: store.BanUser, Value: fmt.Sprintf(" %d ", testUsers[0].UserID)}
+	require.NoError(t, BanAdd(&account))
+	require.Equal(t, fmt.Sprintf("%d", testUsers[0].UserID), account.Value)
+	require.Equal(t, msgBanned, announce("30.30.31.30"))
+	require.NoError(t, BanDelete(account.BanID))
+	require.Equal(t, msgOk, announce("30.30.31.30"))
+}
@@ -0,0 +1,95 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/pkg/errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// bonusMu guards the BonusPoints of all users. Points are persisted with the rest of the user
+// stats on the next sync.
+var bonusMu = &sync.RWMutex{}
+
+// historySeedTime returns how long the user has spent seeding the torrent
+func historySeedTime(userID uint32, ih store.InfoHash) time.Duration {
+	historyMu.RLock()
+	defer historyMu.RUnlock()
+	if h, found := history[store.HistoryKey{UserID: userID, InfoHash: ih}]; found {
+		return h.SeedTime
+	}
+	return 0
+}
+
+// accrueBonus credits the user with the bonus points earned seeding the torrent over the
+// elapsed time
+func accrueBonus(user *store.User, tor *store.Torrent, elapsed time.Duration, now time.Time) {
+	if elapsed <= 0 {
+		return
+	}
+	var age time.Duration
+	if !tor.CreatedOn.IsZero() {
+		age = now.Sub(tor.CreatedOn)
+	}
+	seeders, _ := tor.Peers.Counts()
+	rate := config.Tracker.Bonus.Rate(tor.Size, seeders, historySeedTime(user.UserID, tor.InfoHash), age)
+	if rate <= 0 {
+		return
+	}
+	bonusMu.Lock()
+	user.BonusPoints += rate * elapsed.Hours()
+	bonusMu.Unlock()
+	atomic.AddUint32(&user.Writes, 1)
+}
+
+// bonusSnapshot returns copies of the users taken while holding bonusMu so their BonusPoints
+// can be read without racing the users announcing
+func bonusSnapshot(batch []*store.User) []*store.User {
+	snapshot := make([]*store.User, len(batch))
+	bonusMu.RLock()
+	defer bonusMu.RUnlock()
+	for i, u := range batch {
+		c := *u
+		snapshot[i] = &c
+	}
+	return snapshot
+}
+
+// BonusPoints returns the current bonus point balance of the user
+func BonusPoints(user *store.User) float64 {
+	bonusMu.RLock()
+	defer bonusMu.RUnlock()
+	return user.BonusPoints
+}
+
+// BonusGrant adds points to the users balance, returning the new balance
+func BonusGrant(user *store.User, points float64) (float64, error) {
+	if points <= 0 {
+		return 0, errors.Wrap(consts.ErrMalformedRequest, "Points must be greater than 0")
+	}
+	bonusMu.Lock()
+	user.BonusPoints += points
+	balance := user.BonusPoints
+	bonusMu.Unlock()
+	atomic.AddUint32(&user.Writes, 1)
+	return balance, nil
+}
+
+// BonusSpend removes points from the users balance, returning the new balance. The balance is
+// left unchanged if the user does not have enough points.
+func BonusSpend(user *store.User, points float64) (float64, error) {
+	if points <= 0 {
+		return 0, errors.Wrap(consts.ErrMalformedRequest, "Points must be greater than 0")
+	}
+	bonusMu.Lock()
+	defer bonusMu.Unlock()
+	if user.BonusPoints < points {
+		return user.BonusPoints, consts.ErrInsufficientPoints
+	}
+	user.BonusPoints -= points
+	atomic.AddUint32(&user.Writes, 1)
+	return user.BonusPoints, nil
+}
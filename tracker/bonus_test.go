@@ -0,0 +1,46 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/stretchr/testify/require"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBonusPoints(t *testing.T) {
+	formula := config.Tracker.Bonus
+	defer func() { config.Tracker.Bonus = formula }()
+	config.Tracker.Bonus = config.BonusFormula{Base: 3600}
+	s := newTestSwarm(t)
+	usr := s.usr
+	announce := func(left string, event consts.AnnounceType) {
+		s.mustAnnounce(testReq{left: left, event: string(event)})
+	}
+	rewind := func() { s.rewind(testLeechers[0].PeerID, time.Minute) }
+	// Time spent leeching does not earn points
+	announce("1000", consts.STARTED)
+	rewind()
+	announce("0", consts.COMPLETED)
+	require.Equal(t, 0.0, BonusPoints(usr))
+	rewind()
+	announce("0", consts.ANNOUNCE)
+	require.InDelta(t, 60.0, BonusPoints(usr), 1)
+	// Accrued points mark the user for the next sync
+	writes := atomic.LoadUint32(&usr.Writes)
+	accrueBonus(usr, s.tor, time.Second, time.Now())
+	require.Equal(t, writes+1, atomic.LoadUint32(&usr.Writes))
+	require.InDelta(t, 61.0, BonusPoints(usr), 1)
+
+	_, err := BonusGrant(usr, 0)
+	require.Error(t, err)
+	balance, err := BonusGrant(usr, 40)
+	require.NoError(t, err)
+	require.InDelta(t, 101.0, balance, 1)
+	_, err = BonusSpend(usr, 1000)
+	require.Equal(t, consts.ErrInsufficientPoints, err)
+	balance, err = BonusSpend(usr, 90)
+	require.NoError(t, err)
+	require.InDelta(t, 11.0, balance, 1)
+}
@@ -0,0 +1,251 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Names of the cheat detection rules as recorded in cheat reports
+const (
+	cheatMaxSpeed     = "max_speed"
+	cheatNoLeechers   = "no_leechers"
+	cheatGhostPeers   = "ghost_peers"
+	cheatSpeedProfile = "speed_profile"
+	cheatUserAgent    = "user_agent"
+)
+
+// cheatReportWindow is how long a rule is only reported once for per user and torrent, the
+// rule is still acted on for every flagged announce
+const cheatReportWindow = time.Hour
+
+// cheatReportKey identifies the flagged announces reported together
+type cheatReportKey struct {
+	UserID   uint32
+	InfoHash store.InfoHash
+	Rule     string
+}
+
+var (
+	cheatMu = &sync.RWMutex{}
+	// leechersSeen holds the last time each swarm was seen with leechers
+	leechersSeen = make(map[store.InfoHash]time.Time)
+	// cheatReported holds when each rule was last reported for a user and torrent
+	cheatReported = make(map[cheatReportKey]time.Time)
+	// pendingCheatReports holds the reports not yet written to the store by the StatWorker
+	pendingCheatReports []*store.CheatReport
+)
+
+// CheatReports returns the recorded cheat reports of the user, oldest first. A userID of 0
+// returns the reports of every user and an empty rule returns the reports of every rule.
+// Reports still waiting to be written to the store are included.
+func CheatReports(userID uint32, rule string) ([]store.CheatReport, error) {
+	reports, err := db.CheatReports(userID)
+	if err != nil {
+		return nil, err
+	}
+	cheatMu.RLock()
+	reports = append(reports, pendingCheatReports...)
+	var matched []store.CheatReport
+	for _, r := range reports {
+		if (userID == 0 || r.UserID == userID) && (rule == "" || r.Rule == rule) {
+			matched = append(matched, *r)
+		}
+	}
+	cheatMu.RUnlock()
+	return matched, nil
+}
+
+// cheatReportDue checks if the rule has not been reported for the user and torrent within the
+// report window, marking it as reported if so
+func cheatReportDue(key cheatReportKey, now time.Time) bool {
+	cheatMu.Lock()
+	defer cheatMu.Unlock()
+	if last, found := cheatReported[key]; found && now.Sub(last) < cheatReportWindow {
+		return false
+	}
+	cheatReported[key] = now
+	return true
+}
+
+// pruneCheatReports forgets the rules reported before the report window
+func pruneCheatReports(now time.Time) {
+	cheatMu.Lock()
+	defer cheatMu.Unlock()
+	for key, last := range cheatReported {
+		if now.Sub(last) >= cheatReportWindow {
+			delete(cheatReported, key)
+		}
+	}
+}
+
+// cheatReportSync writes the queued cheat reports to the store. Reports which could not be
+// written stay queued for the next sync.
+func cheatReportSync() error {
+	cheatMu.RLock()
+	batch := append([]*store.CheatReport{}, pendingCheatReports...)
+	cheatMu.RUnlock()
+	written := 0
+	var err error
+	for _, r := range batch {
+		// The store may set the id, the queued report is still shared with CheatReports
+		report := *r
+		if err = db.CheatReportAdd(&report); err != nil {
+			break
+		}
+		written++
+	}
+	// Reports are only ever appended by announces so the written ones are still at the front
+	cheatMu.Lock()
+	pendingCheatReports = pendingCheatReports[written:]
+	cheatMu.Unlock()
+	return err
+}
+
+// swarmLeecherless records if the swarm has leechers other than the peer and returns true if
+// it has had none for the last two announce intervals. The first time a swarm is checked
+// counts as having seen leechers so swarms are not flagged right after a restart.
+func swarmLeecherless(tor *store.Torrent, peer *store.Peer, now time.Time) bool {
+	_, leechers := tor.Peers.Counts()
+	if !peer.IsSeeder() && leechers > 0 {
+		// Nobody else can download what a lone leecher uploads
+		leechers--
+	}
+	cheatMu.Lock()
+	defer cheatMu.Unlock()
+	seen, found := leechersSeen[tor.InfoHash]
+	if leechers > 0 || !found {
+		leechersSeen[tor.InfoHash] = now
+		return false
+	}
+	return now.Sub(seen) > 2*config.Tracker.AnnounceIntervalParsed
+}
+
+// checkCheats runs the cheat detection rules against the amounts transferred by the peer since
+// its previous announce, recording a report for each rule broken and applying its action.
+// Returns the amount of the upload which should still be credited.
+func checkCheats(peer *store.Peer, tor *store.Torrent, user *store.User, uploaded uint64, downloaded uint64,
+	now time.Time) uint64 {
+	rules := config.Tracker.Cheat
+	leecherless := rules.NoLeechers && swarmLeecherless(tor, peer, now)
+	probe, ghosted := recordGhostAnnounce(user.UserID, tor.InfoHash, uploaded, downloaded, now)
+	credited := uploaded
+	// Checked regardless of the upload so spoofed clients are caught before they report any.
+	// Rejected announces never get this far.
+	if rules.UserAgent && rules.UserAgentAction != config.CheatReject {
+		if mismatch := userAgentMismatch(peer.UserAgent, peer.PeerID); mismatch != "" {
+			if userAgentReportDue(user.UserID, peer.PeerID, peer.UserAgent, now) {
+				credited = flagCheat(peer, tor, user, cheatUserAgent, rules.UserAgentAction, uploaded, now,
+					mismatch, credited)
+			} else {
+				credited = cheatAction(user, rules.UserAgentAction, credited)
+			}
+		}
+	}
+	if uploaded == 0 {
+		return 0
+	}
+	speed := uint64(atomic.LoadUint32(&peer.SpeedUP))
+	if rules.MaxSpeed > 0 && speed > rules.MaxSpeed {
+		credited = flagUploadCheat(peer, tor, user, cheatMaxSpeed, rules.MaxSpeedAction, uploaded, now,
+			fmt.Sprintf("Upload speed of %s/s exceeds the maximum of %s/s",
+				util.HumanBytesString(speed), util.HumanBytesString(rules.MaxSpeed)), credited)
+	}
+	if leecherless {
+		credited = flagUploadCheat(peer, tor, user, cheatNoLeechers, rules.NoLeechersAction, uploaded, now,
+			fmt.Sprintf("Uploaded %s to a swarm without leechers since %s",
+				util.HumanBytesString(uploaded), lastLeecherSeen(tor.InfoHash).Format(time.RFC3339)), credited)
+	}
+	if ghosted {
+		credited = flagUploadCheat(peer, tor, user, cheatGhostPeers, rules.GhostPeersAction, uploaded, now,
+			ghostEvidence(probe, uploaded), credited)
+	}
+	if rules.SpeedProfile && speed > 0 {
+		if anomaly := profileSpeed(user.UserID, peer, speed, now); anomaly != "" {
+			credited = flagUploadCheat(peer, tor, user, cheatSpeedProfile, rules.SpeedProfileAction, uploaded, now,
+				anomaly, credited)
+		}
+	}
+	return credited
+}
+
+// lastLeecherSeen returns the last time the swarm was seen with leechers
+func lastLeecherSeen(ih store.InfoHash) time.Time {
+	cheatMu.RLock()
+	defer cheatMu.RUnlock()
+	return leechersSeen[ih]
+}
+
+// flagUploadCheat flags an upload breaking one of the upload rules. The rule is only reported
+// once per report window for the user and torrent, but is acted on for every upload.
+func flagUploadCheat(peer *store.Peer, tor *store.Torrent, user *store.User, rule string, action string,
+	uploaded uint64, now time.Time, detail string, credited uint64) uint64 {
+	if !cheatReportDue(cheatReportKey{UserID: user.UserID, InfoHash: tor.InfoHash, Rule: rule}, now) {
+		return cheatAction(user, action, credited)
+	}
+	return flagCheat(peer, tor, user, rule, action, uploaded, now, detail, credited)
+}
+
+// flagCheat queues a cheat report for the StatWorker to write and applies the action of the
+// rule, returning the amount of the upload which should still be credited
+func flagCheat(peer *store.Peer, tor *store.Torrent, user *store.User, rule string, action string,
+	uploaded uint64, now time.Time, detail string, credited uint64) uint64 {
+	report := &store.CheatReport{
+		UserID:    user.UserID,
+		InfoHash:  tor.InfoHash,
+		IP:        peer.IP.String(),
+		Client:    peer.Client,
+		Rule:      rule,
+		Action:    action,
+		Uploaded:  uploaded,
+		Speed:     uint64(atomic.LoadUint32(&peer.SpeedUP)),
+		Detail:    detail,
+		CreatedOn: now,
+	}
+	cheatMu.Lock()
+	pendingCheatReports = append(pendingCheatReports, report)
+	cheatMu.Unlock()
+	user.Log().WithFields(log.Fields{
+		"rule":      rule,
+		"action":    action,
+		"info_hash": tor.InfoHash.String(),
+		"ip":        report.IP,
+	}).Warn(detail)
+	return cheatAction(user, action, credited)
+}
+
+// cheatAction applies the action of a cheat rule to the user, returning the amount of the
+// upload which should still be credited
+func cheatAction(user *store.User, action string, credited uint64) uint64 {
+	switch action {
+	case config.CheatDrop:
+		return 0
+	case config.CheatDisableDownload:
+		if user.DownloadEnabled {
+			user.DownloadEnabled = false
+			if err := UserSave(user); err != nil {
+				log.Errorf("Failed to disable downloading for cheating user: %v", err)
+			}
+		}
+		return 0
+	case config.CheatDisable:
+		if _, found := userBanned(user.UserID); !found {
+			ban := store.Ban{
+				Kind:   store.BanUser,
+				Value:  strconv.FormatUint(uint64(user.UserID), 10),
+				Reason: "Disabled for cheating",
+			}
+			if err := BanAdd(&ban); err != nil {
+				log.Errorf("Failed to disable cheating user: %v", err)
+			}
+		}
+		return 0
+	}
+	return credited
+}
@@ -0,0 +1,97 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCheatDetection(t *testing.T) {
+	rules := config.Tracker.Cheat
+	defer func() { config.Tracker.Cheat = rules }()
+	s := newTestSwarm(t)
+	usr, tor := s.usr, s.tor
+	announce := func(uploaded string, event consts.AnnounceType) {
+		s.mustAnnounce(testReq{Uploaded: uploaded, event: string(event)})
+	}
+	rewind := func() { s.rewind(testLeechers[0].PeerID, 10*time.Second) }
+
+	// 10000 bytes over 10 seconds is 1000 B/s
+	config.Tracker.Cheat = config.CheatRules{MaxSpeed: 500, MaxSpeedAction: config.CheatDrop}
+	announce("0", consts.STARTED)
+	rewind()
+	announce("10000", consts.ANNOUNCE)
+	require.Equal(t, uint64(0), usr.Uploaded)
+	reports, err := CheatReports(usr.UserID, cheatMaxSpeed)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, config.CheatDrop, reports[0].Action)
+	require.Equal(t, uint64(10000), reports[0].Uploaded)
+	require.InDelta(t, 1000, reports[0].Speed, 10)
+
+	// Uploads below the limit are credited
+	rewind()
+	announce("13000", consts.ANNOUNCE)
+	require.Equal(t, uint64(3000), usr.Uploaded)
+
+	// The rule is acted on again but only reported once per report window
+	config.Tracker.Cheat.MaxSpeedAction = config.CheatDisableDownload
+	rewind()
+	announce("23000", consts.ANNOUNCE)
+	require.Equal(t, uint64(3000), usr.Uploaded)
+	require.False(t, usr.DownloadEnabled)
+	reports, err = CheatReports(usr.UserID, cheatMaxSpeed)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	// The lone seeder has nobody to upload to
+	config.Tracker.Cheat = config.CheatRules{NoLeechers: true, NoLeechersAction: config.CheatLog}
+	rewind()
+	announce("24000", consts.ANNOUNCE)
+	reports, err = CheatReports(usr.UserID, cheatNoLeechers)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+	cheatMu.Lock()
+	leechersSeen[tor.InfoHash] = time.Now().Add(-3 * config.Tracker.AnnounceIntervalParsed)
+	cheatMu.Unlock()
+	rewind()
+	announce("25000", consts.ANNOUNCE)
+	reports, err = CheatReports(usr.UserID, cheatNoLeechers)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, uint64(1000), reports[0].Uploaded)
+	// Logged reports are still credited
+	require.Equal(t, uint64(5000), usr.Uploaded)
+	reports, err = CheatReports(usr.UserID, "")
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	// Queued reports are written to the store by the stat worker
+	require.NoError(t, cheatReportSync())
+	cheatMu.RLock()
+	require.Empty(t, pendingCheatReports)
+	cheatMu.RUnlock()
+	stored, err := db.CheatReports(usr.UserID)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	reports, err = CheatReports(usr.UserID, "")
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	pruneCheatReports(time.Now().Add(cheatReportWindow))
+	cheatMu.RLock()
+	require.Empty(t, cheatReported)
+	cheatMu.RUnlock()
+
+	// Disabling bans the account so its announces are refused
+	config.Tracker.Cheat = config.CheatRules{MaxSpeed: 500, MaxSpeedAction: config.CheatDisable}
+	rewind()
+	announce("33000", consts.ANNOUNCE)
+	ban, found := userBanned(usr.UserID)
+	require.True(t, found)
+	defer func() { _ = BanDelete(ban.BanID) }()
+	rewind()
+	code, _ := s.announce(testReq{Uploaded: "33000"})
+	require.Equal(t, msgBanned, code)
+}
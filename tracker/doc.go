@@ -5,6 +5,9 @@
 //   - /:passkey/announce
 //   - /:passkey/scrape
 //
+// A BEP15 UDP tracker is also available via UDPServer which shares the same
+// announce and scrape logic.
+//
 // API routes:
 //
 //   - General
@@ -0,0 +1,95 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+var (
+	eventsMu = &sync.RWMutex{}
+	// events holds every scheduled multiplier event. Ended events are kept until deleted so
+	// they can still be listed.
+	events []store.Event
+)
+
+// loadEvents reads the scheduled events from the store. This is also called by the StatWorker
+// so events added to the store directly are picked up without a restart.
+func loadEvents() error {
+	evs, err := db.Events()
+	if err != nil {
+		return err
+	}
+	newEvents := make([]store.Event, 0, len(evs))
+	for _, e := range evs {
+		newEvents = append(newEvents, *e)
+	}
+	eventsMu.Lock()
+	events = newEvents
+	eventsMu.Unlock()
+	return nil
+}
+
+// Events returns a copy of every scheduled event
+func Events() []store.Event {
+	eventsMu.RLock()
+	defer eventsMu.RUnlock()
+	return append([]store.Event{}, events...)
+}
+
+// EventAdd validates and persists a new event, which applies to announces as soon as it starts
+func EventAdd(e *store.Event) error {
+	if e.Name == "" {
+		return errors.Wrap(consts.ErrMalformedRequest, "Event name cannot be empty")
+	}
+	if e.MultiUp < 0 || e.MultiDn < 0 {
+		return errors.Wrap(consts.ErrMalformedRequest, "Event multipliers cannot be negative")
+	}
+	if !e.EndOn.After(e.StartOn) {
+		return errors.Wrap(consts.ErrMalformedRequest, "Event must end after it starts")
+	}
+	if e.CreatedOn.IsZero() {
+		e.CreatedOn = util.Now()
+	}
+	if err := db.EventAdd(e); err != nil {
+		return err
+	}
+	eventsMu.Lock()
+	events = append(events, *e)
+	eventsMu.Unlock()
+	return nil
+}
+
+// EventDelete removes an event, ending it immediately if it is active
+func EventDelete(eventID uint32) error {
+	if err := db.EventDelete(eventID); err != nil {
+		return err
+	}
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+	for i, e := range events {
+		if e.EventID == eventID {
+			events = append(events[:i], events[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// eventMultipliers returns the product of the multipliers of every event active at the time
+// provided which applies to the user and torrent
+func eventMultipliers(tor *store.Torrent, user *store.User, now time.Time) (float64, float64) {
+	up, down := 1.0, 1.0
+	eventsMu.RLock()
+	defer eventsMu.RUnlock()
+	for _, e := range events {
+		if e.Active(now) && e.Matches(user.RoleID, tor.Category) {
+			up *= e.MultiUp
+			down *= e.MultiDn
+		}
+	}
+	return up, down
+}
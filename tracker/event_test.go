@@ -0,0 +1,54 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEvents(t *testing.T) {
+	s := newTestSwarm(t)
+	usr, movie := s.usr, s.tor
+	movie.Category = "Movies"
+	other := s.addTorrent()
+	announce := func(tor *store.Torrent, uploaded string, downloaded string, event consts.AnnounceType) {
+		s.mustAnnounce(testReq{Ih: tor.InfoHash, Uploaded: uploaded, Downloaded: downloaded, left: "1000",
+			event: string(event)})
+	}
+	now := time.Now()
+	require.Error(t, EventAdd(&store.Event{Name: "backwards", MultiUp: 1, StartOn: now, EndOn: now.Add(-time.Hour)}))
+	freeleech := store.Event{Name: "Freeleech", MultiUp: 2, MultiDn: 0, Categories: []string{"movies"},
+		StartOn: now.Add(-time.Minute), EndOn: now.Add(time.Hour)}
+	require.NoError(t, EventAdd(&freeleech))
+	// Events for other roles or which have not started do not apply
+	require.NoError(t, EventAdd(&store.Event{Name: "Other role", MultiUp: 10, MultiDn: 10,
+		RoleIDs: []uint32{usr.RoleID + 1000}, StartOn: now.Add(-time.Minute), EndOn: now.Add(time.Hour)}))
+	require.NoError(t, EventAdd(&store.Event{Name: "Future", MultiUp: 10, MultiDn: 10,
+		StartOn: now.Add(time.Hour), EndOn: now.Add(2 * time.Hour)}))
+	require.Len(t, Events(), 3)
+
+	announce(movie, "0", "0", consts.STARTED)
+	announce(movie, "1000", "5000", consts.ANNOUNCE)
+	require.Equal(t, uint64(2000), usr.Uploaded)
+	require.Equal(t, uint64(0), usr.Downloaded)
+	announce(other, "0", "0", consts.STARTED)
+	announce(other, "1000", "5000", consts.ANNOUNCE)
+	require.Equal(t, uint64(3000), usr.Uploaded)
+	require.Equal(t, uint64(5000), usr.Downloaded)
+
+	// Deleting ends the event immediately
+	require.NoError(t, EventDelete(freeleech.EventID))
+	require.Equal(t, consts.ErrInvalidEvent, EventDelete(freeleech.EventID))
+	announce(movie, "2000", "10000", consts.ANNOUNCE)
+	require.Equal(t, uint64(10000), usr.Downloaded)
+
+	// Events added to the store directly are picked up on reload
+	require.NoError(t, db.EventAdd(&freeleech))
+	require.NoError(t, loadEvents())
+	require.Len(t, Events(), 3)
+	for _, e := range Events() {
+		require.NoError(t, EventDelete(e.EventID))
+	}
+}
@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	historyMu = &sync.RWMutex{}
+	history   = make(map[store.HistoryKey]*store.History)
+	// hnrCounts holds the number of flagged hit and runs for each user
+	hnrCounts = make(map[uint32]int)
+)
+
+// loadHistory reads the users transfer histories from the store and rebuilds the hit and run
+// counts from them
+func loadHistory() {
+	hist, err := db.Histories()
+	if err != nil {
+		log.Fatalf("Failed to load user history: %s", err)
+	}
+	newHistory := make(map[store.HistoryKey]*store.History)
+	newCounts := make(map[uint32]int)
+	for _, h := range hist {
+		newHistory[h.Key()] = h
+		if h.HnR {
+			newCounts[h.UserID]++
+		}
+	}
+	historyMu.Lock()
+	history = newHistory
+	hnrCounts = newCounts
+	historyMu.Unlock()
+}
+
+// updateHistory credits an announce to the users history of the torrent, creating the entry
+// on their first announce. The elapsed time is counted as seeding or leeching depending
+// on the state of the peer before the announce.
+func updateHistory(userID uint32, ih store.InfoHash, uploaded uint64, downloaded uint64,
+	elapsed time.Duration, seeding bool, completed bool, now time.Time) {
+	key := store.HistoryKey{UserID: userID, InfoHash: ih}
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	h, found := history[key]
+	if !found {
+		h = &store.History{UserID: userID, InfoHash: ih, AnnounceFirst: now}
+		history[key] = h
+	}
+	h.Uploaded += uploaded
+	h.Downloaded += downloaded
+	if elapsed > 0 {
+		if seeding {
+			h.SeedTime += elapsed
+		} else {
+			h.LeechTime += elapsed
+		}
+	}
+	// Completing the same torrent again keeps the original snatch time
+	if completed && !h.Completed() {
+		h.CompletedOn = now
+	}
+	h.AnnounceLast = now
+	h.Active = true
+	h.Writes++
+}
+
+// historyInactive marks the users history of the torrent as no longer having an active session
+func historyInactive(userID uint32, ih store.InfoHash) {
+	historyMu.Lock()
+	if h, found := history[store.HistoryKey{UserID: userID, InfoHash: ih}]; found && h.Active {
+		h.Active = false
+		h.Writes++
+	}
+	historyMu.Unlock()
+}
+
+// findHistory returns copies of the history entries matching the filter, most recent
+// announce first
+func findHistory(match func(h *store.History) bool) []store.History {
+	var hist []store.History
+	historyMu.RLock()
+	for _, h := range history {
+		if match(h) {
+			hist = append(hist, *h)
+		}
+	}
+	historyMu.RUnlock()
+	sort.Slice(hist, func(i, j int) bool {
+		return hist[i].AnnounceLast.After(hist[j].AnnounceLast)
+	})
+	return hist
+}
+
+// UserHistory returns the transfer history of a user on every torrent they have announced for
+func UserHistory(userID uint32) []store.History {
+	return findHistory(func(h *store.History) bool {
+		return h.UserID == userID
+	})
+}
+
+// TorrentSnatches returns the history of every user which has completed the torrent
+func TorrentSnatches(ih store.InfoHash) []store.History {
+	return findHistory(func(h *store.History) bool {
+		return h.InfoHash == ih && h.Completed()
+	})
+}
+
+// findDirtyHistory returns copies of up to n history entries with pending changes, most
+// frequently written first. The pending changes of the returned entries are reset.
+func findDirtyHistory(n int) []*store.History {
+	var sorted []*store.History
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	for _, h := range history {
+		if h.Writes > 0 {
+			sorted = append(sorted, h)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Writes > sorted[j].Writes
+	})
+	batch := make([]*store.History, util.Min(n, len(sorted)))
+	for i := range batch {
+		h := *sorted[i]
+		batch[i] = &h
+		sorted[i].Writes = 0
+	}
+	return batch
+}
+
+// historySync persists a batch of history entries. On failure the entries are marked dirty
+// again so they are retried on the next sync.
+func historySync(batch []*store.History) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := db.HistorySync(batch); err != nil {
+		historyMu.Lock()
+		for _, b := range batch {
+			if h, found := history[b.Key()]; found {
+				h.Writes++
+			}
+		}
+		historyMu.Unlock()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	s := newTestSwarm(t)
+	usr, tor := s.usr, s.tor
+	announce := func(uploaded, downloaded, left string, event consts.AnnounceType) {
+		s.mustAnnounce(testReq{Uploaded: uploaded, Downloaded: downloaded, left: left, event: string(event)})
+	}
+	require.Empty(t, UserHistory(usr.UserID))
+	announce("0", "0", "1000", consts.STARTED)
+	announce("100", "600", "400", consts.ANNOUNCE)
+	require.Empty(t, TorrentSnatches(tor.InfoHash))
+	announce("200", "1000", "0", consts.COMPLETED)
+	hist := UserHistory(usr.UserID)
+	require.Len(t, hist, 1)
+	require.Equal(t, uint64(200), hist[0].Uploaded)
+	require.Equal(t, uint64(1000), hist[0].Downloaded)
+	require.True(t, hist[0].Active)
+	require.True(t, hist[0].Completed())
+	require.False(t, hist[0].AnnounceFirst.After(hist[0].AnnounceLast))
+
+	// A second session adds to the totals and keeps the original completion time
+	announce("300", "1000", "0", consts.STOPPED)
+	announce("0", "0", "0", consts.STARTED)
+	announce("50", "0", "0", consts.COMPLETED)
+	snatches := TorrentSnatches(tor.InfoHash)
+	require.Len(t, snatches, 1)
+	require.Equal(t, hist[0].CompletedOn, snatches[0].CompletedOn)
+	require.Equal(t, uint64(350), snatches[0].Uploaded)
+	announce("50", "0", "0", consts.STOPPED)
+	require.False(t, UserHistory(usr.UserID)[0].Active)
+
+	dirty := findDirtyHistory(100)
+	require.NotEmpty(t, dirty)
+	require.NoError(t, historySync(dirty))
+	require.Empty(t, findDirtyHistory(100))
+	stored, err := db.Histories()
+	require.NoError(t, err)
+	found := false
+	for _, h := range stored {
+		if h.Key() == snatches[0].Key() {
+			require.Equal(t, uint64(350), h.Uploaded)
+			require.False(t, h.Active)
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestHistoryPublicUser(t *testing.T) {
+	config.Tracker.Public = true
+	defer func() { config.Tracker.Public = false }()
+	rh := NewBitTorrentHandler()
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	announce := func(uploaded, left string, event consts.AnnounceType) {
+		req := testReq{Ih: tor.InfoHash, PID: testLeechers[0].PeerID, IP: "12.34.56.78", Port: "4000",
+			Uploaded: uploaded, Downloaded: "0", left: left, event: string(event)}
+		w := performRequest(rh, "GET", fmt.Sprintf("/announce?%s", req.ToValues().Encode()), nil, nil)
+		require.Equal(t, int(msgOk), w.Code)
+	}
+	// Anonymous peers share the pseudo user so it has no history or leeching limits of its own
+	announce("0", "1000", consts.STARTED)
+	announce("100", "0", consts.COMPLETED)
+	require.Empty(t, TorrentSnatches(tor.InfoHash))
+	for _, h := range UserHistory(publicUserID) {
+		require.NotEqual(t, tor.InfoHash, h.InfoHash)
+	}
+	leechingMu.Lock()
+	_, found := leeching[publicUserID][tor.InfoHash]
+	leechingMu.Unlock()
+	require.False(t, found)
+}
@@ -0,0 +1,85 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// flagHitAndRuns marks every snatch which has passed the configured window without reaching
+// the seed time threshold as a hit and run. Users still seeding the torrent are not flagged
+// as they will eventually reach the threshold. Returns the number of snatches flagged.
+func flagHitAndRuns(now time.Time) int {
+	threshold := config.Tracker.HNRThresholdParsed
+	if threshold <= 0 {
+		return 0
+	}
+	window := config.Tracker.HNRWindowParsed
+	flagged := 0
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	for key, h := range history {
+		if !h.HitAndRun(now, threshold, window) {
+			continue
+		}
+		if tor, err := TorrentGet(key.InfoHash, true); err == nil && tor.Peers.UserSeeding(key.UserID) {
+			continue
+		}
+		h.HnR = true
+		h.HnROn = now
+		h.Writes++
+		hnrCounts[key.UserID]++
+		flagged++
+		log.WithFields(log.Fields{
+			"user_id":   key.UserID,
+			"info_hash": key.InfoHash.String(),
+			"seed_time": h.SeedTime.String(),
+		}).Info("Flagged hit and run")
+	}
+	return flagged
+}
+
+// hnrLimited checks if the user has more hit and runs than their role allows and should not
+// be allowed to download
+func hnrLimited(usr *store.User) bool {
+	if usr.Role == nil || usr.Role.MaxHnR == 0 {
+		return false
+	}
+	historyMu.RLock()
+	count := hnrCounts[usr.UserID]
+	historyMu.RUnlock()
+	return count > int(usr.Role.MaxHnR)
+}
+
+// HitAndRuns returns the flagged hit and runs of a user
+func HitAndRuns(userID uint32) []store.History {
+	return findHistory(func(h *store.History) bool {
+		return h.UserID == userID && h.HnR
+	})
+}
+
+// HitAndRunClear removes the hit and run flag from a users snatch of a torrent. When ih is
+// nil all of the users hit and runs are cleared. Cleared snatches are not flagged again.
+func HitAndRunClear(userID uint32, ih *store.InfoHash) error {
+	historyMu.Lock()
+	defer historyMu.Unlock()
+	cleared := 0
+	for key, h := range history {
+		if key.UserID != userID || !h.HnR || (ih != nil && key.InfoHash != *ih) {
+			continue
+		}
+		h.HnR = false
+		h.Writes++
+		cleared++
+	}
+	if cleared == 0 {
+		return consts.ErrInvalidInfoHash
+	}
+	hnrCounts[userID] -= cleared
+	if hnrCounts[userID] <= 0 {
+		delete(hnrCounts, userID)
+	}
+	return nil
+}
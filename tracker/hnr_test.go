@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestHitAndRuns(t *testing.T) {
+	// Start without snatches left behind by other tests
+	historyMu.Lock()
+	history = make(map[store.HistoryKey]*store.History)
+	hnrCounts = make(map[uint32]int)
+	historyMu.Unlock()
+	s := newTestSwarm(t)
+	s.role.MaxHnR = 1
+	usr := s.usr
+	tors := []*store.Torrent{s.tor, s.addTorrent(), s.addTorrent()}
+	announce := func(tor *store.Torrent, left string, event consts.AnnounceType) errCode {
+		code, _ := s.announce(testReq{Ih: tor.InfoHash, left: left, event: string(event)})
+		return code
+	}
+	for _, tor := range tors {
+		require.Equal(t, msgOk, announce(tor, "1000", consts.STARTED))
+		require.Equal(t, msgOk, announce(tor, "0", consts.COMPLETED))
+	}
+	// Still within the window
+	require.Equal(t, 0, flagHitAndRuns(time.Now()))
+
+	later := time.Now().Add(config.Tracker.HNRWindowParsed + time.Hour)
+	// Seeding for the threshold
+	updateHistory(usr.UserID, tors[0].InfoHash, 0, 0, config.Tracker.HNRThresholdParsed, true, false, time.Now())
+	// Still seeding, may yet reach the threshold
+	require.Equal(t, 0, flagHitAndRuns(later))
+
+	for _, tor := range tors {
+		require.Equal(t, msgOk, announce(tor, "0", consts.STOPPED))
+	}
+	require.Equal(t, 2, flagHitAndRuns(later))
+	require.Equal(t, 0, flagHitAndRuns(later))
+	require.Len(t, HitAndRuns(usr.UserID), 2)
+
+	// Seeding is still allowed with too many hit and runs
+	require.Equal(t, msgHitAndRunLimit, announce(tors[0], "1000", consts.STARTED))
+	require.Equal(t, msgOk, announce(tors[0], "0", consts.STARTED))
+
+	require.NoError(t, HitAndRunClear(usr.UserID, &tors[1].InfoHash))
+	require.Error(t, HitAndRunClear(usr.UserID, &tors[1].InfoHash))
+	require.Len(t, HitAndRuns(usr.UserID), 1)
+	require.False(t, hnrLimited(usr))
+	// Cleared hit and runs are not flagged again
+	require.Equal(t, 0, flagHitAndRuns(later))
+	require.NoError(t, HitAndRunClear(usr.UserID, nil))
+	require.Empty(t, HitAndRuns(usr.UserID))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/viciious/mika/config"
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"github.com/toorop/gin-logrus"
@@ -25,6 +26,7 @@ const (
 	msgMissingPeerID        errCode = 102
 	msgMissingPort          errCode = 103
 	msgInvalidPort          errCode = 104
+	msgInvalidConnectionID  errCode = 105
 	msgInvalidInfoHash      errCode = 150
 	msgInvalidPeerID        errCode = 151
 	msgInvalidNumWant       errCode = 152
@@ -32,6 +34,13 @@ const (
 	msgOk                   errCode = 200
 	msgInfoHashNotFound     errCode = 480
 	msgInvalidAuth          errCode = 490
+	msgHitAndRunLimit       errCode = 491
+	msgDownloadDisabled     errCode = 492
+	msgRoleDownloadDisabled errCode = 493
+	msgLeechingLimit        errCode = 494
+	msgRatioRestricted      errCode = 495
+	msgBanned               errCode = 496
+	msgClientMismatch       errCode = 497
 	msgClientRequestTooFast errCode = 500
 	msgGenericError         errCode = 900
 	msgMalformedRequest     errCode = 901
@@ -46,7 +55,15 @@ var (
 		msgMissingPeerID:        errors.New("peer_id missing from request"),
 		msgMissingPort:          errors.New("port missing from request"),
 		msgInvalidPort:          errors.New("Invalid port"),
+		msgInvalidConnectionID:  errors.New("Invalid connection id"),
 		msgInvalidAuth:          errors.New("Invalid passkey"),
+		msgHitAndRunLimit:       errors.New("Downloading disabled: too many hit and runs"),
+		msgDownloadDisabled:     errors.New("Downloading disabled for your account"),
+		msgRoleDownloadDisabled: errors.New("Downloading disabled for your user class"),
+		msgLeechingLimit:        errors.New("Active download limit reached, finish or stop another torrent first"),
+		msgRatioRestricted:      errors.New("Downloading disabled: ratio below the requirement of your user class"),
+		msgBanned:               errors.New("Banned"),
+		msgClientMismatch:       errors.New("Client does not match its User-Agent"),
 		msgInvalidInfoHash:      errors.New("Invalid info hash"),
 		msgInvalidPeerID:        errors.New("Peer ID invalid"),
 		msgInvalidNumWant:       errors.New("num_want invalid"),
@@ -66,8 +83,9 @@ var (
 //}
 
 // getIP Parses and returns a IP from a query
-// If a IP header exists, it will be used instead of the client provided query parameter
-// If no query IP is provided, the
+// If allowed, the client provided query parameter is used first. Otherwise the forwarded IP
+// headers are used when the request came from a trusted proxy, falling back to the address
+// of the connection itself.
 func getIP(q *query, allowClientIP bool, c *gin.Context) (net.IP, bool, error) {
 	if allowClientIP {
 		for i, k := range [3]announceParam{paramIP, paramIPv4, paramIPv6} {
@@ -86,23 +104,42 @@ func getIP(q *query, allowClientIP bool, c *gin.Context) (net.IP, bool, error) {
 			}
 		}
 	}
-	// Look for forwarded ip in headers
-	for _, header := range []string{"X-Real-IP", "X-Forwarded-For"} {
-		if headerIP := c.Request.Header.Get(header); headerIP != "" {
-			addr := net.ParseIP(headerIP)
-			return addr, strings.Count(headerIP, ":") > 1, nil
+	httpAddr, _, _ := net.SplitHostPort(c.Request.RemoteAddr)
+	remoteIP := net.ParseIP(httpAddr)
+	if remoteIP == nil {
+		return net.IP{}, false, consts.ErrInvalidClient
+	}
+	// Look for forwarded ip in headers, which anybody could set unless they came from our proxies
+	if util.IPInNets(remoteIP, config.Tracker.TrustedProxiesParsed) {
+		if headerIP := net.ParseIP(strings.TrimSpace(c.Request.Header.Get("X-Real-IP"))); headerIP != nil {
+			return headerIP, headerIP.To4() == nil, nil
+		}
+		fwdIP := forwardedIP(c.Request.Header.Values("X-Forwarded-For"), config.Tracker.TrustedProxiesParsed)
+		if fwdIP != nil {
+			return fwdIP, fwdIP.To4() == nil, nil
 		}
 	}
-	httpAddr, _, _ := net.SplitHostPort(c.Request.RemoteAddr)
-	for i := 0; i < len(httpAddr); i++ {
-		switch httpAddr[i] {
-		case '.':
-			return net.ParseIP(httpAddr), false, nil
-		case ':':
-			return net.ParseIP(httpAddr), true, nil
+	return remoteIP, remoteIP.To4() == nil, nil
+}
+
+// forwardedIP returns the right-most X-Forwarded-For hop which is not one of the trusted
+// proxies. Each proxy appends the address it received the request from, so every hop to the
+// left of the first untrusted one could have been written by the client. If every hop is
+// trusted the left-most one is used. Returns nil when the header is missing or a hop which
+// would be used is malformed.
+func forwardedIP(headers []string, trusted []*net.IPNet) net.IP {
+	var hops []string
+	for _, h := range headers {
+		hops = append(hops, strings.Split(h, ",")...)
+	}
+	var ip net.IP
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil || !util.IPInNets(ip, trusted) {
+			break
 		}
 	}
-	return net.IP{}, false, consts.ErrInvalidClient
+	return ip
 }
 
 // oops will output a bencoded error code to the torrent client using
@@ -116,28 +153,50 @@ func oops(ctx *gin.Context, errCode errCode) {
 	log.Errorf("Error in request from: %s (%d : %s)", ctx.Request.RequestURI, errCode, msg.Error())
 }
 
-// preFlightChecks ensures our user meets the requirements to make an authorized request
-// THis is used within the request handler itself and not as a middleware because of the
-// slightly higher cost of passing data in through the request context
-func preFlightChecks(usr *store.User, pk string, c *gin.Context) bool {
-	// Check that the user is valid before parsing anything
+// publicUserID is the id of the pseudo user all requests are attributed to in public mode
+const publicUserID = 1
+
+// publicUser checks if the user is the pseudo user of public mode. It is shared by every
+// anonymous peer so the per-user limits, history and cheat rules do not apply to it.
+func publicUser(usr *store.User) bool {
+	return config.Tracker.Public && usr.UserID == publicUserID
+}
+
+// authenticate looks up the user associated with the passkey supplied. In public mode
+// all requests are attributed to a single pseudo user.
+func authenticate(pk string) (*store.User, errCode) {
 	if config.Tracker.Public {
-		usr.UserID = 1
-		return true
-	} else {
-		if pk == "" {
-			oops(c, msgInvalidAuth)
-			return false
-		}
-		foundUser, err := UserGetByPasskey(pk)
-		if err != nil {
+		return &store.User{UserID: publicUserID, DownloadEnabled: true, MultiUp: -1, MultiDown: -1}, msgOk
+	}
+	if pk == "" {
+		return nil, msgInvalidAuth
+	}
+	usr, err := UserGetByPasskey(pk)
+	if err != nil {
+		// Rotated passkeys keep working until their grace period passes
+		retired, found := userGetByRetiredPasskey(pk)
+		if !found {
 			log.Debugf("Got invalid passkey")
-			oops(c, msgInvalidAuth)
-			return false
+			return nil, msgInvalidAuth
 		}
-		usr = foundUser
-		return usr.Valid()
+		usr = retired
+	}
+	if !usr.Valid() {
+		return nil, msgInvalidAuth
+	}
+	return usr, msgOk
+}
+
+// preFlightChecks ensures our user meets the requirements to make an authorized request
+// THis is used within the request handler itself and not as a middleware because of the
+// slightly higher cost of passing data in through the request context
+func preFlightChecks(pk string, c *gin.Context) (*store.User, bool) {
+	usr, code := authenticate(pk)
+	if code != msgOk {
+		oops(c, code)
+		return nil, false
 	}
+	return usr, true
 }
 
 // handleTrackerErrors is used as the default error handler for tracker requests
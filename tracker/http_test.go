@@ -0,0 +1,57 @@
+package tracker
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/util"
+	"github.com/stretchr/testify/require"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetIPTrustedProxies(t *testing.T) {
+	trusted := config.Tracker.TrustedProxiesParsed
+	defer func() { config.Tracker.TrustedProxiesParsed = trusted }()
+	proxies, err := util.ParseCIDRs([]string{"10.0.0.0/8", "2001:db8::1"})
+	require.NoError(t, err)
+	config.Tracker.TrustedProxiesParsed = proxies
+	cases := []struct {
+		remote  string
+		realIP  string
+		forward []string
+		ip      string
+		ipv6    bool
+	}{
+		// Headers from untrusted sources are ignored
+		{"50.50.50.50:9000", "1.1.1.1", nil, "50.50.50.50", false},
+		{"50.50.50.50:9000", "", []string{"1.1.1.1"}, "50.50.50.50", false},
+		{"[2001:db8::2]:9000", "1.1.1.1", nil, "2001:db8::2", true},
+		// Trusted sources
+		{"10.0.0.1:9000", "1.1.1.1", []string{"2.2.2.2"}, "1.1.1.1", false},
+		{"10.0.0.1:9000", "", []string{"2.2.2.2"}, "2.2.2.2", false},
+		{"[2001:db8::1]:9000", "2001:db8::5", nil, "2001:db8::5", true},
+		// The right-most untrusted hop is used as anything left of it could be spoofed
+		{"10.0.0.1:9000", "", []string{"6.6.6.6, 2.2.2.2, 10.0.0.5"}, "2.2.2.2", false},
+		{"10.0.0.1:9000", "", []string{"6.6.6.6", "2.2.2.2,10.0.0.5"}, "2.2.2.2", false},
+		// Every hop trusted uses the left-most one
+		{"10.0.0.1:9000", "", []string{"10.0.0.3, 10.0.0.5"}, "10.0.0.3", false},
+		// Malformed values fall back to the connection address
+		{"10.0.0.1:9000", "bogus", []string{"2.2.2.2, bogus"}, "10.0.0.1", false},
+		{"10.0.0.1:9000", "", nil, "10.0.0.1", false},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/announce", nil)
+		req.RemoteAddr = c.remote
+		if c.realIP != "" {
+			req.Header.Set("X-Real-IP", c.realIP)
+		}
+		for _, f := range c.forward {
+			req.Header.Add("X-Forwarded-For", f)
+		}
+		ctx := &gin.Context{Request: req}
+		ip, ipv6, err := getIP(&query{Params: map[announceParam]string{}}, false, ctx)
+		require.NoError(t, err)
+		require.Equal(t, c.ip, ip.String(), c)
+		require.Equal(t, c.ipv6, ipv6, c)
+	}
+}
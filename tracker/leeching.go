@@ -0,0 +1,70 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/store"
+	"sync"
+)
+
+var (
+	leechingMu = &sync.Mutex{}
+	// leeching holds the torrents each user has been seen leeching. Entries are only checked
+	// against the swarms when they are counted so may include torrents which have since
+	// been completed or stopped.
+	leeching = make(map[uint32]map[store.InfoHash]struct{})
+)
+
+// maxLeeching returns the number of torrents a user may leech at once, 0 is unlimited. The
+// users own limit takes precedence over their role.
+func maxLeeching(usr *store.User) uint32 {
+	if usr.MaxLeeching > 0 {
+		return usr.MaxLeeching
+	}
+	if usr.Role != nil {
+		return usr.Role.MaxLeeching
+	}
+	return 0
+}
+
+// trackLeeching records the user as leeching the torrent
+func trackLeeching(userID uint32, ih store.InfoHash) {
+	leechingMu.Lock()
+	set, found := leeching[userID]
+	if !found {
+		set = make(map[store.InfoHash]struct{})
+		leeching[userID] = set
+	}
+	set[ih] = struct{}{}
+	leechingMu.Unlock()
+}
+
+// activeLeeching returns the number of torrents, other than the one provided, the user still
+// has a leeching peer in. Torrents the user is no longer leeching are forgotten.
+func activeLeeching(userID uint32, skip store.InfoHash) int {
+	leechingMu.Lock()
+	defer leechingMu.Unlock()
+	active := 0
+	for ih := range leeching[userID] {
+		tor, err := TorrentGet(ih, true)
+		if err != nil || tor.Peers == nil || !tor.Peers.UserLeeching(userID) {
+			delete(leeching[userID], ih)
+			continue
+		}
+		if ih != skip {
+			active++
+		}
+	}
+	if len(leeching[userID]) == 0 {
+		delete(leeching, userID)
+	}
+	return active
+}
+
+// leechingLimited checks if the user has reached their limit of torrents leeched at once and
+// cannot start leeching another. Torrents the user is already leeching are not limited.
+func leechingLimited(usr *store.User, ih store.InfoHash) bool {
+	limit := maxLeeching(usr)
+	if limit == 0 {
+		return false
+	}
+	return activeLeeching(usr.UserID, ih) >= int(limit)
+}
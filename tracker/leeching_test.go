@@ -0,0 +1,39 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLeechingLimit(t *testing.T) {
+	s := newTestSwarm(t)
+	s.role.MaxLeeching = 2
+	usr := s.usr
+	tors := []*store.Torrent{s.tor, s.addTorrent(), s.addTorrent()}
+	announce := func(tor *store.Torrent, left string, event consts.AnnounceType) errCode {
+		code, _ := s.announce(testReq{Ih: tor.InfoHash, left: left, event: string(event)})
+		return code
+	}
+	require.Equal(t, msgOk, announce(tors[0], "1000", consts.STARTED))
+	require.Equal(t, msgOk, announce(tors[1], "1000", consts.STARTED))
+	require.Equal(t, msgLeechingLimit, announce(tors[2], "1000", consts.STARTED))
+	// Leaving out the started event does not get around the limit
+	require.Equal(t, msgLeechingLimit, announce(tors[2], "1000", consts.ANNOUNCE))
+	require.Equal(t, msgLeechingLimit, announce(tors[2], "1000", consts.PAUSED))
+	// Seeding and torrents already being leeched are not limited
+	require.Equal(t, msgOk, announce(tors[2], "0", consts.STARTED))
+	require.Equal(t, msgOk, announce(tors[2], "0", consts.STOPPED))
+	require.Equal(t, msgOk, announce(tors[0], "1000", consts.STARTED))
+
+	// Completing frees a slot
+	require.Equal(t, msgOk, announce(tors[0], "0", consts.COMPLETED))
+	require.Equal(t, msgOk, announce(tors[2], "1000", consts.STARTED))
+
+	// The users own limit overrides the role
+	require.Equal(t, msgOk, announce(tors[0], "0", consts.STOPPED))
+	require.Equal(t, msgLeechingLimit, announce(tors[0], "1000", consts.STARTED))
+	usr.MaxLeeching = 3
+	require.Equal(t, msgOk, announce(tors[0], "1000", consts.STARTED))
+}
@@ -0,0 +1,199 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"sync"
+	"time"
+)
+
+// warnPasskeyRetired is sent to clients announcing with a passkey which has been rotated
+const warnPasskeyRetired = "Your passkey has changed, redownload your torrents to keep using them"
+
+// retiredPasskey is an old passkey of a user which keeps working until it expires
+type retiredPasskey struct {
+	user    *store.User
+	expires time.Time
+}
+
+// passkeyUse holds the last time a passkey was announced from each IP and ASN
+type passkeyUse struct {
+	ips  map[string]time.Time
+	asns map[uint32]time.Time
+}
+
+// prune removes the IPs and ASNs not seen since the cutoff, returning the number of each left
+func (u *passkeyUse) prune(cutoff time.Time) (int, int) {
+	for ip, seen := range u.ips {
+		if seen.Before(cutoff) {
+			delete(u.ips, ip)
+		}
+	}
+	for asn, seen := range u.asns {
+		if seen.Before(cutoff) {
+			delete(u.asns, asn)
+		}
+	}
+	return len(u.ips), len(u.asns)
+}
+
+var (
+	passkeysMu = &sync.RWMutex{}
+	// retiredPasskeys holds the rotated passkeys still inside their grace period
+	retiredPasskeys = make(map[string]retiredPasskey)
+	// passkeyUses holds where each passkey has recently been announced from
+	passkeyUses = make(map[string]*passkeyUse)
+)
+
+// PasskeyRotate replaces the passkey of the user with a new one. The old passkey keeps working
+// until the grace period passes, with a grace of 0 it stops working immediately. Returns when
+// the old passkey expires.
+func PasskeyRotate(user *store.User, grace time.Duration) (time.Time, error) {
+	oldPasskey := user.Passkey
+	if err := userChangePasskey(user, util.NewPasskey()); err != nil {
+		return time.Time{}, err
+	}
+	now := util.Now()
+	passkeysMu.Lock()
+	delete(passkeyUses, oldPasskey)
+	if grace <= 0 {
+		passkeysMu.Unlock()
+		return time.Time{}, nil
+	}
+	expires := now.Add(grace)
+	retiredPasskeys[oldPasskey] = retiredPasskey{user: user, expires: expires}
+	passkeysMu.Unlock()
+	// The old passkey still works until the tracker restarts if it cannot be persisted
+	if err := db.RetiredPasskeyAdd(&store.RetiredPasskey{
+		Passkey:   oldPasskey,
+		UserID:    user.UserID,
+		Expires:   expires,
+		CreatedOn: now,
+	}); err != nil {
+		log.Errorf("Failed to persist retired passkey: %v", err)
+	}
+	return expires, nil
+}
+
+// loadRetiredPasskeys reads the retired passkeys from the store. Passkeys which have expired
+// or belong to unknown users are removed from the store.
+func loadRetiredPasskeys() error {
+	passkeys, err := db.RetiredPasskeys()
+	if err != nil {
+		return err
+	}
+	usersByID := make(map[uint32]*store.User)
+	for _, u := range Users() {
+		usersByID[u.UserID] = u
+	}
+	now := util.Now()
+	newRetired := make(map[string]retiredPasskey)
+	for _, p := range passkeys {
+		user, found := usersByID[p.UserID]
+		if !found || !now.Before(p.Expires) {
+			if err := db.RetiredPasskeyDelete(p.Passkey); err != nil {
+				return err
+			}
+			continue
+		}
+		newRetired[p.Passkey] = retiredPasskey{user: user, expires: p.Expires}
+	}
+	passkeysMu.Lock()
+	retiredPasskeys = newRetired
+	passkeysMu.Unlock()
+	return nil
+}
+
+// userGetByRetiredPasskey returns the user of a rotated passkey which is still inside its
+// grace period
+func userGetByRetiredPasskey(passkey string) (*store.User, bool) {
+	passkeysMu.RLock()
+	r, found := retiredPasskeys[passkey]
+	passkeysMu.RUnlock()
+	if !found || !util.Now().Before(r.expires) {
+		return nil, false
+	}
+	return r.user, true
+}
+
+// passkeyRetired checks if the passkey has been rotated and is inside its grace period
+func passkeyRetired(passkey string) bool {
+	_, found := userGetByRetiredPasskey(passkey)
+	return found
+}
+
+// recordPasskeyUse records the IP and ASN the passkey was announced from, rotating the passkey
+// of the user once it is used from more places at the same time than allowed. Passkeys count
+// as being used from everywhere they announced from within the last two announce intervals.
+func recordPasskeyUse(user *store.User, passkey string, ip net.IP, now time.Time) {
+	maxIPs, maxASNs := config.Tracker.PasskeyMaxIPs, config.Tracker.PasskeyMaxASNs
+	// Retired passkeys have already been rotated
+	if (maxIPs <= 0 && maxASNs <= 0) || passkey == "" || passkey != user.Passkey {
+		return
+	}
+	var asn uint32
+	if maxASNs > 0 {
+		asn = geodb.GetLocation(ip).ASN
+	}
+	passkeysMu.Lock()
+	use, found := passkeyUses[passkey]
+	if !found {
+		use = &passkeyUse{ips: make(map[string]time.Time), asns: make(map[uint32]time.Time)}
+		passkeyUses[passkey] = use
+	}
+	use.ips[ip.String()] = now
+	if asn > 0 {
+		use.asns[asn] = now
+	}
+	ips, asns := use.prune(now.Add(-2 * config.Tracker.AnnounceIntervalParsed))
+	var reason string
+	if maxIPs > 0 && ips > maxIPs {
+		reason = fmt.Sprintf("Passkey announced from %d IPs at once", ips)
+	} else if maxASNs > 0 && asns > maxASNs {
+		reason = fmt.Sprintf("Passkey announced from %d ASNs at once", asns)
+	}
+	if reason != "" {
+		// Only a single announce gets to rotate the passkey
+		delete(passkeyUses, passkey)
+	}
+	passkeysMu.Unlock()
+	if reason == "" {
+		return
+	}
+	expires, err := PasskeyRotate(user, config.Tracker.PasskeyLeakGraceParsed)
+	if err != nil {
+		log.Errorf("Failed to rotate leaked passkey: %v", err)
+		return
+	}
+	user.Log().WithFields(log.Fields{"ip": ip.String(), "expires": expires}).Warnf("%s, rotated it", reason)
+}
+
+// prunePasskeys removes the retired passkeys which have expired and the uses of passkeys not
+// announced recently, returning the number of retired passkeys removed
+func prunePasskeys(now time.Time) int {
+	cutoff := now.Add(-2 * config.Tracker.AnnounceIntervalParsed)
+	var expired []string
+	passkeysMu.Lock()
+	for passkey, r := range retiredPasskeys {
+		if !now.Before(r.expires) {
+			delete(retiredPasskeys, passkey)
+			expired = append(expired, passkey)
+		}
+	}
+	for passkey, use := range passkeyUses {
+		if ips, _ := use.prune(cutoff); ips == 0 {
+			delete(passkeyUses, passkey)
+		}
+	}
+	passkeysMu.Unlock()
+	for _, passkey := range expired {
+		if err := db.RetiredPasskeyDelete(passkey); err != nil {
+			log.Errorf("Failed to delete expired passkey: %v", err)
+		}
+	}
+	return len(expired)
+}
@@ -0,0 +1,144 @@
+package tracker
+
+import (
+	"github.com/chihaya/bencode"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPasskeyRotate(t *testing.T) {
+	s := newTestSwarm(t)
+	usr := s.usr
+	announce := func(passkey string) (errCode, bencode.Dict) {
+		return s.announce(testReq{PK: passkey})
+	}
+
+	oldPasskey := usr.Passkey
+	expires, err := PasskeyRotate(usr, time.Hour)
+	require.NoError(t, err)
+	require.NotEqual(t, oldPasskey, usr.Passkey)
+	require.WithinDuration(t, util.Now().Add(time.Hour), expires, time.Minute)
+	found, err := UserGetByPasskey(usr.Passkey)
+	require.NoError(t, err)
+	require.Equal(t, usr, found)
+	_, err = UserGetByPasskey(oldPasskey)
+	require.Equal(t, consts.ErrInvalidUser, err)
+
+	// The old passkey keeps working with a warning until the grace period passes
+	code, resp := announce(oldPasskey)
+	require.Equal(t, msgOk, code)
+	require.Equal(t, warnPasskeyRetired, resp["warning message"])
+	code, resp = announce(usr.Passkey)
+	require.Equal(t, msgOk, code)
+	require.NotContains(t, resp, "warning message")
+	require.Equal(t, consts.ErrDuplicate, UserChangePasskey(testUsers[0], oldPasskey))
+
+	// Retired passkeys survive a restart
+	passkeysMu.Lock()
+	retiredPasskeys = make(map[string]retiredPasskey)
+	passkeysMu.Unlock()
+	require.NoError(t, loadRetiredPasskeys())
+	retiredUser, ok := userGetByRetiredPasskey(oldPasskey)
+	require.True(t, ok)
+	require.Equal(t, usr, retiredUser)
+	code, _ = announce(oldPasskey)
+	require.Equal(t, msgOk, code)
+
+	require.Equal(t, 1, prunePasskeys(expires))
+	code, _ = announce(oldPasskey)
+	require.Equal(t, msgInvalidAuth, code)
+	stored, err := db.RetiredPasskeys()
+	require.NoError(t, err)
+	for _, p := range stored {
+		require.NotEqual(t, oldPasskey, p.Passkey)
+	}
+
+	// Without a grace period the old passkey stops working immediately
+	oldPasskey = usr.Passkey
+	expires, err = PasskeyRotate(usr, 0)
+	require.NoError(t, err)
+	require.True(t, expires.IsZero())
+	code, _ = announce(oldPasskey)
+	require.Equal(t, msgInvalidAuth, code)
+
+	newPasskey := util.NewPasskey()
+	require.NoError(t, UserChangePasskey(usr, newPasskey))
+	require.Equal(t, newPasskey, usr.Passkey)
+	code, _ = announce(newPasskey)
+	require.Equal(t, msgOk, code)
+	require.Equal(t, consts.ErrDuplicate, UserChangePasskey(usr, testUsers[0].Passkey))
+	require.True(t, errors.Is(UserChangePasskey(usr, ""), consts.ErrMalformedRequest))
+}
+
+func TestPasskeyRotateConcurrent(t *testing.T) {
+	s := newTestSwarm(t)
+	done := make(chan error)
+	go func() {
+		var err error
+		for i := 0; i < 50 && err == nil; i++ {
+			_, err = PasskeyRotate(s.usr, 0)
+		}
+		done <- err
+	}()
+	// Announces look users up while their passkeys are being rotated
+	for i := 0; i < 50; i++ {
+		s.announce(testReq{PK: testUsers[0].Passkey})
+		_, _ = UserGetByUserID(s.usr.UserID)
+	}
+	require.NoError(t, <-done)
+}
+
+func TestPasskeyLeaked(t *testing.T) {
+	cfg := config.Tracker
+	defer func() { config.Tracker = cfg }()
+	oldGeo := geodb
+	defer func() { geodb = oldGeo }()
+	geodb = banTestGeo{}
+	usr := store.GenerateTestUser()
+	require.NoError(t, UserAdd(&usr))
+	now := util.Now()
+	use := func(ip string, at time.Time) {
+		recordPasskeyUse(&usr, usr.Passkey, net.ParseIP(ip), at)
+	}
+
+	config.Tracker.PasskeyMaxIPs = 2
+	config.Tracker.PasskeyGraceParsed = time.Hour
+	passkey := usr.Passkey
+	use("12.34.56.78", now)
+	use("12.34.56.79", now)
+	use("12.34.56.79", now)
+	require.Equal(t, passkey, usr.Passkey)
+	// IPs which stopped announcing no longer count
+	use("12.34.56.80", now.Add(3*config.Tracker.AnnounceIntervalParsed))
+	require.Equal(t, passkey, usr.Passkey)
+	use("12.34.56.81", now.Add(3*config.Tracker.AnnounceIntervalParsed))
+	use("12.34.56.82", now.Add(3*config.Tracker.AnnounceIntervalParsed))
+	require.NotEqual(t, passkey, usr.Passkey)
+	// The leaked passkey stops working right away rather than after the usual grace period
+	require.False(t, passkeyRetired(passkey))
+	_, err := UserGetByPasskey(passkey)
+	require.Equal(t, consts.ErrInvalidUser, err)
+
+	// Uses of the old passkey are not counted against the new one
+	recordPasskeyUse(&usr, passkey, net.ParseIP("12.34.56.83"), now)
+	_, found := passkeyUses[passkey]
+	require.False(t, found)
+
+	config.Tracker.PasskeyMaxIPs = 0
+	config.Tracker.PasskeyMaxASNs = 1
+	config.Tracker.PasskeyLeakGraceParsed = time.Minute
+	passkey = usr.Passkey
+	use("40.40.40.40", now)
+	use("12.34.56.78", now)
+	require.Equal(t, passkey, usr.Passkey)
+	use("41.41.41.41", now)
+	require.NotEqual(t, passkey, usr.Passkey)
+	require.True(t, passkeyRetired(passkey))
+}
@@ -0,0 +1,155 @@
+package tracker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxGhostAnnounces caps the announces kept as evidence for each probe
+const maxGhostAnnounces = 20
+
+var (
+	probesMu = &sync.RWMutex{}
+	// probes holds the users currently being handed ghost peers. Probes are not persisted and
+	// end when the tracker restarts.
+	probes = make(map[uint32]*store.GhostProbe)
+
+	// ghostNets are the documentation ranges (RFC 5737, RFC 3849) which are never routable,
+	// so no client can ever connect to a ghost peer
+	ghostNets = []net.IP{
+		net.IPv4(192, 0, 2, 0).To4(),
+		net.IPv4(198, 51, 100, 0).To4(),
+		net.IPv4(203, 0, 113, 0).To4(),
+	}
+	ghostNet6 = net.ParseIP("2001:db8::")
+)
+
+// ProbeStart begins handing the user only ghost peers. Starting a probe which is already
+// running restarts it, discarding the announces recorded so far.
+func ProbeStart(userID uint32) (store.GhostProbe, error) {
+	if _, err := UserGetByUserID(userID); err != nil {
+		return store.GhostProbe{}, err
+	}
+	probe := &store.GhostProbe{UserID: userID, StartedOn: util.Now()}
+	probesMu.Lock()
+	probes[userID] = probe
+	probesMu.Unlock()
+	return *probe, nil
+}
+
+// ProbeStop returns the user to receiving real peers, returning the finished probe
+func ProbeStop(userID uint32) (store.GhostProbe, error) {
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	probe, found := probes[userID]
+	if !found {
+		return store.GhostProbe{}, consts.ErrInvalidProbe
+	}
+	delete(probes, userID)
+	return *probe, nil
+}
+
+// Probes returns copies of every running probe
+func Probes() []store.GhostProbe {
+	probesMu.RLock()
+	defer probesMu.RUnlock()
+	var all []store.GhostProbe
+	for _, p := range probes {
+		probe := *p
+		probe.Announces = append([]store.GhostAnnounce{}, p.Announces...)
+		all = append(all, probe)
+	}
+	return all
+}
+
+// probing checks if the user is being handed ghost peers
+func probing(userID uint32) bool {
+	probesMu.RLock()
+	_, found := probes[userID]
+	probesMu.RUnlock()
+	return found
+}
+
+// probedPeer checks if the peer belongs to a user being handed ghost peers
+func probedPeer(p *store.Peer) bool {
+	return probing(p.UserID)
+}
+
+// recordGhostAnnounce adds the announce to the evidence of the users probe. Returns the probe
+// evidence and true if the upload reported should be flagged. The first announce of each
+// torrent during a probe is never flagged as the client may still be uploading to the real
+// peers it was handed before the probe started.
+func recordGhostAnnounce(userID uint32, ih store.InfoHash, uploaded uint64, downloaded uint64,
+	now time.Time) (store.GhostProbe, bool) {
+	probesMu.Lock()
+	defer probesMu.Unlock()
+	probe, found := probes[userID]
+	if !found {
+		return store.GhostProbe{}, false
+	}
+	first := true
+	for _, a := range probe.Announces {
+		if a.InfoHash == ih {
+			first = false
+			break
+		}
+	}
+	probe.Announces = append(probe.Announces, store.GhostAnnounce{
+		InfoHash:    ih,
+		AnnouncedOn: now,
+		Uploaded:    uploaded,
+		Downloaded:  downloaded,
+	})
+	if len(probe.Announces) > maxGhostAnnounces {
+		probe.Announces = probe.Announces[len(probe.Announces)-maxGhostAnnounces:]
+	}
+	evidence := *probe
+	evidence.Announces = append([]store.GhostAnnounce{}, probe.Announces...)
+	return evidence, !first && uploaded > 0
+}
+
+// ghostEvidence describes the announces of a probe for a cheat report
+func ghostEvidence(probe store.GhostProbe, uploaded uint64) string {
+	var lines []string
+	for _, a := range probe.Announces {
+		lines = append(lines, fmt.Sprintf("%s %s up=%d dn=%d", a.AnnouncedOn.Format(time.RFC3339),
+			a.InfoHash.String(), a.Uploaded, a.Downloaded))
+	}
+	return fmt.Sprintf("Uploaded %s while only given ghost peers since %s. Announces: %s",
+		util.HumanBytesString(uploaded), probe.StartedOn.Format(time.RFC3339), strings.Join(lines, "; "))
+}
+
+// ghostPeers returns n unreachable peers for the torrent. The peers are derived from the user
+// and torrent so repeated announces are handed the same peers, like a real swarm would.
+func ghostPeers(userID uint32, ih store.InfoHash, n int, v6 bool) []*store.Peer {
+	seed := int64(binary.BigEndian.Uint64(ih[:8])) ^ int64(userID)
+	r := rand.New(rand.NewSource(seed))
+	peers := make([]*store.Peer, 0, n)
+	for i := 0; i < n; i++ {
+		var ip net.IP
+		if v6 && i%2 == 1 {
+			ip = make(net.IP, net.IPv6len)
+			copy(ip, ghostNet6)
+			r.Read(ip[8:])
+		} else {
+			ip = make(net.IP, net.IPv4len)
+			copy(ip, ghostNets[r.Intn(len(ghostNets))])
+			ip[3] = byte(1 + r.Intn(254))
+		}
+		var pid store.PeerID
+		r.Read(pid[:])
+		peer := store.NewPeer(0, pid, ip, uint16(1024+r.Intn(64511)))
+		peer.IPv6 = ip.To4() == nil
+		peer.CryptoLevel = consts.Supported
+		peers = append(peers, peer)
+	}
+	return peers
+}
@@ -0,0 +1,68 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGhostProbe(t *testing.T) {
+	rules := config.Tracker.Cheat
+	defer func() { config.Tracker.Cheat = rules }()
+	config.Tracker.Cheat = config.CheatRules{GhostPeersAction: config.CheatDrop}
+	s := newTestSwarm(t)
+	usr, tor := s.usr, s.tor
+	announce := func(pk string, pid store.PeerID, port string, uploaded string, left string) []net.IP {
+		resp := s.mustAnnounce(testReq{PK: pk, PID: pid, Port: port, Uploaded: uploaded, left: left,
+			event: string(consts.ANNOUNCE)})
+		peers, _ := resp["peers"].(string)
+		var ips []net.IP
+		for i := 0; i+6 <= len(peers); i += 6 {
+			ips = append(ips, net.IP(peers[i:i+4]))
+		}
+		return ips
+	}
+	leecherID := testLeechers[0].PeerID
+	leecherID[19] = 99
+	announce(testUsers[1].Passkey, leecherID, "4099", "0", "1000")
+	rewind := func() { s.rewind(testLeechers[0].PeerID, 10*time.Second) }
+
+	_, err := ProbeStart(0)
+	require.Equal(t, consts.ErrInvalidUser, err)
+	_, err = ProbeStart(usr.UserID)
+	require.NoError(t, err)
+	ips := announce(usr.Passkey, testLeechers[0].PeerID, "4000", "0", "0")
+	require.NotEmpty(t, ips)
+	for _, ip := range ips {
+		require.True(t, ip[0] == 192 || ip[0] == 198 || ip[0] == 203, ip.String())
+	}
+	// Real peers are not handed the probed user either, so nobody can upload to them honestly
+	require.Empty(t, announce(testUsers[1].Passkey, leecherID, "4099", "0", "1000"))
+	// Nobody can download from ghost peers
+	rewind()
+	announce(usr.Passkey, testLeechers[0].PeerID, "4000", "5000", "0")
+	require.Equal(t, uint64(0), usr.Uploaded)
+	reports, err := CheatReports(usr.UserID, cheatGhostPeers)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, uint64(5000), reports[0].Uploaded)
+	require.Equal(t, 2, strings.Count(reports[0].Detail, tor.InfoHash.String()))
+	require.Len(t, Probes(), 1)
+
+	probe, err := ProbeStop(usr.UserID)
+	require.NoError(t, err)
+	require.Len(t, probe.Announces, 2)
+	require.Equal(t, uint64(5000), probe.Announces[1].Uploaded)
+	_, err = ProbeStop(usr.UserID)
+	require.Equal(t, consts.ErrInvalidProbe, err)
+	ips = announce(usr.Passkey, testLeechers[0].PeerID, "4000", "6000", "0")
+	require.Len(t, ips, 1)
+	require.Equal(t, "12.34.56.78", ips[0].String())
+	require.Equal(t, uint64(1000), usr.Uploaded)
+	require.Len(t, announce(testUsers[1].Passkey, leecherID, "4099", "0", "1000"), 1)
+}
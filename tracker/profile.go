@@ -0,0 +1,156 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// speedProfileWindow is the number of recent speeds each profile keeps for its percentiles
+	speedProfileWindow = 100
+	// speedProfileMaxAge is how long a profile is kept without recording a speed
+	speedProfileMaxAge = 90 * 24 * time.Hour
+)
+
+var (
+	profilesMu = &sync.RWMutex{}
+	// profiles holds the historical upload speeds of every user, IP and ASN
+	profiles = make(map[store.SpeedProfileKey]*store.SpeedProfile)
+)
+
+// loadSpeedProfiles reads the speed profiles from the store
+func loadSpeedProfiles() {
+	profs, err := db.SpeedProfiles()
+	if err != nil {
+		log.Fatalf("Failed to load speed profiles: %s", err)
+	}
+	newProfiles := make(map[store.SpeedProfileKey]*store.SpeedProfile)
+	for _, p := range profs {
+		newProfiles[p.ProfileKey()] = p
+	}
+	profilesMu.Lock()
+	profiles = newProfiles
+	profilesMu.Unlock()
+}
+
+// speedProfileKeys returns the profiles the speeds of the users peer are recorded in
+func speedProfileKeys(userID uint32, peer *store.Peer) []store.SpeedProfileKey {
+	keys := []store.SpeedProfileKey{{Kind: store.ProfileUser, Key: strconv.FormatUint(uint64(userID), 10)}}
+	if peer.IP != nil {
+		keys = append(keys, store.SpeedProfileKey{Kind: store.ProfileIP, Key: peer.IP.String()})
+	}
+	if peer.ASN > 0 {
+		keys = append(keys, store.SpeedProfileKey{Kind: store.ProfileASN, Key: strconv.FormatUint(uint64(peer.ASN), 10)})
+	}
+	return keys
+}
+
+// profileSpeed checks the upload speed of the peer against the historical speeds of the user,
+// IP and ASN, then records it in each of them. Returns a description of the anomaly if the
+// speed is beyond the tolerance of any profile with enough samples. Anomalous speeds are
+// recorded capped at the tolerance, so a cheater can only raise their baseline slowly while
+// being flagged, but a user whose line got faster forms a new baseline after a few announces.
+func profileSpeed(userID uint32, peer *store.Peer, speed uint64, now time.Time) string {
+	rules := config.Tracker.Cheat
+	keys := speedProfileKeys(userID, peer)
+	recorded := make([]uint64, len(keys))
+	var anomalies []string
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	for i, key := range keys {
+		recorded[i] = speed
+		p, found := profiles[key]
+		if !found || p.Samples < rules.SpeedProfileMinSamples {
+			continue
+		}
+		p95 := p.Percentile(0.95)
+		limit := float64(p95) * rules.SpeedProfileTolerance
+		if float64(speed) > limit {
+			recorded[i] = uint64(limit)
+			anomalies = append(anomalies, fmt.Sprintf("%s %s (mean %s/s, p50 %s/s, p95 %s/s, max %s/s, %d samples)",
+				key.Kind, key.Key, util.HumanBytesString(uint64(p.Mean)), util.HumanBytesString(p.Percentile(0.5)),
+				util.HumanBytesString(p95), util.HumanBytesString(p.Max), p.Samples))
+		}
+	}
+	for i, key := range keys {
+		p, found := profiles[key]
+		if !found {
+			p = &store.SpeedProfile{Kind: key.Kind, Key: key.Key}
+			profiles[key] = p
+		}
+		p.Add(recorded[i], uint64(atomic.LoadUint32(&peer.SpeedUPMax)), speedProfileWindow, now)
+	}
+	if len(anomalies) > 0 {
+		return fmt.Sprintf("Upload speed of %s/s is over %.1fx the usual speed of %s",
+			util.HumanBytesString(speed), rules.SpeedProfileTolerance, strings.Join(anomalies, ", "))
+	}
+	return ""
+}
+
+// findDirtySpeedProfiles returns copies of up to n speed profiles with pending changes. The
+// pending changes of the returned profiles are reset.
+func findDirtySpeedProfiles(n int) []*store.SpeedProfile {
+	var batch []*store.SpeedProfile
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	for _, p := range profiles {
+		if len(batch) >= n {
+			break
+		}
+		if p.Writes > 0 {
+			profile := *p
+			profile.Recent = append([]uint64{}, p.Recent...)
+			batch = append(batch, &profile)
+			p.Writes = 0
+		}
+	}
+	return batch
+}
+
+// pruneSpeedProfiles removes the profiles which have not recorded a speed within
+// speedProfileMaxAge, returning the number removed. Profiles with pending changes are kept
+// until they have been synced.
+func pruneSpeedProfiles(now time.Time) int {
+	var stale []store.SpeedProfileKey
+	profilesMu.Lock()
+	for key, p := range profiles {
+		if p.Writes == 0 && now.Sub(p.UpdatedOn) >= speedProfileMaxAge {
+			delete(profiles, key)
+			stale = append(stale, key)
+		}
+	}
+	profilesMu.Unlock()
+	for _, key := range stale {
+		if err := db.SpeedProfileDelete(key); err != nil {
+			log.Errorf("Failed to delete stale speed profile: %v", err)
+		}
+	}
+	return len(stale)
+}
+
+// speedProfileSync persists a batch of speed profiles. On failure the profiles are marked
+// dirty again so they are retried on the next sync.
+func speedProfileSync(batch []*store.SpeedProfile) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := db.SpeedProfileSync(batch); err != nil {
+		profilesMu.Lock()
+		for _, b := range batch {
+			if p, found := profiles[b.ProfileKey()]; found {
+				p.Writes++
+			}
+		}
+		profilesMu.Unlock()
+		return err
+	}
+	return nil
+}
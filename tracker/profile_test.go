@@ -0,0 +1,83 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSpeedProfiles(t *testing.T) {
+	rules := config.Tracker.Cheat
+	defer func() { config.Tracker.Cheat = rules }()
+	config.Tracker.Cheat = config.CheatRules{SpeedProfile: true, SpeedProfileTolerance: 3,
+		SpeedProfileMinSamples: 5, SpeedProfileAction: config.CheatDrop}
+	s := newTestSwarm(t)
+	usr := s.usr
+	var total uint64
+	announce := func(uploaded uint64, event consts.AnnounceType) {
+		total += uploaded
+		s.mustAnnounce(testReq{Uploaded: strconv.FormatUint(total, 10), event: string(event)})
+		s.rewind(testLeechers[0].PeerID, 10*time.Second)
+	}
+	userKey := store.SpeedProfileKey{Kind: store.ProfileUser, Key: strconv.FormatUint(uint64(usr.UserID), 10)}
+
+	// Build up a baseline of about 100 B/s
+	announce(0, consts.STARTED)
+	for i := 0; i < 5; i++ {
+		announce(1000, consts.ANNOUNCE)
+	}
+	require.Equal(t, uint64(5000), usr.Uploaded)
+	reports, err := CheatReports(usr.UserID, cheatSpeedProfile)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	// 10x the usual speed
+	announce(10000, consts.ANNOUNCE)
+	require.Equal(t, uint64(5000), usr.Uploaded)
+	reports, err = CheatReports(usr.UserID, cheatSpeedProfile)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Contains(t, reports[0].Detail, "user "+userKey.Key)
+
+	// Within the tolerance
+	announce(2500, consts.ANNOUNCE)
+	require.Equal(t, uint64(7500), usr.Uploaded)
+
+	// A line which got faster forms a new baseline instead of being flagged forever
+	announce(10000, consts.ANNOUNCE)
+	require.Equal(t, uint64(7500), usr.Uploaded)
+	announce(10000, consts.ANNOUNCE)
+	require.Equal(t, uint64(17500), usr.Uploaded)
+	// The second flagged upload is inside the report window of the first
+	reports, err = CheatReports(usr.UserID, cheatSpeedProfile)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	// Profiles survive a restart
+	require.NoError(t, speedProfileSync(findDirtySpeedProfiles(500)))
+	require.Empty(t, findDirtySpeedProfiles(500))
+	loadSpeedProfiles()
+	profilesMu.RLock()
+	profile := profiles[userKey]
+	profilesMu.RUnlock()
+	require.NotNil(t, profile)
+	require.Equal(t, uint64(9), profile.Samples)
+	require.Len(t, profile.Recent, 9)
+
+	// Profiles which stop recording speeds are removed
+	require.Zero(t, pruneSpeedProfiles(time.Now()))
+	require.NotZero(t, pruneSpeedProfiles(time.Now().Add(speedProfileMaxAge)))
+	profilesMu.RLock()
+	_, found := profiles[userKey]
+	profilesMu.RUnlock()
+	require.False(t, found)
+	stored, err := db.SpeedProfiles()
+	require.NoError(t, err)
+	for _, p := range stored {
+		require.NotEqual(t, userKey, p.ProfileKey())
+	}
+}
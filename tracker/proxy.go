@@ -0,0 +1,189 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// proxyHeaderTimeout is how long a connection has to send its PROXY header
+	proxyHeaderTimeout = 5 * time.Second
+	// proxyV1MaxLen is the longest a v1 header can be, including the CRLF
+	proxyV1MaxLen = 107
+)
+
+// proxyV2Sig is the signature every v2 header begins with
+var proxyV2Sig = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// ProxyListener wraps the tracker listener when it sits behind a L4 load balancer, reading the
+// HAProxy PROXY protocol header sent at the start of each connection so the address of the
+// client is used instead of the load balancer
+type ProxyListener struct {
+	net.Listener
+	// Trusted limits the PROXY header to connections from these ranges. Connections from other
+	// sources are served using their own address. When empty every connection must send one.
+	Trusted []*net.IPNet
+}
+
+// NewProxyListener wraps the listener to read PROXY headers from connections from the trusted
+// sources
+func NewProxyListener(l net.Listener, trusted []*net.IPNet) *ProxyListener {
+	return &ProxyListener{Listener: l, Trusted: trusted}
+}
+
+// Accept waits for and returns the next connection. The header is read the first time the
+// connection is read from or its remote address is used, so a slow client cannot block
+// other connections from being accepted.
+func (l *ProxyListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if len(l.Trusted) > 0 {
+		host, _, _ := net.SplitHostPort(conn.RemoteAddr().String())
+		if !util.IPInNets(net.ParseIP(host), l.Trusted) {
+			return conn, nil
+		}
+	}
+	return &proxyConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// proxyConn is a connection which begins with a PROXY header
+type proxyConn struct {
+	net.Conn
+	reader *bufio.Reader
+	once   sync.Once
+	remote net.Addr
+	err    error
+}
+
+// readHeader reads the PROXY header once. Connections with an invalid header fail every read.
+func (c *proxyConn) readHeader() {
+	c.once.Do(func() {
+		_ = c.Conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout))
+		c.remote, c.err = readProxyHeader(c.reader)
+		_ = c.Conn.SetReadDeadline(time.Time{})
+		if c.err != nil {
+			log.Warnf("Invalid PROXY header from %s: %v", c.Conn.RemoteAddr(), c.err)
+		}
+		if c.remote == nil {
+			c.remote = c.Conn.RemoteAddr()
+		}
+	})
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	c.readHeader()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+// RemoteAddr returns the client address from the PROXY header
+func (c *proxyConn) RemoteAddr() net.Addr {
+	c.readHeader()
+	return c.remote
+}
+
+// readProxyHeader reads a v1 or v2 PROXY header, returning the source address it holds. A nil
+// address is returned for headers which do not describe a proxied TCP connection, such as the
+// health checks of the load balancer itself.
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	// Both versions are at least this long
+	sig, err := r.Peek(len(proxyV2Sig))
+	if err != nil {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, err.Error())
+	}
+	if bytes.Equal(sig, proxyV2Sig) {
+		return readProxyHeaderV2(r)
+	}
+	if bytes.HasPrefix(sig, []byte("PROXY ")) {
+		return readProxyHeaderV1(r)
+	}
+	return nil, consts.ErrInvalidProxyHeader
+}
+
+// readProxyHeaderV1 reads the human readable header, eg: "PROXY TCP4 1.2.3.4 5.6.7.8 1234 80\r\n"
+func readProxyHeaderV1(r *bufio.Reader) (net.Addr, error) {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, errors.Wrap(consts.ErrInvalidProxyHeader, err.Error())
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+		if len(line) >= proxyV1MaxLen {
+			return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "v1 header too long")
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "v1 header missing CRLF")
+	}
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "malformed v1 header")
+	}
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if ip == nil || err != nil || (ip.To4() != nil) != (fields[1] == "TCP4") {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "invalid v1 source address")
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// readProxyHeaderV2 reads the binary header. Any TLVs following the addresses are discarded.
+func readProxyHeaderV2(r *bufio.Reader) (net.Addr, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, err.Error())
+	}
+	if header[12]>>4 != 2 {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "unsupported version")
+	}
+	body := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, err.Error())
+	}
+	switch header[12] & 0x0f {
+	case 0x0:
+		// LOCAL, sent by the load balancer for its own connections
+		return nil, nil
+	case 0x1:
+		// PROXY
+	default:
+		return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "unsupported command")
+	}
+	// The high nibble is the address family, the low nibble the transport
+	switch header[13] >> 4 {
+	case 0x1:
+		if len(body) < 12 {
+			return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "short v2 ipv4 addresses")
+		}
+		return &net.TCPAddr{IP: net.IP(body[0:4]), Port: int(binary.BigEndian.Uint16(body[8:10]))}, nil
+	case 0x2:
+		if len(body) < 36 {
+			return nil, errors.Wrap(consts.ErrInvalidProxyHeader, "short v2 ipv6 addresses")
+		}
+		return &net.TCPAddr{IP: net.IP(body[0:16]), Port: int(binary.BigEndian.Uint16(body[32:34]))}, nil
+	}
+	// Unspecified or unix sockets carry no usable client address
+	return nil, nil
+}
@@ -0,0 +1,101 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+)
+
+func proxyV2Header(cmd byte, family byte, addrs []byte) []byte {
+	var b bytes.Buffer
+	b.Write(proxyV2Sig)
+	b.WriteByte(0x20 | cmd)
+	b.WriteByte(family)
+	_ = binary.Write(&b, binary.BigEndian, uint16(len(addrs)))
+	b.Write(addrs)
+	return b.Bytes()
+}
+
+func TestReadProxyHeader(t *testing.T) {
+	v4 := append(append(net.ParseIP("12.34.56.78").To4(), net.ParseIP("10.0.0.1").To4()...), 0x1f, 0x90, 0x00, 0x50)
+	v6 := append(append(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2")...), 0x1f, 0x90, 0x00, 0x50)
+	// A TLV after the addresses is skipped
+	v6 = append(v6, 0x04, 0x00, 0x01, 0xff)
+	cases := []struct {
+		header string
+		addr   string
+		valid  bool
+	}{
+		{"PROXY TCP4 12.34.56.78 10.0.0.1 8080 80\r\nGET /", "12.34.56.78:8080", true},
+		{"PROXY TCP6 2001:db8::1 2001:db8::2 8080 80\r\nGET /", "[2001:db8::1]:8080", true},
+		{"PROXY UNKNOWN\r\nGET /", "", true},
+		{"PROXY TCP4 2001:db8::1 10.0.0.1 8080 80\r\nGET /", "", false},
+		{"PROXY TCP4 12.34.56.78 10.0.0.1 99999 80\r\nGET /", "", false},
+		{"PROXY TCP4 12.34.56.78\r\nGET /", "", false},
+		{"PROXY TCP4 12.34.56.78 10.0.0.1 8080 80\nGET /", "", false},
+		{"PROXY " + strings.Repeat("A", 120) + "\r\n", "", false},
+		{"GET /announce HTTP/1.1\r\n", "", false},
+		{string(proxyV2Header(0x1, 0x11, v4)) + "GET /", "12.34.56.78:8080", true},
+		{string(proxyV2Header(0x1, 0x21, v6)) + "GET /", "[2001:db8::1]:8080", true},
+		{string(proxyV2Header(0x0, 0x00, nil)) + "GET /", "", true},
+		{string(proxyV2Header(0x1, 0x11, v4[:8])) + "GET /", "", false},
+		{string(proxyV2Header(0x2, 0x11, v4)) + "GET /", "", false},
+	}
+	for _, c := range cases {
+		r := bufio.NewReader(strings.NewReader(c.header))
+		addr, err := readProxyHeader(r)
+		if !c.valid {
+			require.True(t, errors.Is(err, consts.ErrInvalidProxyHeader), c.header)
+			continue
+		}
+		require.NoError(t, err, c.header)
+		if c.addr == "" {
+			require.Nil(t, addr)
+		} else {
+			require.Equal(t, c.addr, addr.String())
+		}
+		rest, _ := ioutil.ReadAll(r)
+		require.Equal(t, "GET /", string(rest))
+	}
+}
+
+func TestProxyListener(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	send := func(pl *ProxyListener, data string) (net.Addr, string) {
+		conn, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		_, err = conn.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+		accepted, err := pl.Accept()
+		require.NoError(t, err)
+		defer func() { _ = accepted.Close() }()
+		body, _ := ioutil.ReadAll(accepted)
+		return accepted.RemoteAddr(), string(body)
+	}
+	pl := NewProxyListener(l, nil)
+	addr, body := send(pl, "PROXY TCP4 12.34.56.78 10.0.0.1 8080 80\r\nhello")
+	require.Equal(t, "12.34.56.78:8080", addr.String())
+	require.Equal(t, "hello", body)
+	// Connections without a header are rejected
+	addr, body = send(pl, "hello")
+	require.Contains(t, addr.String(), "127.0.0.1")
+	require.Empty(t, body)
+
+	// Only trusted sources are expected to send a header
+	untrusted, err := util.ParseCIDRs([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	addr, body = send(NewProxyListener(l, untrusted), "PROXY TCP4 12.34.56.78 10.0.0.1 8080 80\r\nhello")
+	require.Contains(t, addr.String(), "127.0.0.1")
+	require.Equal(t, "PROXY TCP4 12.34.56.78 10.0.0.1 8080 80\r\nhello", body)
+}
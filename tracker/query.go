@@ -138,12 +138,12 @@ func queryStringParser(qStr string) (*query, error) {
 	return q, nil
 }
 
-func getUint32Key(q *query, key announceParam, def uint32) uint32 {
-	left, err := q.Uint32key(key)
+func getUint64Key(q *query, key announceParam, def uint64) uint64 {
+	left, err := q.Uint64(key)
 	if err != nil {
 		return def
 	}
-	return util.UMax32(0, left)
+	return util.UMax64(0, left)
 }
 
 func getBoolKey(q *query, key announceParam, def bool) bool {
@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ratioMu = &sync.RWMutex{}
+	// ratioEvents holds the ratio watch transitions of each user, oldest first. The last
+	// event is the users current state.
+	ratioEvents = make(map[uint32][]store.RatioWatchEvent)
+)
+
+// loadRatioWatch reads the recorded ratio watch transitions from the store
+func loadRatioWatch() {
+	events, err := db.RatioWatchEvents()
+	if err != nil {
+		log.Fatalf("Failed to load ratio watch events: %s", err)
+	}
+	newEvents := make(map[uint32][]store.RatioWatchEvent)
+	for _, e := range events {
+		newEvents[e.UserID] = append(newEvents[e.UserID], *e)
+	}
+	ratioMu.Lock()
+	ratioEvents = newEvents
+	ratioMu.Unlock()
+}
+
+// ratioRule returns the ratio rule configured for the users role
+func ratioRule(usr *store.User) (config.RatioRule, bool) {
+	if usr.Role != nil {
+		for _, rule := range config.Tracker.RatioRules {
+			if strings.EqualFold(rule.Role, usr.Role.RoleName) {
+				return rule, true
+			}
+		}
+	}
+	return config.RatioRule{}, false
+}
+
+// ratioStatus returns the most recent ratio watch transition of the user. Users which have
+// never been watched are returned as RatioOK.
+func ratioStatus(userID uint32) store.RatioWatchEvent {
+	ratioMu.RLock()
+	defer ratioMu.RUnlock()
+	events := ratioEvents[userID]
+	if len(events) == 0 {
+		return store.RatioWatchEvent{UserID: userID, State: store.RatioOK}
+	}
+	return events[len(events)-1]
+}
+
+// recordRatioEvent persists a ratio watch transition and makes it the users current state.
+// The state is still applied if it cannot be persisted.
+func recordRatioEvent(e store.RatioWatchEvent) {
+	if err := db.RatioWatchAdd(&e); err != nil {
+		log.Errorf("Failed to record ratio watch event: %v", err)
+	}
+	ratioMu.Lock()
+	ratioEvents[e.UserID] = append(ratioEvents[e.UserID], e)
+	ratioMu.Unlock()
+	log.WithFields(log.Fields{
+		"user_id":  e.UserID,
+		"state":    e.State,
+		"ratio":    e.Ratio,
+		"required": e.Required,
+	}).Info("Ratio watch state changed")
+}
+
+// nextRatioState decides the ratio watch state of a user from their current state and ratio
+func nextRatioState(current store.RatioWatchEvent, rule config.RatioRule, ratioOK bool,
+	now time.Time) (store.RatioState, time.Time) {
+	switch {
+	case ratioOK:
+		return store.RatioOK, time.Time{}
+	case current.State == store.RatioOK:
+		return store.RatioWatch, now.Add(rule.GraceParsed)
+	case current.State == store.RatioWatch && rule.Restrict && !now.Before(current.Expires):
+		return store.RatioRestricted, time.Time{}
+	case current.State == store.RatioRestricted && !rule.Restrict:
+		// The rule no longer restricts, so the user goes back to only being warned with a
+		// fresh grace period to recover in
+		return store.RatioWatch, now.Add(rule.GraceParsed)
+	}
+	return current.State, current.Expires
+}
+
+// evaluateRatios checks the totals of every user against the ratio rule of their role and
+// records any change in their ratio watch state. Users of roles without a rule are always
+// considered OK. Returns the number of users which changed state.
+func evaluateRatios(now time.Time) int {
+	changed := 0
+	for _, usr := range Users() {
+		current := ratioStatus(usr.UserID)
+		rule, found := ratioRule(usr)
+		if !found && current.State == store.RatioOK {
+			continue
+		}
+		downloaded := atomic.LoadUint64(&usr.Downloaded)
+		ratio := 0.0
+		if downloaded > 0 {
+			ratio = float64(atomic.LoadUint64(&usr.Uploaded)) / float64(downloaded)
+		}
+		required := rule.Required(downloaded)
+		state, expires := nextRatioState(current, rule, !found || downloaded == 0 || ratio >= required, now)
+		if state == current.State {
+			continue
+		}
+		recordRatioEvent(store.RatioWatchEvent{
+			UserID:    usr.UserID,
+			State:     state,
+			Ratio:     ratio,
+			Required:  required,
+			Expires:   expires,
+			CreatedOn: now,
+		})
+		changed++
+	}
+	return changed
+}
+
+// ratioRestricted checks if the user has been restricted from downloading for their ratio
+func ratioRestricted(usr *store.User) bool {
+	return ratioStatus(usr.UserID).State == store.RatioRestricted
+}
+
+// ratioWarning returns the warning sent to users on ratio watch, if any
+func ratioWarning(usr *store.User) string {
+	status := ratioStatus(usr.UserID)
+	switch status.State {
+	case store.RatioWatch:
+		return fmt.Sprintf("Ratio watch: your ratio is below the required %.2f, recover it by %s",
+			status.Required, status.Expires.Format("2006-01-02 15:04 MST"))
+	case store.RatioRestricted:
+		return fmt.Sprintf("Ratio watch: downloading disabled until your ratio reaches %.2f", status.Required)
+	}
+	return ""
+}
+
+// RatioWatchEvents returns the ratio watch transitions of the user, oldest first
+func RatioWatchEvents(userID uint32) []store.RatioWatchEvent {
+	ratioMu.RLock()
+	defer ratioMu.RUnlock()
+	return append([]store.RatioWatchEvent(nil), ratioEvents[userID]...)
+}
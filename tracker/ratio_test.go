@@ -0,0 +1,90 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/chihaya/bencode"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRatioWatch(t *testing.T) {
+	s := newTestSwarm(t)
+	usr := s.usr
+	usr.Downloaded, usr.Uploaded = 500, 0
+	rules := config.Tracker.RatioRules
+	t.Cleanup(func() { config.Tracker.RatioRules = rules })
+	config.Tracker.RatioRules = []config.RatioRule{{
+		Role:        s.role.RoleName,
+		GraceParsed: 24 * time.Hour,
+		Restrict:    true,
+		Bands:       []config.RatioBand{{Downloaded: 1000, Ratio: 0.5}, {Downloaded: 10000, Ratio: 1}},
+	}}
+	announce := func() (errCode, bencode.Dict) {
+		return s.announce(testReq{left: "1000", event: string(consts.STARTED)})
+	}
+	now := time.Now()
+	// Below the first band
+	require.Equal(t, 0, evaluateRatios(now))
+	require.Empty(t, RatioWatchEvents(usr.UserID))
+
+	usr.Downloaded, usr.Uploaded = 2000, 500
+	evaluateRatios(now)
+	require.Equal(t, store.RatioWatch, ratioStatus(usr.UserID).State)
+	require.Equal(t, 0.5, ratioStatus(usr.UserID).Required)
+	code, resp := announce()
+	require.Equal(t, msgOk, code)
+	require.Contains(t, resp["warning message"], "Ratio watch")
+
+	// Restricted once the grace period passes
+	evaluateRatios(now.Add(time.Hour))
+	require.Equal(t, store.RatioWatch, ratioStatus(usr.UserID).State)
+	evaluateRatios(now.Add(25 * time.Hour))
+	require.Equal(t, store.RatioRestricted, ratioStatus(usr.UserID).State)
+	code, _ = announce()
+	require.Equal(t, msgRatioRestricted, code)
+
+	usr.Uploaded = 1500
+	evaluateRatios(now.Add(26 * time.Hour))
+	require.Equal(t, store.RatioOK, ratioStatus(usr.UserID).State)
+	code, resp = announce()
+	require.Equal(t, msgOk, code)
+	require.NotContains(t, resp, "warning message")
+
+	events := RatioWatchEvents(usr.UserID)
+	require.Len(t, events, 3)
+	require.Equal(t, []store.RatioState{store.RatioWatch, store.RatioRestricted, store.RatioOK},
+		[]store.RatioState{events[0].State, events[1].State, events[2].State})
+	stored, err := db.RatioWatchEvents()
+	require.NoError(t, err)
+	var persisted int
+	for _, e := range stored {
+		if e.UserID == usr.UserID {
+			persisted++
+		}
+	}
+	require.Equal(t, 3, persisted)
+}
+
+func TestNextRatioState(t *testing.T) {
+	now := time.Now()
+	rule := config.RatioRule{GraceParsed: 24 * time.Hour, Restrict: true}
+	state, expires := nextRatioState(store.RatioWatchEvent{State: store.RatioOK}, rule, false, now)
+	require.Equal(t, store.RatioWatch, state)
+	require.Equal(t, now.Add(24*time.Hour), expires)
+	state, _ = nextRatioState(store.RatioWatchEvent{State: store.RatioWatch, Expires: expires}, rule, false, now)
+	require.Equal(t, store.RatioWatch, state)
+	state, _ = nextRatioState(store.RatioWatchEvent{State: store.RatioWatch, Expires: expires}, rule, false, expires)
+	require.Equal(t, store.RatioRestricted, state)
+
+	// Users restricted by a rule which no longer restricts get a new deadline to recover by
+	rule.Restrict = false
+	state, expires = nextRatioState(store.RatioWatchEvent{State: store.RatioRestricted}, rule, false, now)
+	require.Equal(t, store.RatioWatch, state)
+	require.Equal(t, now.Add(24*time.Hour), expires)
+	state, expires = nextRatioState(store.RatioWatchEvent{State: store.RatioRestricted}, rule, true, now)
+	require.Equal(t, store.RatioOK, state)
+	require.True(t, expires.IsZero())
+}
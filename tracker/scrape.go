@@ -9,10 +9,43 @@ import (
 	"net/http"
 )
 
+// scrapeResult holds the swarm counts for a single torrent in a scrape response
+type scrapeResult struct {
+	// Found is false when the info hash does not match a known torrent
+	Found    bool
+	Seeders  uint32
+	Snatches uint32
+	Leechers uint32
+}
+
+// handleScrape fetches the current swarm counts for each of the info hashes provided. The
+// results are returned in the same order as the input. This is shared between the HTTP and UDP
+// front ends.
+func handleScrape(infoHashes []store.InfoHash) []scrapeResult {
+	results := make([]scrapeResult, len(infoHashes))
+	for i, ih := range infoHashes {
+		torrent, err := TorrentGet(ih, false)
+		if err != nil {
+			log.Debugf("Scrape request for invalid torrent: %s", ih)
+			continue
+		}
+		seeders, leechers := torrent.Peers.Counts()
+		results[i] = scrapeResult{
+			Found:    true,
+			Seeders:  seeders,
+			Snatches: torrent.Snatches,
+			Leechers: leechers,
+		}
+	}
+	return results
+}
+
 // scrape handles the bittorrent scrape protocol for
 func scrape(c *gin.Context) {
-	var user store.User
-	if !preFlightChecks(&user, c.Param("passkey"), c) {
+	if requestBanned(c) {
+		return
+	}
+	if _, valid := preFlightChecks(c.Param("passkey"), c); !valid {
 		return
 	}
 	q, err := queryStringParser(c.Request.URL.RawQuery)
@@ -31,22 +64,24 @@ func scrape(c *gin.Context) {
 		return
 	}
 	// Todo limit scrape to N db
-	resp := make(bencode.Dict, len(q.InfoHashes))
-	var ih store.InfoHash
+	var infoHashes []store.InfoHash
 	for _, ihStr := range q.InfoHashes {
+		var ih store.InfoHash
 		if err := store.InfoHashFromString(&ih, ihStr); err != nil {
 			log.Errorf("Failed to decode info hash in scrape: %s", ihStr)
 			continue
 		}
-		torrent, err2 := TorrentGet(ih, false)
-		if err2 != nil {
-			log.Debugf("Scrape request for invalid torrent: %s", ih)
+		infoHashes = append(infoHashes, ih)
+	}
+	resp := make(bencode.Dict, len(infoHashes))
+	for i, result := range handleScrape(infoHashes) {
+		if !result.Found {
 			continue
 		}
-		resp[ih.String()] = bencode.Dict{
-			"complete":   torrent.Seeders,
-			"downloaded": torrent.Snatches,
-			"incomplete": torrent.Leechers,
+		resp[infoHashes[i].String()] = bencode.Dict{
+			"complete":   result.Seeders,
+			"downloaded": result.Snatches,
+			"incomplete": result.Leechers,
 		}
 	}
 	var buf bytes.Buffer
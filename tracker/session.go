@@ -0,0 +1,31 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/metrics"
+	"github.com/viciious/mika/store"
+	log "github.com/sirupsen/logrus"
+	"sync/atomic"
+)
+
+// endSession is called once a peer has left a swarm, either by sending a stopped event or
+// by being reaped after it stopped announcing, in which case the session is abandoned.
+// The time spent in the swarm is added to the peers total time and the users history of the
+// torrent is marked inactive.
+func endSession(tor *store.Torrent, peer *store.Peer, abandoned bool) {
+	elapsed := peer.AnnounceLast.Sub(peer.AnnounceFirst)
+	if elapsed > 0 {
+		peer.TotalTime += elapsed
+	}
+	historyInactive(peer.UserID, tor.InfoHash)
+	if abandoned {
+		atomic.AddInt64(&metrics.SessionsAbandoned, 1)
+	}
+	log.WithFields(log.Fields{
+		"info_hash": tor.InfoHash.String(),
+		"peer_id":   peer.PeerID.String(),
+		"user_id":   peer.UserID,
+		"abandoned": abandoned,
+		"seeder":    peer.IsSeeder(),
+		"duration":  elapsed.String(),
+	}).Debug("Peer session ended")
+}
@@ -0,0 +1,88 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestReapPeers(t *testing.T) {
+	s := newTestSwarm(t)
+	tor := s.tor
+	for i, left := range []string{"0", "1000", "1000"} {
+		pid := testLeechers[0].PeerID
+		pid[19] = byte(i)
+		s.mustAnnounce(testReq{PID: pid, Port: fmt.Sprintf("%d", 4000+i), left: left, event: "started"})
+	}
+	require.Equal(t, uint32(1), tor.Seeders)
+	require.Equal(t, uint32(2), tor.Leechers)
+
+	// Still within the grace period
+	for _, p := range tor.Peers.Peers {
+		p.AnnounceLast = time.Now().Add(-config.Tracker.AnnounceIntervalParsed)
+	}
+	require.Equal(t, 0, reapPeers())
+
+	stale := testLeechers[0].PeerID
+	stale[19] = 1
+	tor.Peers.Peers[stale].AnnounceLast = time.Now().Add(-peerTTL() - time.Second)
+	require.Equal(t, 1, reapPeers())
+	require.Len(t, tor.Peers.Peers, 2)
+	require.Equal(t, uint32(1), tor.Seeders)
+	require.Equal(t, uint32(1), tor.Leechers)
+
+	for _, p := range tor.Peers.Peers {
+		p.AnnounceLast = time.Now().Add(-peerTTL() - time.Second)
+	}
+	require.Equal(t, 2, reapPeers())
+	require.Empty(t, tor.Peers.Peers)
+	require.Equal(t, uint32(0), tor.Seeders)
+	require.Equal(t, uint32(0), tor.Leechers)
+}
+
+func TestSwarmCounts(t *testing.T) {
+	s := newTestSwarm(t)
+	tor := s.tor
+	announce := func(idx byte, left string, event consts.AnnounceType) {
+		pid := testLeechers[0].PeerID
+		pid[19] = idx
+		s.mustAnnounce(testReq{PID: pid, Port: fmt.Sprintf("%d", 4000+int(idx)), left: left, event: string(event)})
+	}
+	counts := func(seeders, leechers, snatches uint32) {
+		s, l := tor.Peers.Counts()
+		require.Equal(t, seeders, s, "Invalid seeders")
+		require.Equal(t, leechers, l, "Invalid leechers")
+		require.Equal(t, snatches, tor.Snatches, "Invalid snatches")
+		require.Equal(t, seeders, tor.Seeders)
+		require.Equal(t, leechers, tor.Leechers)
+	}
+	// Started as a seeder
+	announce(0, "0", consts.STARTED)
+	counts(1, 0, 0)
+	// Leecher completes, then sends a duplicate completed event
+	announce(1, "1000", consts.STARTED)
+	counts(1, 1, 0)
+	announce(1, "0", consts.COMPLETED)
+	announce(1, "0", consts.COMPLETED)
+	counts(2, 0, 1)
+	// Reconnects after completing without a started event
+	announce(1, "0", consts.STOPPED)
+	announce(1, "0", consts.ANNOUNCE)
+	counts(2, 0, 1)
+	// Leecher which never sends a stopped event
+	announce(2, "1000", consts.ANNOUNCE)
+	counts(2, 1, 1)
+	pid := testLeechers[0].PeerID
+	pid[19] = 2
+	tor.Peers.Peers[pid].AnnounceLast = time.Now().Add(-peerTTL() - time.Second)
+	require.Equal(t, 1, reapPeers())
+	counts(2, 0, 1)
+
+	tor.Peers.Leechers = 3
+	require.Equal(t, 1, reconcileSwarms())
+	counts(2, 0, 1)
+	require.Equal(t, 0, reconcileSwarms())
+}
@@ -0,0 +1,172 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	tokensMu = &sync.RWMutex{}
+	// tokens holds the personal freeleech tokens of every user
+	tokens = make(map[store.HistoryKey]*store.FreeleechToken)
+)
+
+// loadTokens reads the freeleech tokens from the store
+func loadTokens() {
+	toks, err := db.Tokens()
+	if err != nil {
+		log.Fatalf("Failed to load freeleech tokens: %s", err)
+	}
+	newTokens := make(map[store.HistoryKey]*store.FreeleechToken)
+	for _, t := range toks {
+		newTokens[t.Key()] = t
+	}
+	tokensMu.Lock()
+	tokens = newTokens
+	tokensMu.Unlock()
+}
+
+// TokenGrant gives the user a freeleech token for the torrent, replacing any token they already
+// have for it. A zero expires never expires and a maxDownloaded of 0 does not limit the amount
+// downloaded for free.
+func TokenGrant(userID uint32, ih store.InfoHash, expires time.Time, maxDownloaded uint64) (store.FreeleechToken, error) {
+	if _, err := UserGetByUserID(userID); err != nil {
+		return store.FreeleechToken{}, err
+	}
+	if _, err := TorrentGet(ih, false); err != nil {
+		return store.FreeleechToken{}, err
+	}
+	now := util.Now()
+	if !expires.IsZero() && !expires.After(now) {
+		return store.FreeleechToken{}, errors.Wrap(consts.ErrMalformedRequest, "Token expiry must be in the future")
+	}
+	token := &store.FreeleechToken{
+		UserID:        userID,
+		InfoHash:      ih,
+		MaxDownloaded: maxDownloaded,
+		Expires:       expires,
+		CreatedOn:     now,
+	}
+	if err := db.TokenSync([]*store.FreeleechToken{token}); err != nil {
+		return store.FreeleechToken{}, err
+	}
+	tokensMu.Lock()
+	tokens[token.Key()] = token
+	tokensMu.Unlock()
+	return *token, nil
+}
+
+// TokenRevoke removes the freeleech token of the user for the torrent
+func TokenRevoke(userID uint32, ih store.InfoHash) error {
+	if err := db.TokenDelete(userID, ih); err != nil {
+		return err
+	}
+	tokensMu.Lock()
+	delete(tokens, store.HistoryKey{UserID: userID, InfoHash: ih})
+	tokensMu.Unlock()
+	return nil
+}
+
+// UserTokens returns copies of the freeleech tokens of the user, newest first
+func UserTokens(userID uint32) []store.FreeleechToken {
+	var toks []store.FreeleechToken
+	tokensMu.RLock()
+	for _, t := range tokens {
+		if t.UserID == userID {
+			toks = append(toks, *t)
+		}
+	}
+	tokensMu.RUnlock()
+	sort.Slice(toks, func(i, j int) bool {
+		return toks[i].CreatedOn.After(toks[j].CreatedOn)
+	})
+	return toks
+}
+
+// tokenFreeleech returns how much of the amount downloaded is covered by the users freeleech
+// token for the torrent, charging it to the token
+func tokenFreeleech(userID uint32, ih store.InfoHash, downloaded uint64, now time.Time) uint64 {
+	if downloaded == 0 {
+		return 0
+	}
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+	t, found := tokens[store.HistoryKey{UserID: userID, InfoHash: ih}]
+	if !found {
+		return 0
+	}
+	free := downloaded
+	if remaining := t.Remaining(now); remaining < free {
+		free = remaining
+	}
+	if free > 0 {
+		t.Downloaded += free
+		t.Writes++
+	}
+	return free
+}
+
+// pruneTokens removes the tokens which have expired, returning the number removed
+func pruneTokens(now time.Time) int {
+	var expired []store.HistoryKey
+	tokensMu.RLock()
+	for key, t := range tokens {
+		if t.Expired(now) {
+			expired = append(expired, key)
+		}
+	}
+	tokensMu.RUnlock()
+	removed := 0
+	for _, key := range expired {
+		if err := TokenRevoke(key.UserID, key.InfoHash); err != nil {
+			log.Errorf("Failed to remove expired freeleech token: %v", err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// findDirtyTokens returns copies of up to n tokens with pending changes. The pending changes
+// of the returned tokens are reset.
+func findDirtyTokens(n int) []*store.FreeleechToken {
+	var batch []*store.FreeleechToken
+	tokensMu.Lock()
+	defer tokensMu.Unlock()
+	for _, t := range tokens {
+		if len(batch) >= n {
+			break
+		}
+		if t.Writes > 0 {
+			token := *t
+			batch = append(batch, &token)
+			t.Writes = 0
+		}
+	}
+	return batch
+}
+
+// tokenSync persists a batch of tokens. On failure the tokens are marked dirty again so they
+// are retried on the next sync.
+func tokenSync(batch []*store.FreeleechToken) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := db.TokenSync(batch); err != nil {
+		tokensMu.Lock()
+		for _, b := range batch {
+			if t, found := tokens[b.Key()]; found {
+				t.Writes++
+			}
+		}
+		tokensMu.Unlock()
+		return err
+	}
+	return nil
+}
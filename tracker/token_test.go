@@ -0,0 +1,55 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestFreeleechTokens(t *testing.T) {
+	s := newTestSwarm(t)
+	usr, tor := s.usr, s.tor
+	announce := func(downloaded string, event consts.AnnounceType) {
+		s.mustAnnounce(testReq{Downloaded: downloaded, left: "100000", event: string(event)})
+	}
+	_, err := TokenGrant(usr.UserID, store.InfoHash{}, time.Time{}, 0)
+	require.Equal(t, consts.ErrInvalidInfoHash, err)
+	_, err = TokenGrant(usr.UserID, tor.InfoHash, time.Now().Add(-time.Hour), 0)
+	require.Error(t, err)
+	token, err := TokenGrant(usr.UserID, tor.InfoHash, time.Now().Add(time.Hour), 3000)
+	require.NoError(t, err)
+	require.Len(t, UserTokens(usr.UserID), 1)
+
+	// Only the amount up to the cap is free
+	announce("0", consts.STARTED)
+	announce("5000", consts.ANNOUNCE)
+	require.Equal(t, uint64(2000), usr.Downloaded)
+	require.Equal(t, uint64(5000), usr.DownloadedReal)
+	require.Equal(t, uint64(3000), UserTokens(usr.UserID)[0].Downloaded)
+	announce("6000", consts.ANNOUNCE)
+	require.Equal(t, uint64(3000), usr.Downloaded)
+
+	// Usage is persisted on sync
+	require.NoError(t, tokenSync(findDirtyTokens(100)))
+	require.Empty(t, findDirtyTokens(100))
+	stored, err := db.Tokens()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, uint64(3000), stored[0].Downloaded)
+
+	// Expired tokens no longer apply and are removed
+	_, err = TokenGrant(usr.UserID, tor.InfoHash, time.Time{}, 0)
+	require.NoError(t, err)
+	announce("7000", consts.ANNOUNCE)
+	require.Equal(t, uint64(3000), usr.Downloaded)
+	tokensMu.Lock()
+	tokens[token.Key()].Expires = time.Now().Add(-time.Second)
+	tokensMu.Unlock()
+	announce("8000", consts.ANNOUNCE)
+	require.Equal(t, uint64(4000), usr.Downloaded)
+	require.Equal(t, 1, pruneTokens(time.Now()))
+	require.Empty(t, UserTokens(usr.UserID))
+	require.Equal(t, consts.ErrInvalidToken, TokenRevoke(usr.UserID, tor.InfoHash))
+}
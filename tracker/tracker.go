@@ -5,6 +5,7 @@ import (
 	"github.com/viciious/mika/config"
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/geo"
+	"github.com/viciious/mika/metrics"
 	"github.com/viciious/mika/store"
 	"github.com/viciious/mika/util"
 	"github.com/pkg/errors"
@@ -22,23 +23,29 @@ var (
 	storeMu     *sync.RWMutex
 	db          store.Store
 	users       store.Users
+	usersMu     *sync.RWMutex
 	roles       store.Roles
 	whitelist   store.WhiteList
 	torrents    store.Torrents
+	torrentsMu  *sync.RWMutex
 	geodb       geo.Provider
 	whitelistMu *sync.RWMutex
+	selector    store.PeerSelector
 )
 
 func init() {
 	storeMu = &sync.RWMutex{}
 	whitelist = make(store.WhiteList)
 	whitelistMu = &sync.RWMutex{}
+	usersMu = &sync.RWMutex{}
+	torrentsMu = &sync.RWMutex{}
 	memCfg := config.StoreConfig{Type: "memory"}
 	ts, _ := store.NewStore(memCfg)
 	db = ts
 	torrents = make(store.Torrents)
 	users = make(store.Users)
 	roles = make(store.Roles)
+	selector, _ = store.NewPeerSelector("random", config.Tracker.PeerSelectorMinSeeders, false)
 }
 
 func Init() {
@@ -59,6 +66,12 @@ func Init() {
 		newGeodb = &geo.DummyProvider{}
 	}
 	geodb = newGeodb
+	newSelector, err := store.NewPeerSelector(config.Tracker.PeerSelector,
+		config.Tracker.PeerSelectorMinSeeders, config.Tracker.PeerSelectorPreferFast)
+	if err != nil {
+		log.Fatalf("Failed to setup peer selector: %s", err)
+	}
+	selector = newSelector
 
 	if err := Migrate(); err != nil {
 		log.Fatalf("Failed to perform migration: %v", err)
@@ -66,8 +79,27 @@ func Init() {
 
 	whitelist = loadWhitelist()
 	roles = loadRoles()
-	users = loadUsers()
-	torrents = loadTorrents()
+	newUsers := loadUsers()
+	usersMu.Lock()
+	users = newUsers
+	usersMu.Unlock()
+	newTorrents := loadTorrents()
+	torrentsMu.Lock()
+	torrents = newTorrents
+	torrentsMu.Unlock()
+	loadHistory()
+	loadRatioWatch()
+	loadTokens()
+	loadSpeedProfiles()
+	if err := loadEvents(); err != nil {
+		log.Fatalf("Failed to load events: %s", err)
+	}
+	if err := loadBans(); err != nil {
+		log.Fatalf("Failed to load bans: %s", err)
+	}
+	if err := loadRetiredPasskeys(); err != nil {
+		log.Fatalf("Failed to load retired passkeys: %s", err)
+	}
 }
 
 func mapRoleToUser(u *store.User) {
@@ -83,7 +115,7 @@ func loadWhitelist() store.WhiteList {
 		log.Warn("whitelist empty, all clients are allowed")
 	} else {
 		for _, cw := range wl {
-			newWhitelist[cw.ClientPrefix] = cw
+			newWhitelist[cw.WhiteListID] = cw
 		}
 	}
 	return newWhitelist
@@ -119,20 +151,65 @@ func loadTorrents() store.Torrents {
 	return torrentSet
 }
 
-// PeerReaper will call the store.PeerStore.Reap() function periodically. This is
-// used to clean peers that have not announced in a while from the swarm.
+// peerTTL is how long a peer can go without announcing before it is considered gone
+func peerTTL() time.Duration {
+	return config.Tracker.AnnounceIntervalParsed + config.Tracker.ReaperGraceParsed
+}
+
+// reapPeers removes the expired peers from every swarm, ending their sessions as abandoned.
+// The number of peers removed is returned.
+func reapPeers() int {
+	ttl := peerTTL()
+	reaped := 0
+	for _, tor := range Torrents() {
+		expired := tor.Peers.ReapExpired(ttl)
+		for _, peer := range expired {
+			endSession(tor, peer, true)
+			reaped++
+		}
+		if len(expired) > 0 && tor.UpdateCounts() {
+			atomic.AddUint32(&tor.Writes, 1)
+		}
+	}
+	atomic.AddInt64(&metrics.PeersReaped, int64(reaped))
+	return reaped
+}
+
+// reconcileSwarms recounts the seeders and leechers of every swarm from the state of its
+// peers, correcting any drift, and refreshes the persisted torrent counts. The number of
+// swarms which had drifted is returned.
+func reconcileSwarms() int {
+	drifted := 0
+	for _, tor := range Torrents() {
+		if tor.Peers == nil {
+			continue
+		}
+		if tor.Peers.Reconcile() {
+			tor.Log().Warn("Corrected drifted swarm counts")
+			drifted++
+		}
+		if tor.UpdateCounts() {
+			atomic.AddUint32(&tor.Writes, 1)
+		}
+	}
+	return drifted
+}
+
+// PeerReaper will call reapPeers periodically. This is used to clean peers that have
+// not announced in a while from the swarms. The swarm counts are reconciled and hit and runs
+// flagged afterwards.
 func PeerReaper(ctx context.Context) {
 	peerTimer := time.NewTimer(config.Tracker.ReaperIntervalParsed)
 	for {
 		select {
 		case <-peerTimer.C:
-			// TODO FIXME
-			//expired := peers.Reap()
-			//if PeerCache != nil {
-			//	for _, ph := range expired {
-			//		PeerCache.Delete(ph.InfoHash(), ph.PeerID())
-			//	}
-			//}
+			if reaped := reapPeers(); reaped > 0 {
+				log.Debugf("Reaped %d expired peers", reaped)
+			}
+			reconcileSwarms()
+			if flagged := flagHitAndRuns(time.Now()); flagged > 0 {
+				log.Debugf("Flagged %d hit and runs", flagged)
+			}
 			// We use a timer here so that config updates for the interval get applied
 			// on the next tick
 			peerTimer.Reset(config.Tracker.ReaperIntervalParsed)
@@ -142,28 +219,90 @@ func PeerReaper(ctx context.Context) {
 	}
 }
 
+// findDirtyUsers returns up to n users with pending changes, most frequently written first
 func findDirtyUsers(n int) ([]*store.User, error) {
 	var sorted []*store.User
-	for _, t := range users {
-		sorted = append(sorted, t)
+	usersMu.RLock()
+	for _, u := range users {
+		if atomic.LoadUint32(&u.Writes) > 0 {
+			sorted = append(sorted, u)
+		}
 	}
+	usersMu.RUnlock()
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Writes < sorted[j].Writes
+		return sorted[i].Writes > sorted[j].Writes
 	})
 	return sorted[0:util.Min(n, len(sorted))], nil
 }
 
+// findDirtyTorrents returns up to n torrents with pending changes, most frequently written first
 func findDirtyTorrents(n int) ([]*store.Torrent, error) {
 	var sorted []*store.Torrent
-	for _, t := range torrents {
-		sorted = append(sorted, t)
+	for _, t := range Torrents() {
+		if atomic.LoadUint32(&t.Writes) > 0 {
+			sorted = append(sorted, t)
+		}
 	}
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Writes < sorted[j].Writes
+		return sorted[i].Writes > sorted[j].Writes
 	})
 	return sorted[0:util.Min(n, len(sorted))], nil
 }
 
+// syncStats writes the pending user, torrent and history changes to the backing store and
+// evaluates the ratio rules against the updated user totals. A failure in one step is
+// logged and does not stop the others.
+func syncStats() {
+	dUsers, err := findDirtyUsers(100)
+	if err != nil {
+		log.Errorf("Failed to fetch dirty users: %v", err)
+	} else if err2 := userSync(dUsers); err2 != nil {
+		log.Errorf("Failed to sync dirty users: %v", err2)
+	}
+	dTorrents, err3 := findDirtyTorrents(100)
+	if err3 != nil {
+		log.Errorf("Failed to fetch dirty torrents: %v", err3)
+	} else if err4 := torrentSync(dTorrents); err4 != nil {
+		log.Errorf("Failed to sync dirty torrents: %v", err4)
+	}
+	if err5 := historySync(findDirtyHistory(100)); err5 != nil {
+		log.Errorf("Failed to sync dirty history: %v", err5)
+	}
+	if changed := evaluateRatios(time.Now()); changed > 0 {
+		log.Debugf("Ratio watch state changed for %d users", changed)
+	}
+	if err6 := loadEvents(); err6 != nil {
+		log.Errorf("Failed to reload events: %v", err6)
+	}
+	if err7 := tokenSync(findDirtyTokens(100)); err7 != nil {
+		log.Errorf("Failed to sync dirty freeleech tokens: %v", err7)
+	}
+	if removed := pruneTokens(time.Now()); removed > 0 {
+		log.Debugf("Removed %d expired freeleech tokens", removed)
+	}
+	if err8 := speedProfileSync(findDirtySpeedProfiles(500)); err8 != nil {
+		log.Errorf("Failed to sync dirty speed profiles: %v", err8)
+	}
+	if removed := pruneSpeedProfiles(time.Now()); removed > 0 {
+		log.Debugf("Removed %d stale speed profiles", removed)
+	}
+	if err10 := cheatReportSync(); err10 != nil {
+		log.Errorf("Failed to sync cheat reports: %v", err10)
+	}
+	if err9 := loadBans(); err9 != nil {
+		log.Errorf("Failed to reload bans: %v", err9)
+	}
+	if removed := pruneBans(time.Now()); removed > 0 {
+		log.Debugf("Removed %d expired bans", removed)
+	}
+	if removed := prunePasskeys(util.Now()); removed > 0 {
+		log.Debugf("Removed %d expired passkeys", removed)
+	}
+	pruneUserAgentReports(util.Now())
+	pruneCheatReports(util.Now())
+	pruneTrapLeaks(util.Now())
+}
+
 // StatWorker handles summing up stats for users/peers/db to be sent to the
 // backing stores for long term storage.
 // No locking required for these data sets
@@ -172,25 +311,8 @@ func StatWorker(ctx context.Context) {
 	for {
 		select {
 		case <-syncTimer.C:
-			dUsers, err := findDirtyUsers(100)
-			if err != nil {
-				log.Errorf("Failed to fetch dirty users: %v", err)
-				continue
-			}
-			if err2 := userSync(dUsers); err2 != nil {
-				log.Errorf("Failed to sync dirty users: %v", err2)
-				continue
-			}
-
-			dTorrents, err3 := findDirtyTorrents(100)
-			if err3 != nil {
-				log.Errorf("Failed to fetch dirty torrents: %v", err3)
-				continue
-			}
-			if err4 := torrentSync(dTorrents); err4 != nil {
-				log.Errorf("Failed to sync dirty torrents: %v", err4)
-				continue
-			}
+			syncStats()
+			// The timer is always reset, even after a failed sync, so the worker keeps running
 			syncTimer.Reset(config.Tracker.BatchUpdateIntervalParsed)
 		case <-ctx.Done():
 			log.Debugf("Batch context closed")
@@ -240,62 +362,154 @@ func Migrate() error {
 	return db.Migrate()
 }
 
-func ClientWhitelisted(peerID store.PeerID) bool {
-	var found = true
+// ClientWhitelisted checks the client against the whitelist rules. Deny rules are checked first
+// and the message of the rule rejecting the client is returned, which is empty when the rule has
+// none. Once any allow rule exists the client must match one, an empty whitelist allows every
+// client.
+func ClientWhitelisted(peerID store.PeerID) (bool, string) {
 	whitelistMu.RLock()
-	if len(whitelist) > 0 {
-		_, found = whitelist[string(peerID[0:8])]
+	defer whitelistMu.RUnlock()
+	if len(whitelist) == 0 {
+		return true, ""
+	}
+	client := store.ClientString(peerID)
+	var (
+		denied   *store.WhiteListClient
+		hasAllow bool
+		allowed  bool
+	)
+	for _, wl := range whitelist {
+		if !wl.Denies() {
+			hasAllow = true
+		}
+		if !wl.Match(peerID, client) {
+			continue
+		}
+		if !wl.Denies() {
+			allowed = true
+		} else if denied == nil || wl.WhiteListID < denied.WhiteListID {
+			denied = wl
+		}
+	}
+	if denied != nil {
+		return false, denied.Message
 	}
-	whitelistMu.RUnlock()
-	return found
+	return allowed || !hasAllow, ""
 }
 
+// validateWhiteList checks the rule can match clients, setting the default action
+func validateWhiteList(wl *store.WhiteListClient) error {
+	if wl.Action == "" {
+		wl.Action = store.WhiteListAllow
+	}
+	if wl.Action != store.WhiteListAllow && wl.Action != store.WhiteListDeny {
+		return errors.Wrapf(consts.ErrMalformedRequest, "Invalid action: %s", wl.Action)
+	}
+	if wl.ClientPrefix == "" && wl.Client == "" {
+		return errors.Wrap(consts.ErrMalformedRequest, "Must supply a client prefix or name")
+	}
+	if len(wl.ClientPrefix) > 8 {
+		return errors.Wrapf(consts.ErrMalformedRequest, "Client prefix too long: %s", wl.ClientPrefix)
+	}
+	var versions []store.ClientVersion
+	for _, v := range []string{wl.MinVersion, wl.MaxVersion} {
+		if v == "" {
+			continue
+		}
+		parsed, err := store.ParseClientVersion(v)
+		if err != nil {
+			return errors.Wrap(consts.ErrMalformedRequest, err.Error())
+		}
+		versions = append(versions, parsed)
+	}
+	if len(versions) == 2 && versions[0].Compare(versions[1]) > 0 {
+		return errors.Wrap(consts.ErrMalformedRequest, "Min version is newer than the max version")
+	}
+	return nil
+}
+
+// WhiteListAdd validates and adds a new client whitelist rule
 func WhiteListAdd(wl *store.WhiteListClient) error {
+	if err := validateWhiteList(wl); err != nil {
+		return err
+	}
 	if err := db.WhiteListAdd(wl); err != nil {
 		return errors.Wrap(err, "Failed to add new client whitelist")
 	}
 	whitelistMu.Lock()
 	defer whitelistMu.Unlock()
-	whitelist[wl.ClientPrefix] = wl
+	whitelist[wl.WhiteListID] = wl
 	return nil
 }
 
-func WhiteListGet(p string) (*store.WhiteListClient, error) {
-	w, found := whitelist[p]
+// WhiteListGet returns the client whitelist rule
+func WhiteListGet(whiteListID uint32) (*store.WhiteListClient, error) {
+	whitelistMu.RLock()
+	defer whitelistMu.RUnlock()
+	w, found := whitelist[whiteListID]
 	if !found {
 		return nil, consts.ErrInvalidClient
 	}
 	return w, nil
 }
 
+// WhiteListDelete removes a client whitelist rule
 func WhiteListDelete(wl *store.WhiteListClient) error {
 	if err := db.WhiteListDelete(wl); err != nil {
 		return err
 	}
-	delete(whitelist, wl.ClientPrefix)
+	whitelistMu.Lock()
+	delete(whitelist, wl.WhiteListID)
+	whitelistMu.Unlock()
 	return nil
 }
 
+// WhiteList returns a copy of the client whitelist rules
 func WhiteList() store.WhiteList {
-	return whitelist
+	whitelistMu.RLock()
+	defer whitelistMu.RUnlock()
+	wl := make(store.WhiteList, len(whitelist))
+	for id, w := range whitelist {
+		wl[id] = w
+	}
+	return wl
 }
 
+// Torrents returns a copy of the torrents map so it can be iterated without holding torrentsMu
 func Torrents() store.Torrents {
-	return torrents
+	torrentsMu.RLock()
+	defer torrentsMu.RUnlock()
+	all := make(store.Torrents, len(torrents))
+	for ih, t := range torrents {
+		all[ih] = t
+	}
+	return all
 }
 
 func TorrentAdd(torrent *store.Torrent) error {
+	if torrent.TrapUserID > 0 {
+		if _, err := UserGetByUserID(torrent.TrapUserID); err != nil {
+			return err
+		}
+	}
 	torrent.CreatedOn = util.Now()
 	torrent.UpdatedOn = util.Now()
 	if err := db.TorrentAdd(torrent); err != nil {
 		return errors.Wrapf(err, "Failed to add torrent")
 	}
+	if torrent.Peers == nil {
+		torrent.Peers = store.NewSwarm()
+	}
+	torrentsMu.Lock()
 	torrents[torrent.InfoHash] = torrent
+	torrentsMu.Unlock()
 	return nil
 }
 
 func TorrentGet(hash store.InfoHash, deletedOk bool) (*store.Torrent, error) {
+	torrentsMu.RLock()
 	t, found := torrents[hash]
+	torrentsMu.RUnlock()
 	if !found {
 		return nil, consts.ErrInvalidInfoHash
 	}
@@ -336,6 +550,9 @@ func TorrentDelete(torrent *store.Torrent) error {
 //}
 
 func torrentSync(batch []*store.Torrent) error {
+	if len(batch) == 0 {
+		return nil
+	}
 	if err := db.TorrentSync(batch); err != nil {
 		return err
 	}
@@ -3,14 +3,18 @@ package tracker
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"github.com/chihaya/bencode"
 	"github.com/viciious/mika/config"
 	"github.com/viciious/mika/store"
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"testing"
+	"time"
 )
 
 var (
@@ -56,6 +60,9 @@ type testReq struct {
 	Downloaded string
 	left       string
 	event      string
+	numWant    string
+	// userAgent is sent as the User-Agent header, not as a query value
+	userAgent string
 }
 
 // ToValues will generate query  values
@@ -80,9 +87,95 @@ func (t testReq) ToValues() url.Values {
 	if t.event != "" {
 		v.Set("event", t.event)
 	}
+	if t.numWant != "" {
+		v.Set("numwant", t.numWant)
+	}
 	return v
 }
 
+// testSwarm is a role, user and torrent of their own for tests which announce, so the
+// announces of other tests do not change the results
+type testSwarm struct {
+	t    *testing.T
+	rh   http.Handler
+	role *store.Role
+	usr  *store.User
+	tor  *store.Torrent
+}
+
+// newTestSwarm adds a new role, user and torrent. The role and user multipliers are unset and
+// the user starts without any transfer so the amounts credited can be checked directly.
+func newTestSwarm(t *testing.T) *testSwarm {
+	role := store.GenerateTestRole()
+	role.MultiUp, role.MultiDown = -1, -1
+	require.NoError(t, RoleAdd(&role))
+	usr := store.GenerateTestUser()
+	usr.RoleID = role.RoleID
+	usr.Uploaded, usr.Downloaded = 0, 0
+	require.NoError(t, UserAdd(&usr))
+	s := &testSwarm{t: t, rh: NewBitTorrentHandler(), role: &role, usr: &usr}
+	s.tor = s.addTorrent()
+	return s
+}
+
+// addTorrent adds another torrent for the swarm user to announce
+func (s *testSwarm) addTorrent() *store.Torrent {
+	tor := store.GenerateTestTorrent()
+	require.NoError(s.t, TorrentAdd(&tor))
+	return &tor
+}
+
+// announce sends the announce, returning the response code and the decoded response. Unset
+// fields announce the swarm torrent as testLeechers[0] of the swarm user from 12.34.56.78:4000,
+// with nothing transferred and nothing left.
+func (s *testSwarm) announce(req testReq) (errCode, bencode.Dict) {
+	if req.PK == "" {
+		req.PK = s.usr.Passkey
+	}
+	if req.Ih == (store.InfoHash{}) && req.IhStr == "" {
+		req.Ih = s.tor.InfoHash
+	}
+	if req.PID == (store.PeerID{}) && req.PIDStr == "" {
+		req.PID = testLeechers[0].PeerID
+	}
+	orDefault := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	orDefault(&req.IP, "12.34.56.78")
+	orDefault(&req.Port, "4000")
+	orDefault(&req.Uploaded, "0")
+	orDefault(&req.Downloaded, "0")
+	orDefault(&req.left, "0")
+	r := httptest.NewRequest("GET", fmt.Sprintf("/announce/%s?%s", req.PK, req.ToValues().Encode()), nil)
+	r.RemoteAddr = "50.50.50.50:9000"
+	if req.userAgent != "" {
+		r.Header.Set("User-Agent", req.userAgent)
+	}
+	w := httptest.NewRecorder()
+	s.rh.ServeHTTP(w, r)
+	// Responses with 1xx codes have no body to decode
+	resp, _ := bencode.NewDecoder(w.Body).Decode()
+	dict, _ := resp.(bencode.Dict)
+	return errCode(w.Code), dict
+}
+
+// mustAnnounce sends the announce like announce, failing the test unless it succeeds
+func (s *testSwarm) mustAnnounce(req testReq) bencode.Dict {
+	code, resp := s.announce(req)
+	require.Equal(s.t, msgOk, code)
+	return resp
+}
+
+// rewind moves the last announce of the peer on the swarm torrent back by d, as if the time
+// had passed before its next announce
+func (s *testSwarm) rewind(pid store.PeerID, d time.Duration) {
+	p, err := s.tor.Peers.Get(pid)
+	require.NoError(s.t, err)
+	p.AnnounceLast = time.Now().Add(-d)
+}
+
 type scrapeReq struct {
 	PK         string
 	InfoHashes []store.InfoHash
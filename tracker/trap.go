@@ -0,0 +1,158 @@
+package tracker
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"sync"
+	"time"
+)
+
+// trapLeakWindow is how long a leaker must stop announcing a trap before their next announce
+// is recorded as a new leak
+const trapLeakWindow = 24 * time.Hour
+
+// trapLeakKey identifies a single leaker of a trap so their repeated announces are only
+// recorded once
+type trapLeakKey struct {
+	InfoHash    store.InfoHash
+	AnnouncerID uint32
+	IP          string
+}
+
+var (
+	trapMu = &sync.RWMutex{}
+	// trapLeaksSeen holds when each recorded leak was last announced
+	trapLeaksSeen = make(map[trapLeakKey]time.Time)
+	// trapsReset holds the traps which have already had the passkey of their owner reset
+	trapsReset = make(map[store.InfoHash]bool)
+)
+
+// TrapLeaks returns the recorded leaks of the trap torrents registered to the user, oldest
+// first. A userID of 0 returns the leaks of every user.
+func TrapLeaks(userID uint32) ([]store.TrapLeak, error) {
+	leaks, err := db.TrapLeaks(userID)
+	if err != nil {
+		return nil, err
+	}
+	var all []store.TrapLeak
+	for _, l := range leaks {
+		all = append(all, *l)
+	}
+	return all, nil
+}
+
+// trapLeaked checks if an announce for a trap torrent was made by anybody other than the user
+// it was registered to, recording the leak when it was. The announcer is nil when the announce
+// did not have a valid passkey.
+func trapLeaked(tor *store.Torrent, announcer *store.User, ip net.IP, peerID store.PeerID) bool {
+	if tor.TrapUserID == 0 {
+		return false
+	}
+	leak := store.TrapLeak{
+		InfoHash:  tor.InfoHash,
+		UserID:    tor.TrapUserID,
+		IP:        ip.String(),
+		Client:    store.ClientString(peerID).String(),
+		CreatedOn: util.Now(),
+	}
+	if announcer != nil {
+		if announcer.UserID == tor.TrapUserID {
+			return false
+		}
+		leak.AnnouncerID = announcer.UserID
+	}
+	key := trapLeakKey{InfoHash: leak.InfoHash, AnnouncerID: leak.AnnouncerID, IP: leak.IP}
+	trapMu.Lock()
+	_, seen := trapLeaksSeen[key]
+	trapLeaksSeen[key] = leak.CreatedOn
+	trapMu.Unlock()
+	if seen {
+		return true
+	}
+	owner, err := UserGetByUserID(tor.TrapUserID)
+	if err != nil {
+		log.Errorf("Trap torrent %s registered to unknown user: %d", tor.InfoHash.String(), tor.TrapUserID)
+	} else if config.Tracker.TrapResetPasskey && trapResetFirst(tor) {
+		if err := userChangePasskey(owner, util.NewPasskey()); err != nil {
+			log.Errorf("Failed to reset passkey of trap leaker: %v", err)
+		} else {
+			leak.PasskeyReset = true
+		}
+	}
+	if err := db.TrapLeakAdd(&leak); err != nil {
+		log.Errorf("Failed to record trap leak: %v", err)
+	}
+	log.WithFields(log.Fields{
+		"user_id":      leak.UserID,
+		"announcer_id": leak.AnnouncerID,
+		"info_hash":    leak.InfoHash.String(),
+		"ip":           leak.IP,
+		"client":       leak.Client,
+	}).Warn("Trap torrent leaked")
+	return true
+}
+
+// pruneTrapLeaks forgets the leaks which have not been announced within the trap leak window
+func pruneTrapLeaks(now time.Time) {
+	trapMu.Lock()
+	defer trapMu.Unlock()
+	for key, last := range trapLeaksSeen {
+		if now.Sub(last) >= trapLeakWindow {
+			delete(trapLeaksSeen, key)
+		}
+	}
+}
+
+// trapResetFirst checks if the passkey of the trap owner has not been reset for a leak of the
+// trap yet, marking it as reset. Each trap only resets the passkey once, otherwise every new
+// leaker would reset the passkey of the owner again. Resets made before the tracker restarted
+// are found in the recorded leaks.
+func trapResetFirst(tor *store.Torrent) bool {
+	trapMu.Lock()
+	reset := trapsReset[tor.InfoHash]
+	trapsReset[tor.InfoHash] = true
+	trapMu.Unlock()
+	if reset {
+		return false
+	}
+	leaks, err := db.TrapLeaks(tor.TrapUserID)
+	if err != nil {
+		log.Errorf("Failed to read trap leaks: %v", err)
+		return true
+	}
+	for _, l := range leaks {
+		if l.InfoHash == tor.InfoHash && l.PasskeyReset {
+			return false
+		}
+	}
+	return true
+}
+
+// trapUnauthorized checks announces which failed authentication for trap torrents. Passkeys
+// are usually stripped from torrents shared on public sites so this is where most leaked
+// traps are announced.
+func trapUnauthorized(c *gin.Context) {
+	q, err := queryStringParser(c.Request.URL.RawQuery)
+	if err != nil {
+		return
+	}
+	var ih store.InfoHash
+	if err := store.InfoHashFromString(&ih, q.Params[paramInfoHash]); err != nil {
+		return
+	}
+	tor, err := TorrentGet(ih, false)
+	if err != nil || tor.TrapUserID == 0 {
+		return
+	}
+	ip, _, err := getIP(q, config.Tracker.AllowClientIP, c)
+	if err != nil || ip == nil {
+		return
+	}
+	// Passkeys of disabled users still identify who announced
+	announcer, _ := UserGetByPasskey(c.Param("passkey"))
+	trapLeaked(tor, announcer, ip, store.PeerIDFromString(q.Params[paramPeerID]))
+}
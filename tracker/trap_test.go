@@ -0,0 +1,89 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTrapTorrents(t *testing.T) {
+	resetPasskey := config.Tracker.TrapResetPasskey
+	defer func() { config.Tracker.TrapResetPasskey = resetPasskey }()
+	config.Tracker.TrapResetPasskey = true
+	rh := NewBitTorrentHandler()
+	owner := store.GenerateTestUser()
+	owner.RoleID = testUsers[0].RoleID
+	require.NoError(t, UserAdd(&owner))
+	trap := store.GenerateTestTorrent()
+	trap.TrapUserID = 0xffffff
+	require.Equal(t, consts.ErrInvalidUser, TorrentAdd(&trap))
+	trap.TrapUserID = owner.UserID
+	require.NoError(t, TorrentAdd(&trap))
+	announce := func(path string, pk string, ip string) errCode {
+		req := testReq{Ih: trap.InfoHash, PID: testLeechers[0].PeerID, IP: ip, Port: "4000", Uploaded: "0",
+			Downloaded: "0", left: "1000", event: string(consts.STARTED), PK: pk}
+		w := performRequest(rh, "GET", fmt.Sprintf("%s?%s", path, req.ToValues().Encode()), nil, nil)
+		return errCode(w.Code)
+	}
+
+	// The owner announcing their own trap is not a leak
+	originalPasskey := owner.Passkey
+	require.Equal(t, msgOk, announce("/announce/"+owner.Passkey, owner.Passkey, "12.34.56.78"))
+	leaks, err := TrapLeaks(owner.UserID)
+	require.NoError(t, err)
+	require.Empty(t, leaks)
+
+	// Another user announcing it
+	other := testUsers[1]
+	require.Equal(t, msgInvalidInfoHash, announce("/announce/"+other.Passkey, other.Passkey, "12.34.56.79"))
+	leaks, err = TrapLeaks(owner.UserID)
+	require.NoError(t, err)
+	require.Len(t, leaks, 1)
+	require.Equal(t, other.UserID, leaks[0].AnnouncerID)
+	require.Equal(t, "12.34.56.79", leaks[0].IP)
+	require.Equal(t, store.ClientString(testLeechers[0].PeerID).String(), leaks[0].Client)
+	require.True(t, leaks[0].PasskeyReset)
+	require.NotEqual(t, originalPasskey, owner.Passkey)
+	_, err = UserGetByPasskey(originalPasskey)
+	require.Equal(t, consts.ErrInvalidUser, err)
+	usr, err := UserGetByPasskey(owner.Passkey)
+	require.NoError(t, err)
+	require.Equal(t, owner.UserID, usr.UserID)
+
+	// Repeated announces by the same leaker are only recorded once
+	require.Equal(t, msgInvalidInfoHash, announce("/announce/"+other.Passkey, other.Passkey, "12.34.56.79"))
+
+	// Announces without a passkey, like those made to public trackers. Further leaks are
+	// recorded without resetting the passkey of the owner again.
+	resetTo := owner.Passkey
+	require.Equal(t, msgInvalidAuth, announce("/announce", "", "12.34.56.80"))
+	// The leaked passkey of the owner no longer works either
+	require.Equal(t, msgInvalidAuth, announce("/announce/"+originalPasskey, originalPasskey, "12.34.56.81"))
+	leaks, err = TrapLeaks(owner.UserID)
+	require.NoError(t, err)
+	require.Len(t, leaks, 3)
+	require.Equal(t, uint32(0), leaks[1].AnnouncerID)
+	require.Equal(t, "12.34.56.80", leaks[1].IP)
+	require.Equal(t, "12.34.56.81", leaks[2].IP)
+	require.False(t, leaks[1].PasskeyReset)
+	require.False(t, leaks[2].PasskeyReset)
+	require.Equal(t, resetTo, owner.Passkey)
+	all, err := TrapLeaks(0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	// Leakers which stop announcing are recorded again when they come back
+	pruneTrapLeaks(time.Now().Add(trapLeakWindow))
+	trapMu.RLock()
+	require.Empty(t, trapLeaksSeen)
+	trapMu.RUnlock()
+	require.Equal(t, msgInvalidInfoHash, announce("/announce/"+other.Passkey, other.Passkey, "12.34.56.79"))
+	leaks, err = TrapLeaks(owner.UserID)
+	require.NoError(t, err)
+	require.Len(t, leaks, 4)
+	require.False(t, leaks[3].PasskeyReset)
+}
@@ -0,0 +1,465 @@
+package tracker
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/metrics"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// udpProtocolID is the magic constant sent by clients in the connect request
+const udpProtocolID uint64 = 0x41727101980
+
+type udpAction uint32
+
+// BEP15 actions
+const (
+	udpActionConnect  udpAction = 0
+	udpActionAnnounce udpAction = 1
+	udpActionScrape   udpAction = 2
+	udpActionError    udpAction = 3
+)
+
+// BEP41 option types
+const (
+	udpOptionEndOfOptions byte = 0x0
+	udpOptionNOP          byte = 0x1
+	udpOptionURLData      byte = 0x2
+)
+
+const (
+	// udpHeaderLen is the length of the connection_id, action & transaction_id header shared by all requests
+	udpHeaderLen = 16
+	// udpAnnounceLen is the length of a announce request without any BEP41 options
+	udpAnnounceLen = 98
+	// udpMaxScrape is the maximum number of info hashes that fit into a single scrape
+	udpMaxScrape = 74
+	// udpMaxPacketSize is the largest request we will read
+	udpMaxPacketSize = 2048
+	// udpConnectionIDTTL is how often the connection id secret is rotated. Issued ids are
+	// valid for at least this long and at most twice as long.
+	udpConnectionIDTTL = time.Minute
+)
+
+// connectionIDs generates and validates BEP15 connection ids without storing any per client state.
+// Ids are a HMAC of the client address using a secret which is rotated every udpConnectionIDTTL. The
+// previous secret is retained so that ids issued just before a rotation are still accepted.
+type connectionIDs struct {
+	*sync.RWMutex
+	current  []byte
+	previous []byte
+	rotated  time.Time
+}
+
+func newConnectionIDs() *connectionIDs {
+	c := &connectionIDs{RWMutex: &sync.RWMutex{}}
+	c.rotate(time.Now())
+	return c
+}
+
+func newConnectionSecret() []byte {
+	secret, err := util.GenRandomBytes(32)
+	if err != nil {
+		log.Panicf("Failed to generate udp connection secret: %s", err)
+	}
+	return secret
+}
+
+// rotate replaces the current secret if it has expired. The caller must hold the write lock
+// or be the constructor.
+func (c *connectionIDs) rotate(now time.Time) {
+	switch age := now.Sub(c.rotated); {
+	case c.current == nil || age >= 2*udpConnectionIDTTL:
+		c.previous = newConnectionSecret()
+		c.current = newConnectionSecret()
+	case age >= udpConnectionIDTTL:
+		c.previous = c.current
+		c.current = newConnectionSecret()
+	default:
+		return
+	}
+	c.rotated = now
+}
+
+// secrets returns the current and previous secrets, taking the write lock only when the
+// current secret has expired and needs to be rotated
+func (c *connectionIDs) secrets(now time.Time) ([]byte, []byte) {
+	c.RLock()
+	current, previous := c.current, c.previous
+	expired := now.Sub(c.rotated) >= udpConnectionIDTTL
+	c.RUnlock()
+	if !expired {
+		return current, previous
+	}
+	c.Lock()
+	c.rotate(now)
+	current, previous = c.current, c.previous
+	c.Unlock()
+	return current, previous
+}
+
+// Generate returns a new connection id for the client ip
+func (c *connectionIDs) Generate(ip net.IP) uint64 {
+	current, _ := c.secrets(time.Now())
+	return connectionID(current, ip)
+}
+
+// Valid checks that the connection id was issued to the client ip and has not expired
+func (c *connectionIDs) Valid(id uint64, ip net.IP) bool {
+	current, previous := c.secrets(time.Now())
+	return hmacEqual(id, connectionID(current, ip)) || hmacEqual(id, connectionID(previous, ip))
+}
+
+func connectionID(secret []byte, ip net.IP) uint64 {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(ip.To16())
+	return binary.BigEndian.Uint64(mac.Sum(nil))
+}
+
+func hmacEqual(a, b uint64) bool {
+	var ab, bb [8]byte
+	binary.BigEndian.PutUint64(ab[:], a)
+	binary.BigEndian.PutUint64(bb[:], b)
+	return hmac.Equal(ab[:], bb[:])
+}
+
+// UDPServer implements the BEP15 UDP tracker protocol. Announces and scrapes are handled using the
+// same logic as the HTTP tracker.
+type UDPServer struct {
+	// Addr is the host:port to listen on
+	Addr    string
+	conn    net.PacketConn
+	connMu  *sync.Mutex
+	connIDs *connectionIDs
+	closed  int32
+	wg      *sync.WaitGroup
+}
+
+// NewUDPServer returns a UDPServer which will listen on the address provided
+func NewUDPServer(addr string) *UDPServer {
+	return &UDPServer{
+		Addr:    addr,
+		connMu:  &sync.Mutex{},
+		connIDs: newConnectionIDs(),
+		wg:      &sync.WaitGroup{},
+	}
+}
+
+// ListenAndServe opens the UDP socket and serves requests until Shutdown is called
+func (s *UDPServer) ListenAndServe() error {
+	conn, err := net.ListenPacket("udp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(conn)
+}
+
+// Serve reads and handles requests from the connection until Shutdown is called
+func (s *UDPServer) Serve(conn net.PacketConn) error {
+	s.connMu.Lock()
+	s.conn = conn
+	s.connMu.Unlock()
+	buf := make([]byte, udpMaxPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if atomic.LoadInt32(&s.closed) == 1 {
+				return nil
+			}
+			return err
+		}
+		udpAddr, ok := addr.(*net.UDPAddr)
+		if !ok || n < udpHeaderLen {
+			continue
+		}
+		pkt := make([]byte, n)
+		copy(pkt, buf[:n])
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handlePacket(pkt, udpAddr)
+		}()
+	}
+}
+
+// Shutdown closes the socket and waits for any in flight requests to complete
+func (s *UDPServer) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&s.closed, 1)
+	s.connMu.Lock()
+	conn := s.conn
+	s.connMu.Unlock()
+	if conn != nil {
+		if err := conn.Close(); err != nil {
+			return err
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *UDPServer) handlePacket(pkt []byte, addr *net.UDPAddr) {
+	connID := binary.BigEndian.Uint64(pkt[0:8])
+	action := udpAction(binary.BigEndian.Uint32(pkt[8:12]))
+	txID := pkt[12:16]
+	if action == udpActionConnect {
+		if connID != udpProtocolID {
+			// Not a BEP15 client, ignore it
+			return
+		}
+		resp := make([]byte, 16)
+		binary.BigEndian.PutUint32(resp[0:4], uint32(udpActionConnect))
+		copy(resp[4:8], txID)
+		binary.BigEndian.PutUint64(resp[8:16], s.connIDs.Generate(addr.IP))
+		s.write(resp, addr)
+		return
+	}
+	if !s.connIDs.Valid(connID, addr.IP) {
+		s.writeError(txID, responseStringMap[msgInvalidConnectionID].Error(), addr)
+		return
+	}
+	if ban, found := banned(addr.IP); found {
+		s.writeError(txID, banMessage(ban), addr)
+		return
+	}
+	switch action {
+	case udpActionAnnounce:
+		s.announce(pkt, txID, addr)
+	case udpActionScrape:
+		s.scrape(pkt, txID, addr)
+	default:
+		s.writeError(txID, responseStringMap[msgInvalidReqType].Error(), addr)
+	}
+}
+
+func (s *UDPServer) announce(pkt []byte, txID []byte, addr *net.UDPAddr) {
+	start := time.Now()
+	atomic.AddInt64(&metrics.AnnounceTotal, 1)
+	req, code := newUDPAnnounce(pkt, addr)
+	if code != msgOk {
+		atomic.AddInt64(&metrics.AnnounceStatusMalformed, 1)
+		s.oops(txID, code, addr)
+		return
+	}
+	usr, code := authenticate(req.Passkey)
+	if code != msgOk {
+		atomic.AddInt64(&metrics.AnnounceStatusUnauthorized, 1)
+		s.oops(txID, code, addr)
+		return
+	}
+	resp, code, reason := handleAnnounce(usr, req)
+	if code != msgOk {
+		if reason != "" {
+			s.writeError(txID, reason, addr)
+			return
+		}
+		s.oops(txID, code, addr)
+		return
+	}
+	peers := makeCompactPeers(resp.Peers, resp.PeerID, req.IPv6, req.CryptoLevel)
+	out := make([]byte, 20, 20+len(peers))
+	binary.BigEndian.PutUint32(out[0:4], uint32(udpActionAnnounce))
+	copy(out[4:8], txID)
+	binary.BigEndian.PutUint32(out[8:12], uint32(resp.Interval.Seconds()))
+	binary.BigEndian.PutUint32(out[12:16], resp.Leechers)
+	binary.BigEndian.PutUint32(out[16:20], resp.Seeders)
+	s.write(append(out, peers...), addr)
+	metrics.AddAnnounceTime(time.Since(start).Nanoseconds())
+}
+
+func (s *UDPServer) scrape(pkt []byte, txID []byte, addr *net.UDPAddr) {
+	count, urlData := udpScrapeHashes(pkt)
+	if count == 0 {
+		s.oops(txID, msgMalformedRequest, addr)
+		return
+	}
+	if count > udpMaxScrape {
+		count = udpMaxScrape
+	}
+	if _, code := authenticate(udpPasskey(urlData)); code != msgOk {
+		s.oops(txID, code, addr)
+		return
+	}
+	infoHashes := make([]store.InfoHash, count)
+	for i := range infoHashes {
+		copy(infoHashes[i][:], pkt[udpHeaderLen+i*20:udpHeaderLen+(i+1)*20])
+	}
+	out := make([]byte, 8, 8+count*12)
+	binary.BigEndian.PutUint32(out[0:4], uint32(udpActionScrape))
+	copy(out[4:8], txID)
+	var stats [12]byte
+	for _, result := range handleScrape(infoHashes) {
+		// Unknown torrents must still be included as the results are positional
+		binary.BigEndian.PutUint32(stats[0:4], result.Seeders)
+		binary.BigEndian.PutUint32(stats[4:8], result.Snatches)
+		binary.BigEndian.PutUint32(stats[8:12], result.Leechers)
+		out = append(out, stats[:]...)
+	}
+	s.write(out, addr)
+}
+
+// oops sends a error message using a preset message code constant
+func (s *UDPServer) oops(txID []byte, code errCode, addr *net.UDPAddr) {
+	msg, exists := responseStringMap[code]
+	if !exists {
+		msg = responseStringMap[msgGenericError]
+	}
+	log.Debugf("Error in udp request from: %s (%d : %s)", addr, code, msg.Error())
+	s.writeError(txID, msg.Error(), addr)
+}
+
+func (s *UDPServer) writeError(txID []byte, message string, addr *net.UDPAddr) {
+	out := make([]byte, 8, 8+len(message))
+	binary.BigEndian.PutUint32(out[0:4], uint32(udpActionError))
+	copy(out[4:8], txID)
+	s.write(append(out, message...), addr)
+}
+
+func (s *UDPServer) write(b []byte, addr *net.UDPAddr) {
+	if _, err := s.conn.WriteTo(b, addr); err != nil {
+		log.Debugf("Failed to write udp response to %s: %s", addr, err)
+	}
+}
+
+// newUDPAnnounce parses a BEP15 announce packet into a announceRequest
+func newUDPAnnounce(pkt []byte, addr *net.UDPAddr) (*announceRequest, errCode) {
+	if len(pkt) < udpAnnounceLen {
+		return nil, msgMalformedRequest
+	}
+	var req announceRequest
+	copy(req.InfoHash[:], pkt[16:36])
+	copy(req.PeerID[:], pkt[36:56])
+	req.Downloaded = binary.BigEndian.Uint64(pkt[56:64])
+	req.Left = binary.BigEndian.Uint64(pkt[64:72])
+	req.Uploaded = binary.BigEndian.Uint64(pkt[72:80])
+	switch binary.BigEndian.Uint32(pkt[80:84]) {
+	case 1:
+		req.Event = consts.COMPLETED
+	case 2:
+		req.Event = consts.STARTED
+	case 3:
+		req.Event = consts.STOPPED
+	default:
+		req.Event = consts.ANNOUNCE
+	}
+	req.IP = addr.IP
+	if ip4 := addr.IP.To4(); ip4 != nil {
+		req.IP = ip4
+	} else {
+		req.IPv6 = true
+	}
+	if ipField := pkt[84:88]; config.Tracker.AllowClientIP && binary.BigEndian.Uint32(ipField) != 0 && !req.IPv6 {
+		req.IP = net.IPv4(ipField[0], ipField[1], ipField[2], ipField[3]).To4()
+	}
+	if !config.Tracker.AllowNonRoutable && util.IsPrivateIP(req.IP) {
+		log.Warnf("Attempt to use non-routable IP value: %s", req.IP.String())
+		return nil, msgMalformedRequest
+	}
+	req.Key = fmt.Sprintf("%08x", binary.BigEndian.Uint32(pkt[88:92]))
+	req.NumWant = 30
+	if numWant := int32(binary.BigEndian.Uint32(pkt[92:96])); numWant >= 0 {
+		req.NumWant = uint(numWant)
+	}
+	req.Port = binary.BigEndian.Uint16(pkt[96:98])
+	if req.Port < 1024 {
+		// Don't allow privileged ports which require root to bind to on unix
+		return nil, msgInvalidPort
+	}
+	urlData, ok := udpURLData(pkt[udpAnnounceLen:])
+	if !ok {
+		return nil, msgMalformedRequest
+	}
+	req.Passkey = udpPasskey(urlData)
+	req.Compact = true
+	req.CryptoLevel = consts.Unencrypted
+	return &req, msgOk
+}
+
+// udpURLData parses the BEP41 options section of a request and returns the concatenated
+// contents of any URLData options. ok is false if the options are malformed.
+func udpURLData(opts []byte) (string, bool) {
+	var data []byte
+	for i := 0; i < len(opts); {
+		switch opts[i] {
+		case udpOptionEndOfOptions:
+			return string(data), true
+		case udpOptionNOP:
+			i++
+		default:
+			// All other options, including unknown ones, are length prefixed
+			if i+1 >= len(opts) {
+				return "", false
+			}
+			end := i + 2 + int(opts[i+1])
+			if end > len(opts) {
+				return "", false
+			}
+			if opts[i] == udpOptionURLData {
+				data = append(data, opts[i+2:end]...)
+			}
+			i = end
+		}
+	}
+	return string(data), true
+}
+
+// udpScrapeHashes determines how many info hashes are contained in a scrape request. Scrape
+// packets have no length field so the shortest run of info hashes followed by options
+// containing URLData is used, otherwise the entire packet is considered to be info hashes.
+func udpScrapeHashes(pkt []byte) (int, string) {
+	body := pkt[udpHeaderLen:]
+	for n := 1; n*20 < len(body); n++ {
+		urlData, ok := udpURLData(body[n*20:])
+		if ok && urlData != "" {
+			return n, urlData
+		}
+	}
+	return len(body) / 20, ""
+}
+
+// udpPasskey extracts the passkey from BEP41 URL data using the same routes as the HTTP
+// tracker: /announce/:passkey and /scrape/:passkey. The /:passkey/announce form is
+// also accepted. An empty string is returned if no passkey is present.
+func udpPasskey(urlData string) string {
+	if urlData == "" {
+		return ""
+	}
+	u, err := url.Parse(urlData)
+	if err != nil {
+		log.Debugf("Failed to parse udp url data: %s", err)
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 {
+		return ""
+	}
+	switch {
+	case parts[0] == "announce" || parts[0] == "scrape":
+		return parts[1]
+	case parts[1] == "announce" || parts[1] == "scrape":
+		return parts[0]
+	default:
+		return ""
+	}
+}
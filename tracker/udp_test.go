@@ -0,0 +1,188 @@
+package tracker
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestUDPServer(t *testing.T) net.Conn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := NewUDPServer(conn.LocalAddr().String())
+	go func() {
+		_ = srv.Serve(conn)
+	}()
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = srv.Shutdown(context.Background())
+	})
+	return client
+}
+
+func udpRequest(t *testing.T, c net.Conn, req []byte) []byte {
+	_, err := c.Write(req)
+	require.NoError(t, err)
+	require.NoError(t, c.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, udpMaxPacketSize)
+	n, err := c.Read(buf)
+	require.NoError(t, err)
+	return buf[:n]
+}
+
+func udpHeader(connID uint64, action udpAction, txID uint32) []byte {
+	b := make([]byte, udpHeaderLen)
+	binary.BigEndian.PutUint64(b[0:8], connID)
+	binary.BigEndian.PutUint32(b[8:12], uint32(action))
+	binary.BigEndian.PutUint32(b[12:16], txID)
+	return b
+}
+
+func udpConnect(t *testing.T, c net.Conn) uint64 {
+	resp := udpRequest(t, c, udpHeader(udpProtocolID, udpActionConnect, 1))
+	require.Len(t, resp, 16)
+	require.Equal(t, uint32(udpActionConnect), binary.BigEndian.Uint32(resp[0:4]))
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(resp[4:8]))
+	return binary.BigEndian.Uint64(resp[8:16])
+}
+
+func udpAnnounceReq(connID uint64, ih store.InfoHash, pid store.PeerID, left uint64, event uint32, ip net.IP) []byte {
+	b := udpHeader(connID, udpActionAnnounce, 2)
+	b = append(b, ih.Bytes()...)
+	b = append(b, pid.Bytes()...)
+	var body [42]byte
+	binary.BigEndian.PutUint64(body[8:16], left)
+	binary.BigEndian.PutUint32(body[24:28], event)
+	copy(body[28:32], ip.To4())
+	binary.BigEndian.PutUint32(body[36:40], 0xffffffff)
+	binary.BigEndian.PutUint16(body[40:42], 4000)
+	return append(b, body[:]...)
+}
+
+func TestConnectionIDs(t *testing.T) {
+	c := newConnectionIDs()
+	ip := net.ParseIP("12.34.56.78")
+	id := c.Generate(ip)
+	require.True(t, c.Valid(id, ip))
+	require.False(t, c.Valid(id, net.ParseIP("12.34.56.79")))
+	// Rotated once, previous secret still valid
+	c.rotated = c.rotated.Add(-udpConnectionIDTTL)
+	require.True(t, c.Valid(id, ip))
+	// Rotated twice, expired
+	c.rotated = c.rotated.Add(-udpConnectionIDTTL)
+	require.False(t, c.Valid(id, ip))
+}
+
+func TestUDPServer(t *testing.T) {
+	config.Tracker.Public = true
+	// Registered before the server so it's reset after shutdown
+	t.Cleanup(func() { config.Tracker.Public = false })
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	c := newTestUDPServer(t)
+
+	// Requests without a valid connection id are rejected
+	resp := udpRequest(t, c, udpAnnounceReq(12345, tor.InfoHash, testSeeders[0].PeerID, 0, 2,
+		net.ParseIP("12.34.56.78")))
+	require.Equal(t, uint32(udpActionError), binary.BigEndian.Uint32(resp[0:4]))
+	require.Equal(t, responseStringMap[msgInvalidConnectionID].Error(), string(resp[8:]))
+
+	connID := udpConnect(t, c)
+	resp = udpRequest(t, c, udpAnnounceReq(connID, tor.InfoHash, testSeeders[0].PeerID, 0, 2,
+		net.ParseIP("12.34.56.78")))
+	require.Equal(t, uint32(udpActionAnnounce), binary.BigEndian.Uint32(resp[0:4]), string(resp[8:]))
+	require.Equal(t, uint32(2), binary.BigEndian.Uint32(resp[4:8]))
+	require.Equal(t, uint32(config.Tracker.AnnounceIntervalParsed.Seconds()), binary.BigEndian.Uint32(resp[8:12]))
+
+	// Non-routable client supplied addresses are refused
+	resp = udpRequest(t, c, udpAnnounceReq(connID, tor.InfoHash, testLeechers[0].PeerID, 100, 2,
+		net.ParseIP("10.0.0.1")))
+	require.Equal(t, uint32(udpActionError), binary.BigEndian.Uint32(resp[0:4]))
+
+	unknown := store.GenerateTestTorrent()
+	scrapeReq := udpHeader(connID, udpActionScrape, 3)
+	scrapeReq = append(scrapeReq, tor.InfoHash.Bytes()...)
+	scrapeReq = append(scrapeReq, unknown.InfoHash.Bytes()...)
+	resp = udpRequest(t, c, scrapeReq)
+	require.Equal(t, uint32(udpActionScrape), binary.BigEndian.Uint32(resp[0:4]))
+	require.Len(t, resp, 8+2*12)
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(resp[8:12]))
+	require.Equal(t, uint32(0), binary.BigEndian.Uint32(resp[16:20]))
+	require.Equal(t, make([]byte, 12), resp[20:32])
+}
+
+func udpURLDataOption(data string) []byte {
+	var b []byte
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > 255 {
+			chunk = chunk[:255]
+		}
+		b = append(b, udpOptionURLData, byte(len(chunk)))
+		b = append(b, chunk...)
+		data = data[len(chunk):]
+	}
+	return append(b, udpOptionEndOfOptions)
+}
+
+func TestUDPURLData(t *testing.T) {
+	data, ok := udpURLData(udpURLDataOption("/announce/" + strings.Repeat("x", 300)))
+	require.True(t, ok)
+	require.Equal(t, "/announce/"+strings.Repeat("x", 300), data)
+	data, ok = udpURLData([]byte{udpOptionNOP, udpOptionURLData, 3, '/', 'a', 'b', udpOptionNOP,
+		udpOptionURLData, 2, 'c', 'd'})
+	require.True(t, ok)
+	require.Equal(t, "/abcd", data)
+	_, ok = udpURLData([]byte{udpOptionURLData, 10, '/', 'a'})
+	require.False(t, ok)
+	data, ok = udpURLData(nil)
+	require.True(t, ok)
+	require.Equal(t, "", data)
+
+	for path, pk := range map[string]string{
+		"/announce/abc":      "abc",
+		"/scrape/abc?x=1":    "abc",
+		"/abc/announce":      "abc",
+		"/announce":          "",
+		"/foo/bar":           "",
+		"/announce/abc/junk": "",
+	} {
+		require.Equal(t, pk, udpPasskey(path), path)
+	}
+}
+
+func TestUDPServerPasskey(t *testing.T) {
+	tor := store.GenerateTestTorrent()
+	require.NoError(t, TorrentAdd(&tor))
+	c := newTestUDPServer(t)
+	connID := udpConnect(t, c)
+	ip := net.ParseIP("12.34.56.78")
+
+	resp := udpRequest(t, c, udpAnnounceReq(connID, tor.InfoHash, testSeeders[0].PeerID, 0, 2, ip))
+	require.Equal(t, uint32(udpActionError), binary.BigEndian.Uint32(resp[0:4]))
+	require.Equal(t, responseStringMap[msgInvalidAuth].Error(), string(resp[8:]))
+
+	req := udpAnnounceReq(connID, tor.InfoHash, testSeeders[0].PeerID, 0, 2, ip)
+	req = append(req, udpURLDataOption("/announce/"+testUsers[0].Passkey+"?foo=bar")...)
+	resp = udpRequest(t, c, req)
+	require.Equal(t, uint32(udpActionAnnounce), binary.BigEndian.Uint32(resp[0:4]), string(resp[8:]))
+
+	scrapeReq := udpHeader(connID, udpActionScrape, 3)
+	scrapeReq = append(scrapeReq, tor.InfoHash.Bytes()...)
+	resp = udpRequest(t, c, scrapeReq)
+	require.Equal(t, uint32(udpActionError), binary.BigEndian.Uint32(resp[0:4]))
+
+	scrapeReq = append(scrapeReq, udpURLDataOption("/scrape/"+testUsers[0].Passkey)...)
+	resp = udpRequest(t, c, scrapeReq)
+	require.Equal(t, uint32(udpActionScrape), binary.BigEndian.Uint32(resp[0:4]), string(resp[8:]))
+	require.Len(t, resp, 8+12)
+	require.Equal(t, uint32(1), binary.BigEndian.Uint32(resp[8:12]))
+}
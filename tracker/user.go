@@ -4,10 +4,19 @@ import (
 	"github.com/viciious/mika/consts"
 	"github.com/viciious/mika/store"
 	"github.com/viciious/mika/util"
+	"github.com/pkg/errors"
+	"sync/atomic"
 )
 
+// Users returns a copy of the users map so it can be iterated without holding usersMu
 func Users() store.Users {
-	return users
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+	all := make(store.Users, len(users))
+	for passkey, u := range users {
+		all[passkey] = u
+	}
+	return all
 }
 
 func UserAdd(user *store.User) error {
@@ -17,12 +26,19 @@ func UserAdd(user *store.User) error {
 	if err := db.UserAdd(user); err != nil {
 		return err
 	}
+	if user.Role == nil {
+		mapRoleToUser(user)
+	}
+	usersMu.Lock()
 	users[user.Passkey] = user
+	usersMu.Unlock()
 	return nil
 }
 
 func UserGetByPasskey(passkey string) (*store.User, error) {
+	usersMu.RLock()
 	u, found := users[passkey]
+	usersMu.RUnlock()
 	if !found {
 		return nil, consts.ErrInvalidUser
 	}
@@ -30,6 +46,8 @@ func UserGetByPasskey(passkey string) (*store.User, error) {
 }
 
 func UserGetByUserID(userID uint32) (*store.User, error) {
+	usersMu.RLock()
+	defer usersMu.RUnlock()
 	for _, u := range users {
 		if u.UserID == userID {
 			return u, nil
@@ -39,6 +57,8 @@ func UserGetByUserID(userID uint32) (*store.User, error) {
 }
 
 func UserGetByRemoteID(remoteID uint64) (*store.User, error) {
+	usersMu.RLock()
+	defer usersMu.RUnlock()
 	for _, u := range users {
 		if u.RemoteID == remoteID {
 			return u, nil
@@ -51,10 +71,47 @@ func UserSave(user *store.User) error {
 	return db.UserSave(user)
 }
 
+// UserChangePasskey replaces the passkey of the user with the one given. Unlike PasskeyRotate
+// the old passkey stops working immediately.
+func UserChangePasskey(user *store.User, passkey string) error {
+	if passkey == "" {
+		return errors.Wrap(consts.ErrMalformedRequest, "Passkey cannot be empty")
+	}
+	if passkey == user.Passkey {
+		return nil
+	}
+	if _, err := UserGetByPasskey(passkey); err == nil || passkeyRetired(passkey) {
+		return consts.ErrDuplicate
+	}
+	return userChangePasskey(user, passkey)
+}
+
+// userChangePasskey replaces the passkey of the user, moving them to the new passkey in the
+// users map once saved
+func userChangePasskey(user *store.User, passkey string) error {
+	oldPasskey := user.Passkey
+	user.Passkey = passkey
+	if err := UserSave(user); err != nil {
+		user.Passkey = oldPasskey
+		return err
+	}
+	usersMu.Lock()
+	delete(users, oldPasskey)
+	users[passkey] = user
+	usersMu.Unlock()
+	return nil
+}
+
 func userSync(batch []*store.User) error {
-	if err := db.UserSync(batch); err != nil {
+	if len(batch) == 0 {
+		return nil
+	}
+	if err := db.UserSync(bonusSnapshot(batch)); err != nil {
 		return err
 	}
+	for _, u := range batch {
+		atomic.SwapUint32(&u.Writes, 0)
+	}
 	return nil
 }
 
@@ -65,6 +122,8 @@ func UserDelete(user *store.User) error {
 	if err := UserSave(user); err != nil {
 		return err
 	}
+	usersMu.Lock()
 	delete(users, user.Passkey)
+	usersMu.Unlock()
 	return nil
 }
@@ -0,0 +1,175 @@
+package tracker
+
+import (
+	"fmt"
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/store"
+	"github.com/viciious/mika/util"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// userAgentReportWindow is how long a mismatched User-Agent is only reported once for, the
+// mismatch is still acted on for every announce
+const userAgentReportWindow = 24 * time.Hour
+
+// userAgentReportKey identifies the mismatches reported together
+type userAgentReportKey struct {
+	UserID    uint32
+	Client    string
+	UserAgent string
+}
+
+var (
+	userAgentMu = &sync.RWMutex{}
+	// userAgentReported holds when each mismatch was last reported
+	userAgentReported = make(map[userAgentReportKey]time.Time)
+)
+
+// userAgentAliases maps the client names parsed from peer ids to the other names the clients
+// send in their User-Agent
+var userAgentAliases = map[string][]string{
+	"µTorrent":            {"uTorrent"},
+	"µTorrent for Mac":    {"uTorrentMac", "uTorrent"},
+	"mainline BitTorrent": {"BitTorrent"},
+	"DelugeTorrent":       {"Deluge"},
+	"libTorrent":          {"rtorrent"},
+	"Azureus":             {"Vuze"},
+}
+
+// clientNameKey normalises a client name so names which only differ in case, spacing or
+// punctuation are equal
+func clientNameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == 'µ':
+			b.WriteRune('u')
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// parseUserAgent returns the name and version of the first product in the User-Agent, eg:
+// "qBittorrent/4.3.3" or "Deluge 1.3.15". The version is only returned if one was found.
+// Condensed versions without dots, such as the 3550 of µTorrent 3.5.5.0, are split into
+// their digits.
+func parseUserAgent(userAgent string) (string, []int) {
+	fields := strings.Fields(userAgent)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	name, version := fields[0], ""
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		name, version = name[:i], name[i+1:]
+	} else if len(fields) > 1 {
+		version = fields[1]
+	}
+	version = strings.TrimPrefix(strings.ToLower(version), "v")
+	var parts []int
+	for _, p := range strings.Split(version, ".") {
+		end := 0
+		for end < len(p) && p[end] >= '0' && p[end] <= '9' {
+			end++
+		}
+		n, err := strconv.Atoi(p[:end])
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+		if end < len(p) {
+			// Trailing build info such as 3550(45555)
+			break
+		}
+	}
+	if len(parts) == 1 && parts[0] >= 100 && !strings.Contains(version, ".") {
+		digits := strconv.Itoa(parts[0])
+		parts = make([]int, len(digits))
+		for i, d := range digits {
+			parts[i] = int(d - '0')
+		}
+	}
+	return name, parts
+}
+
+// userAgentMismatch compares the User-Agent with the client identified from the peer id,
+// returning a description of the difference or an empty string if they agree. Announces without
+// a User-Agent, such as UDP announces, or from clients unknown to ClientString are not checked.
+func userAgentMismatch(userAgent string, peerID store.PeerID) string {
+	client := store.ClientString(peerID)
+	if userAgent == "" || client.Name == "" || client.Name == "Unknown" {
+		return ""
+	}
+	name, version := parseUserAgent(userAgent)
+	names := append([]string{client.Name}, userAgentAliases[client.Name]...)
+	for configured, aliases := range config.Tracker.Cheat.UserAgentAliases {
+		if strings.EqualFold(configured, client.Name) {
+			names = append(names, aliases...)
+		}
+	}
+	matched := false
+	for _, n := range names {
+		if clientNameKey(n) == clientNameKey(name) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return fmt.Sprintf("User-Agent %q does not match the peer id client %s", userAgent, client)
+	}
+	if config.Tracker.Cheat.UserAgentVersion && len(version) > 0 && version[0] != client.Major {
+		return fmt.Sprintf("User-Agent %q does not match the peer id client version %s", userAgent, client)
+	}
+	return ""
+}
+
+// userAgentReportDue checks if the mismatch of the users client has not been reported within
+// the report window, marking it as reported when it has not
+func userAgentReportDue(userID uint32, peerID store.PeerID, userAgent string, now time.Time) bool {
+	key := userAgentReportKey{UserID: userID, Client: store.ClientString(peerID).String(), UserAgent: userAgent}
+	userAgentMu.Lock()
+	defer userAgentMu.Unlock()
+	if last, found := userAgentReported[key]; found && now.Sub(last) < userAgentReportWindow {
+		return false
+	}
+	userAgentReported[key] = now
+	return true
+}
+
+// pruneUserAgentReports forgets the mismatches reported before the report window
+func pruneUserAgentReports(now time.Time) {
+	userAgentMu.Lock()
+	defer userAgentMu.Unlock()
+	for key, last := range userAgentReported {
+		if now.Sub(last) >= userAgentReportWindow {
+			delete(userAgentReported, key)
+		}
+	}
+}
+
+// userAgentRejected records a cheat report and returns true when the user agent rule rejects
+// mismatched announces and the announce is mismatched
+func userAgentRejected(req *announceRequest, tor *store.Torrent, user *store.User) bool {
+	rules := config.Tracker.Cheat
+	if !rules.UserAgent || rules.UserAgentAction != config.CheatReject {
+		return false
+	}
+	mismatch := userAgentMismatch(req.UserAgent, req.PeerID)
+	if mismatch == "" {
+		return false
+	}
+	now := util.Now()
+	if !userAgentReportDue(user.UserID, req.PeerID, req.UserAgent, now) {
+		return true
+	}
+	peer := store.NewPeer(user.UserID, req.PeerID, req.IP, req.Port)
+	peer.Client = store.ClientString(req.PeerID).String()
+	peer.UserAgent = req.UserAgent
+	flagCheat(peer, tor, user, cheatUserAgent, rules.UserAgentAction, 0, now, mismatch, 0)
+	return true
+}
@@ -0,0 +1,121 @@
+package tracker
+
+import (
+	"github.com/viciious/mika/config"
+	"github.com/viciious/mika/consts"
+	"github.com/viciious/mika/store"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseUserAgent(t *testing.T) {
+	for _, c := range []struct {
+		userAgent string
+		name      string
+		version   []int
+	}{
+		{"qBittorrent/4.3.3", "qBittorrent", []int{4, 3, 3}},
+		{"Deluge/2.0.3 libtorrent/1.2.5.0", "Deluge", []int{2, 0, 3}},
+		{"Deluge 1.3.15", "Deluge", []int{1, 3, 15}},
+		{"qBittorrent v4.1.5", "qBittorrent", []int{4, 1, 5}},
+		{"uTorrent/3550(45555)", "uTorrent", []int{3, 5, 5, 0}},
+		{"BitTorrent/7100", "BitTorrent", []int{7, 1, 0, 0}},
+		{"Tixati 23", "Tixati", []int{23}},
+		{"Transmission/3.00", "Transmission", []int{3, 0}},
+		{"rtorrent/0.9.6/0.13.6", "rtorrent", []int{0, 9, 6}},
+		{"curl", "curl", nil},
+		{"", "", nil},
+	} {
+		name, version := parseUserAgent(c.userAgent)
+		require.Equal(t, c.name, name, c.userAgent)
+		require.Equal(t, c.version, version, c.userAgent)
+	}
+}
+
+func TestUserAgentMismatch(t *testing.T) {
+	rules := config.Tracker.Cheat
+	defer func() { config.Tracker.Cheat = rules }()
+	config.Tracker.Cheat = config.CheatRules{UserAgent: true}
+	qb := store.PeerIDFromString("-qB4330-u-rGseINmloG")
+	require.Empty(t, userAgentMismatch("qBittorrent/4.3.3", qb))
+	require.Empty(t, userAgentMismatch("qbittorrent/3.3.16", qb))
+	require.Empty(t, userAgentMismatch("", qb))
+	require.NotEmpty(t, userAgentMismatch("Transmission/3.00", qb))
+	// Unknown peer id clients cannot be compared
+	require.Empty(t, userAgentMismatch("Transmission/3.00", store.PeerIDFromString("--------u-rGseINmloG")))
+	// Built in aliases
+	require.Empty(t, userAgentMismatch("Deluge 1.3.15", store.PeerIDFromString("-DE1390-u-rGseINmloG")))
+
+	config.Tracker.Cheat.UserAgentVersion = true
+	require.Empty(t, userAgentMismatch("qBittorrent/4.3.3", qb))
+	require.NotEmpty(t, userAgentMismatch("qBittorrent/3.3.16", qb))
+	// µTorrent condenses its version into a single number
+	ut := store.PeerIDFromString("-UT3550-u-rGseINmloG")
+	require.Empty(t, userAgentMismatch("uTorrent/3550(45555)", ut))
+	require.NotEmpty(t, userAgentMismatch("uTorrent/2210(25130)", ut))
+
+	tr := store.PeerIDFromString("-TR3000-u-rGseINmloG")
+	require.NotEmpty(t, userAgentMismatch("Tixati/2.73", tr))
+	config.Tracker.Cheat.UserAgentAliases = map[string][]string{"transmission": {"Tixati"}}
+	require.Empty(t, userAgentMismatch("Tixati 3.0", tr))
+}
+
+func TestBitTorrentHandler_AnnounceUserAgent(t *testing.T) {
+	rules := config.Tracker.Cheat
+	defer func() { config.Tracker.Cheat = rules }()
+	s := newTestSwarm(t)
+	usr, tor := s.usr, s.tor
+	announce := func(peerID string, userAgent string, uploaded string, event consts.AnnounceType) errCode {
+		code, _ := s.announce(testReq{PID: store.PeerIDFromString(peerID), Uploaded: uploaded, event: string(event),
+			userAgent: userAgent})
+		return code
+	}
+
+	config.Tracker.Cheat = config.CheatRules{UserAgent: true, UserAgentAction: config.CheatReject}
+	require.Equal(t, msgOk, announce("-qB4330-aaaaaaaaaaaa", "qBittorrent/4.3.3", "0", consts.STARTED))
+	peer, err := tor.Peers.Get(store.PeerIDFromString("-qB4330-aaaaaaaaaaaa"))
+	require.NoError(t, err)
+	require.Equal(t, "qBittorrent/4.3.3", peer.UserAgent)
+	require.Equal(t, msgClientMismatch, announce("-qB4330-bbbbbbbbbbbb", "Transmission/3.00", "0", consts.STARTED))
+	_, err = tor.Peers.Get(store.PeerIDFromString("-qB4330-bbbbbbbbbbbb"))
+	require.Equal(t, consts.ErrInvalidPeerID, err)
+	reports, err := CheatReports(usr.UserID, cheatUserAgent)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	require.Equal(t, config.CheatReject, reports[0].Action)
+	require.Equal(t, "qBittorrent 4.3.3.0", reports[0].Client)
+	require.Contains(t, reports[0].Detail, "Transmission/3.00")
+	// Repeated mismatches are still rejected but only reported once
+	require.Equal(t, msgClientMismatch, announce("-qB4330-bbbbbbbbbbbb", "Transmission/3.00", "0", consts.STARTED))
+	reports, err = CheatReports(usr.UserID, cheatUserAgent)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	// Other actions only apply to the upload of the announce, and are checked whether or not
+	// anything was uploaded. The User-Agent is updated on every announce.
+	config.Tracker.Cheat.UserAgentAction = config.CheatDrop
+	require.Equal(t, msgOk, announce("-qB4330-cccccccccccc", "qBittorrent/4.3.3", "0", consts.STARTED))
+	require.Equal(t, msgOk, announce("-qB4330-cccccccccccc", "Deluge 2.0.3", "0", consts.ANNOUNCE))
+	peer, err = tor.Peers.Get(store.PeerIDFromString("-qB4330-cccccccccccc"))
+	require.NoError(t, err)
+	require.Equal(t, "Deluge 2.0.3", peer.UserAgent)
+	reports, err = CheatReports(usr.UserID, cheatUserAgent)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, config.CheatDrop, reports[1].Action)
+	require.Contains(t, reports[1].Detail, "Deluge 2.0.3")
+	uploaded := usr.Uploaded
+	require.Equal(t, msgOk, announce("-qB4330-cccccccccccc", "Deluge 2.0.3", "1000", consts.ANNOUNCE))
+	require.Equal(t, uploaded, usr.Uploaded)
+	reports, err = CheatReports(usr.UserID, cheatUserAgent)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+
+	// Reported again once the window passes
+	pruneUserAgentReports(time.Now().Add(userAgentReportWindow))
+	require.Equal(t, msgOk, announce("-qB4330-cccccccccccc", "Deluge 2.0.3", "0", consts.ANNOUNCE))
+	reports, err = CheatReports(usr.UserID, cheatUserAgent)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+}
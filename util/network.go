@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"net"
+	"strings"
 )
 
 var privateIPBlocks []*net.IPNet
@@ -38,3 +39,39 @@ func IsPrivateIP(ip net.IP) bool {
 	}
 	return false
 }
+
+// ParseCIDRs parses a list of CIDR ranges. Plain addresses are accepted as a range holding
+// only that address.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address: %s", cidr)
+			}
+			bits := net.IPv6len * 8
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, net.IPv4len*8
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, block, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr: %s", cidr)
+		}
+		nets = append(nets, block)
+	}
+	return nets, nil
+}
+
+// IPInNets returns true if the IP provided is contained in any of the ranges
+func IPInNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, block := range nets {
+		if block.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -23,3 +23,18 @@ func TestIsPrivateIP(t *testing.T) {
 		require.Equal(t, i.private, IsPrivateIP(net.ParseIP(i.ip)))
 	}
 }
+
+func TestParseCIDRs(t *testing.T) {
+	nets, err := ParseCIDRs([]string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32", "2001:db8:1::1"})
+	require.NoError(t, err)
+	require.Len(t, nets, 4)
+	require.True(t, IPInNets(net.ParseIP("10.1.2.3"), nets))
+	require.True(t, IPInNets(net.ParseIP("192.0.2.1"), nets))
+	require.False(t, IPInNets(net.ParseIP("192.0.2.2"), nets))
+	require.True(t, IPInNets(net.ParseIP("2001:db8:ffff::1"), nets))
+	require.False(t, IPInNets(net.ParseIP("8.8.8.8"), nets))
+	_, err = ParseCIDRs([]string{"10.0.0.0/33"})
+	require.Error(t, err)
+	_, err = ParseCIDRs([]string{"not an ip"})
+	require.Error(t, err)
+}